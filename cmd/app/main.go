@@ -2,19 +2,32 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/app"
 	"github.com/IncubusX/go-todo-app/internal/controller/http/v1"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/mail"
+	"github.com/IncubusX/go-todo-app/internal/notify"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
 	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/IncubusX/go-todo-app/internal/repository/memory"
 	postgres "github.com/IncubusX/go-todo-app/internal/repository/postgres"
 	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/IncubusX/go-todo-app/internal/storage"
+	"github.com/IncubusX/go-todo-app/internal/worker"
 	"github.com/jmoiron/sqlx"
 	"github.com/joho/godotenv"
 	_ "github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 )
 
 const serverClosed = "http: Server closed"
@@ -53,8 +66,48 @@ func main() {
 		logrus.Fatalf("Ошибка при инициализации БД: %s", err.Error())
 	}
 
+	keys, err := loadKeyRing()
+	if err != nil {
+		logrus.Fatalf("Ошибка при загрузке ключей подписи токенов: %s", err.Error())
+	}
+
+	providers, err := loadOIDCProviders()
+	if err != nil {
+		logrus.Fatalf("Ошибка при чтении настроек OIDC: %s", err.Error())
+	}
+
+	mailer, err := newMailer()
+	if err != nil {
+		logrus.Fatalf("Ошибка при настройке отправки почты: %s", err.Error())
+	}
+
+	blobs, err := newBlobStore()
+	if err != nil {
+		logrus.Fatalf("Ошибка при настройке хранилища вложений: %s", err.Error())
+	}
+
 	repos := repository.NewRepository(db)
-	services := service.NewService(repos)
+	if viper.GetString("auth.lockout.store") == "memory" {
+		repos.LoginAttempt = memory.NewLoginAttempt()
+	}
+	services := service.NewService(repos, service.Deps{
+		Hasher:            hash.NewArgon2idHasher(hash.DefaultArgon2idParams, os.Getenv("JWT_SALT")),
+		Keys:              keys,
+		AccessTokenTTL:    viper.GetDuration("auth.accessTokenTTL"),
+		RefreshTokenTTL:   viper.GetDuration("auth.refreshTokenTTL"),
+		OIDCProviders:     providers,
+		Mailer:            mailer,
+		AppURL:            viper.GetString("appUrl"),
+		Blobs:             blobs,
+		MaxAttachmentSize: viper.GetInt64("attachments.maxSize"),
+		Lockout: service.LockoutPolicy{
+			Threshold:   viper.GetInt("auth.lockout.threshold"),
+			IPThreshold: viper.GetInt("auth.lockout.ipThreshold"),
+			Window:      viper.GetDuration("auth.lockout.window"),
+			BaseDelay:   viper.GetDuration("auth.lockout.baseDelay"),
+			MaxDelay:    viper.GetDuration("auth.lockout.maxDelay"),
+		},
+	})
 	handlers := v1.NewHandler(services)
 
 	srv := new(app.Server)
@@ -66,7 +119,27 @@ func main() {
 
 	logrus.Println("HTTP Сервер запущен!")
 
-	gracefulShutdown(srv, db)
+	reminders := worker.NewReminderWorker(repos.Reminder, map[string]notify.Notifier{
+		entity.ReminderChannelInbox:   notify.NewInboxNotifier(repos.Notification),
+		entity.ReminderChannelEmail:   notify.NewEmailNotifier(mailer, viper.GetString("appUrl")),
+		entity.ReminderChannelWebhook: notify.NewWebhookNotifier(notify.NewWebhookClient(viper.GetDuration("reminders.timeout"))),
+	}, worker.ReminderConfig{
+		Interval:    viper.GetDuration("reminders.interval"),
+		MaxAttempts: viper.GetInt("reminders.maxAttempts"),
+		BaseDelay:   viper.GetDuration("reminders.baseDelay"),
+		MaxDelay:    viper.GetDuration("reminders.maxDelay"),
+		Timeout:     viper.GetDuration("reminders.timeout"),
+	})
+	go reminders.Run()
+
+	collector := worker.NewBlobCollector(repos.Attachment, blobs, worker.BlobCollectorConfig{
+		Interval:  viper.GetDuration("attachments.collector.interval"),
+		BatchSize: viper.GetInt("attachments.collector.batchSize"),
+		Timeout:   viper.GetDuration("attachments.collector.timeout"),
+	})
+	go collector.Run()
+
+	gracefulShutdown(srv, reminders, collector, db)
 }
 
 func initConfig() error {
@@ -75,7 +148,85 @@ func initConfig() error {
 	return viper.ReadInConfig()
 }
 
-func gracefulShutdown(srv *app.Server, db *sqlx.DB) {
+// loadKeyRing загружает ключи подписи из файлов, перечисленных в конфигурации.
+// Без них используется прежний симметричный ключ из JWT_SIGNING_KEY.
+func loadKeyRing() (*keyring.KeyRing, error) {
+	var keys []struct {
+		Id   string
+		File string
+	}
+	if err := viper.UnmarshalKey("auth.signingKeys.keys", &keys); err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		logrus.Warn("Ключи подписи не настроены, используется HS256 и JWT_SIGNING_KEY")
+		return keyring.NewKeyRing("default", keyring.NewHMACKey("default", []byte(os.Getenv("JWT_SIGNING_KEY"))))
+	}
+
+	files := make(map[string]string, len(keys))
+	for _, key := range keys {
+		files[key.Id] = key.File
+	}
+
+	return keyring.Load(viper.GetString("auth.signingKeys.active"), files)
+}
+
+func loadOIDCProviders() ([]*oidc.Provider, error) {
+	var configs []oidc.Config
+	if err := viper.UnmarshalKey("oidc.providers", &configs); err != nil {
+		return nil, err
+	}
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	providers := make([]*oidc.Provider, 0, len(configs))
+	for _, config := range configs {
+		config.ClientSecret = os.Getenv("OIDC_" + strings.ToUpper(config.Name) + "_CLIENT_SECRET")
+		providers = append(providers, oidc.NewProvider(config, client))
+	}
+
+	return providers, nil
+}
+
+func newMailer() (mail.Mailer, error) {
+	switch driver := viper.GetString("mail.driver"); driver {
+	case "smtp":
+		return mail.NewSMTPMailer(mail.SMTPConfig{
+			Host:     viper.GetString("mail.smtp.host"),
+			Port:     viper.GetString("mail.smtp.port"),
+			Username: viper.GetString("mail.smtp.username"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     viper.GetString("mail.from"),
+		}), nil
+	case "file":
+		return mail.NewFileMailer(viper.GetString("mail.dir"), viper.GetString("mail.from"))
+	case "log", "":
+		return mail.NewLogMailer(), nil
+	default:
+		return nil, fmt.Errorf("unknown mail driver %q", driver)
+	}
+}
+
+func newBlobStore() (storage.BlobStore, error) {
+	switch driver := viper.GetString("attachments.storage"); driver {
+	case "s3":
+		return storage.NewS3Store(storage.S3Config{
+			Endpoint:  viper.GetString("attachments.s3.endpoint"),
+			Region:    viper.GetString("attachments.s3.region"),
+			Bucket:    viper.GetString("attachments.s3.bucket"),
+			AccessKey: viper.GetString("attachments.s3.accessKey"),
+			SecretKey: os.Getenv("S3_SECRET_KEY"),
+			PathStyle: viper.GetBool("attachments.s3.pathStyle"),
+		}, &http.Client{Timeout: viper.GetDuration("attachments.s3.timeout")})
+	case "local", "":
+		return storage.NewLocalStore(viper.GetString("attachments.dir"))
+	default:
+		return nil, fmt.Errorf("unknown attachments storage %q", driver)
+	}
+}
+
+// gracefulShutdown останавливает HTTP сервер, затем дожидается текущей доставки напоминаний и удаления файлов
+// и только после этого закрывает БД, чтобы результат доставки успел сохраниться.
+func gracefulShutdown(srv *app.Server, reminders *worker.ReminderWorker, collector *worker.BlobCollector, db *sqlx.DB) {
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
 	<-quit
@@ -84,6 +235,18 @@ func gracefulShutdown(srv *app.Server, db *sqlx.DB) {
 		logrus.Fatalf("Ошибка во время остановки HTTP Сервера: %s", err.Error())
 	}
 
+	ctx, cancel := context.WithTimeout(context.Background(), 2*viper.GetDuration("reminders.timeout"))
+	defer cancel()
+	if err := reminders.Shutdown(ctx); err != nil {
+		logrus.Errorf("Ошибка во время остановки рассылки напоминаний: %s", err.Error())
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), 2*viper.GetDuration("attachments.collector.timeout"))
+	defer cancel()
+	if err := collector.Shutdown(ctx); err != nil {
+		logrus.Errorf("Ошибка во время остановки удаления файлов вложений: %s", err.Error())
+	}
+
 	if err := db.Close(); err != nil {
 		logrus.Fatalf("Ошибка во время остановки БД: %s", err.Error())
 	}
@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// @Summary		Create personal access token
+// @Security		ApiKeyAuth
+// @Tags			tokens
+// @Description	Выпуск персонального токена доступа для скриптов и CI. Значение токена показывается только один раз
+// @ID				create-access-token
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.CreatePersonalAccessTokenInput	true	"token info"
+// @Success		200		{object}	entity.PersonalAccessToken
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/tokens [post]
+func (h *Handler) createAccessToken(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.CreatePersonalAccessTokenInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	token, err := h.services.PersonalAccessToken.Create(userId, input)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, token)
+}
+
+type getAllAccessTokensResponse struct {
+	Data []entity.PersonalAccessToken `json:"data"`
+}
+
+// @Summary		Get all personal access tokens
+// @Security		ApiKeyAuth
+// @Tags			tokens
+// @Description	Список действующих персональных токенов
+// @ID				get-all-access-tokens
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllAccessTokensResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/tokens [get]
+func (h *Handler) getAllAccessTokens(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	tokens, err := h.services.PersonalAccessToken.GetAll(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllAccessTokensResponse{
+		Data: tokens,
+	})
+}
+
+// @Summary		Delete personal access token
+// @Security		ApiKeyAuth
+// @Tags			tokens
+// @Description	Отзыв персонального токена
+// @ID				delete-access-token
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Token ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/tokens/{id} [delete]
+func (h *Handler) deleteAccessToken(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	tokenId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.PersonalAccessToken.Revoke(userId, tokenId); err != nil {
+		if errors.Is(err, service.ErrAccessTokenNotFound) {
+			newErrorResponse(c, http.StatusNotFound, ErrAccessTokenNotFound)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
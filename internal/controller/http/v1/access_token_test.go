@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAccessTokenHandler_createAccessToken(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockPersonalAccessToken, userId int, input entity.CreatePersonalAccessTokenInput)
+
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name                string
+		setCtx              func(c *gin.Context)
+		userId              int
+		inputBody           string
+		input               entity.CreatePersonalAccessTokenInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			userId:    1,
+			inputBody: `{"name":"ci","scopes":["lists:read","items:write"]}`,
+			input: entity.CreatePersonalAccessTokenInput{
+				Name:   "ci",
+				Scopes: []string{entity.ScopeListsRead, entity.ScopeItemsWrite},
+			},
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			mockBehavior: func(s *mock_service.MockPersonalAccessToken, userId int, input entity.CreatePersonalAccessTokenInput) {
+				s.EXPECT().Create(userId, input).Return(entity.PersonalAccessToken{
+					Id:        1,
+					Name:      "ci",
+					Scopes:    input.Scopes,
+					Token:     "tdp_secret",
+					CreatedAt: ts,
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"id":1,"name":"ci","scopes":["lists:read","items:write"],"token":"tdp_secret",` +
+				`"created_at":"2023-01-01T00:00:00Z","last_used_at":null,"expires_at":null}`,
+		},
+		{
+			name:      "Unknown scope",
+			userId:    1,
+			inputBody: `{"name":"ci","scopes":["root"]}`,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			mockBehavior:        func(s *mock_service.MockPersonalAccessToken, userId int, input entity.CreatePersonalAccessTokenInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"unknown scope root"}`,
+		},
+		{
+			name:      "BindJSON",
+			userId:    1,
+			inputBody: `{"scopes":["lists:read"]}`,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			mockBehavior:        func(s *mock_service.MockPersonalAccessToken, userId int, input entity.CreatePersonalAccessTokenInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Service failure",
+			userId:    1,
+			inputBody: `{"name":"ci","scopes":["admin"]}`,
+			input: entity.CreatePersonalAccessTokenInput{
+				Name:   "ci",
+				Scopes: []string{entity.ScopeAdmin},
+			},
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			mockBehavior: func(s *mock_service.MockPersonalAccessToken, userId int, input entity.CreatePersonalAccessTokenInput) {
+				s.EXPECT().Create(userId, input).Return(entity.PersonalAccessToken{}, errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tokens := mock_service.NewMockPersonalAccessToken(c)
+			tc.mockBehavior(tokens, tc.userId, tc.input)
+
+			services := &service.Service{PersonalAccessToken: tokens}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/me/tokens", tc.setCtx, handler.createAccessToken)
+
+			req := httptest.NewRequest("POST", "/api/v1/me/tokens", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestAccessTokenHandler_deleteAccessToken(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockPersonalAccessToken, userId, tokenId int)
+
+	tt := []struct {
+		name                string
+		userId              int
+		tokenId             int
+		url                 string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "Ok",
+			userId:  1,
+			tokenId: 2,
+			url:     "/api/v1/me/tokens/2",
+			mockBehavior: func(s *mock_service.MockPersonalAccessToken, userId, tokenId int) {
+				s.EXPECT().Revoke(userId, tokenId).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Bad Request",
+			userId:              1,
+			url:                 "/api/v1/me/tokens/WrongPath",
+			mockBehavior:        func(s *mock_service.MockPersonalAccessToken, userId, tokenId int) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:    "Not Found",
+			userId:  1,
+			tokenId: 3,
+			url:     "/api/v1/me/tokens/3",
+			mockBehavior: func(s *mock_service.MockPersonalAccessToken, userId, tokenId int) {
+				s.EXPECT().Revoke(userId, tokenId).Return(service.ErrAccessTokenNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"personal access token not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			tokens := mock_service.NewMockPersonalAccessToken(c)
+			tc.mockBehavior(tokens, tc.userId, tc.tokenId)
+
+			services := &service.Service{PersonalAccessToken: tokens}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.DELETE("/api/v1/me/tokens/:id", func(c *gin.Context) {
+				c.Set(userCtx, tc.userId)
+			}, handler.deleteAccessToken)
+
+			req := httptest.NewRequest("DELETE", tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// @Summary			Verify email
+// @Tags			auth
+// @Description		Подтверждение адреса почты по токену из письма
+// @ID				verify-email
+// @Accept			json
+// @Produce			json
+// @Param			input	body		entity.VerifyEmailInput	true	"token from email"
+// @Success			200		{object}	statusResponse
+// @Failure			400		{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/email/verify [post]
+func (h *Handler) verifyEmail(c *gin.Context) {
+	var input entity.VerifyEmailInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err := h.services.Account.VerifyEmail(input.Token); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidUserToken)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary			Forgot password
+// @Tags			auth
+// @Description		Отправка ссылки для сброса пароля. Ответ не зависит от того, зарегистрирован ли адрес
+// @ID				forgot-password
+// @Accept			json
+// @Produce			json
+// @Param			input	body		entity.ForgotPasswordInput	true	"email"
+// @Success			200		{object}	statusResponse
+// @Failure			400		{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/password/forgot [post]
+func (h *Handler) forgotPassword(c *gin.Context) {
+	var input entity.ForgotPasswordInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err := h.services.Account.ForgotPassword(input.Email); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary			Reset password
+// @Tags			auth
+// @Description		Установка нового пароля по токену из письма. Все сессии и персональные токены отзываются
+// @ID				reset-password
+// @Accept			json
+// @Produce			json
+// @Param			input	body		entity.ResetPasswordInput	true	"token and new password"
+// @Success			200		{object}	statusResponse
+// @Failure			400		{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/password/reset [post]
+func (h *Handler) resetPassword(c *gin.Context) {
+	var input entity.ResetPasswordInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err := h.services.Account.ResetPassword(input); err != nil {
+		if errors.Is(err, service.ErrInvalidUserToken) {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidUserToken)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Send verification email
+// @Security		ApiKeyAuth
+// @Tags			me
+// @Description	Повторная отправка письма для подтверждения адреса почты
+// @ID				send-verification
+// @Produce		json
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/email/verification [post]
+func (h *Handler) sendVerification(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	if err := h.services.Account.SendVerification(userId); err != nil {
+		switch {
+		case errors.Is(err, service.ErrNoEmail):
+			newErrorResponse(c, http.StatusBadRequest, ErrNoEmail)
+		case errors.Is(err, service.ErrEmailAlreadyVerified):
+			newErrorResponse(c, http.StatusConflict, ErrEmailVerified)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,201 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAccountHandler_forgotPassword(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccount, email string)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		email               string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"email":"user@example.com"}`,
+			email:     "user@example.com",
+			mockBehavior: func(s *mock_service.MockAccount, email string) {
+				s.EXPECT().ForgotPassword(email).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Invalid email",
+			inputBody:           `{"email":"user"}`,
+			mockBehavior:        func(s *mock_service.MockAccount, email string) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Service failure",
+			inputBody: `{"email":"user@example.com"}`,
+			email:     "user@example.com",
+			mockBehavior: func(s *mock_service.MockAccount, email string) {
+				s.EXPECT().ForgotPassword(email).Return(errors.New("smtp: connection refused"))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			account := mock_service.NewMockAccount(c)
+			tc.mockBehavior(account, tc.email)
+
+			services := &service.Service{Account: account}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/auth/password/forgot", handler.forgotPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/password/forgot", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_resetPassword(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccount, input entity.ResetPasswordInput)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		input               entity.ResetPasswordInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"token":"token","new_password":"qwerty"}`,
+			input:     entity.ResetPasswordInput{Token: "token", NewPassword: "qwerty"},
+			mockBehavior: func(s *mock_service.MockAccount, input entity.ResetPasswordInput) {
+				s.EXPECT().ResetPassword(input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Empty Fields",
+			inputBody:           `{"token":"token"}`,
+			mockBehavior:        func(s *mock_service.MockAccount, input entity.ResetPasswordInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Used token",
+			inputBody: `{"token":"token","new_password":"qwerty"}`,
+			input:     entity.ResetPasswordInput{Token: "token", NewPassword: "qwerty"},
+			mockBehavior: func(s *mock_service.MockAccount, input entity.ResetPasswordInput) {
+				s.EXPECT().ResetPassword(input).Return(service.ErrInvalidUserToken)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid or expired token"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			account := mock_service.NewMockAccount(c)
+			tc.mockBehavior(account, tc.input)
+
+			services := &service.Service{Account: account}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/auth/password/reset", handler.resetPassword)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/auth/password/reset", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestAccountHandler_sendVerification(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAccount, userId int)
+
+	tt := []struct {
+		name                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(s *mock_service.MockAccount, userId int) {
+				s.EXPECT().SendVerification(userId).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name: "Already verified",
+			mockBehavior: func(s *mock_service.MockAccount, userId int) {
+				s.EXPECT().SendVerification(userId).Return(service.ErrEmailAlreadyVerified)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"email is already verified"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			account := mock_service.NewMockAccount(c)
+			tc.mockBehavior(account, 1)
+
+			services := &service.Service{Account: account}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/me/email/verification", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.sendVerification)
+
+			req := httptest.NewRequest("POST", "/api/v1/me/email/verification", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,205 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"mime"
+	"net/http"
+	"strconv"
+)
+
+// multipartOverhead - запас к размеру файла на заголовки частей и границы multipart-формы.
+const multipartOverhead = 64 << 10
+
+// @Summary		Upload attachment
+// @Security		ApiKeyAuth
+// @Tags			attachments
+// @Description	Прикрепление файла к задаче полем file формы multipart/form-data. Тип файла определяется по содержимому:
+// @Description	разрешены PNG, JPEG, GIF, WebP, PDF и текст. Прикреплять файлы могут редакторы и владельцы списка
+// @ID				create-attachment
+// @Accept			mpfd
+// @Produce		json
+// @Param			item_id	path		int		true	"Item ID"
+// @Param			file	formData	file	true	"file"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		413,415	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/attachments [post]
+func (h *Handler) createAttachment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.services.Attachment.MaxSize()+multipartOverhead)
+	file, header, err := c.Request.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			newErrorResponse(c, http.StatusRequestEntityTooLarge, ErrAttachmentTooLarge)
+			return
+		}
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	defer file.Close()
+
+	id, err := h.services.Attachment.Create(userId, itemId, entity.AttachmentUpload{
+		FileName: header.Filename,
+		Size:     header.Size,
+		Content:  file,
+	})
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+type getAllAttachmentsResponse struct {
+	Data []entity.Attachment `json:"data"`
+}
+
+// @Summary		Get item attachments
+// @Security		ApiKeyAuth
+// @Tags			attachments
+// @Description	Файлы, прикреплённые к задаче, в порядке загрузки
+// @ID				get-all-attachments
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int	true	"Item ID"
+// @Success		200		{object}	getAllAttachmentsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/attachments [get]
+func (h *Handler) getAllAttachments(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	attachments, err := h.services.Attachment.GetAll(userId, itemId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllAttachmentsResponse{
+		Data: attachments,
+	})
+}
+
+// @Summary		Download attachment
+// @Security		ApiKeyAuth
+// @Tags			attachments
+// @Description	Содержимое файла. Поддерживаются заголовки Range и If-Range для загрузки по частям,
+// @Description	If-None-Match с ETag из метаданных вложения - для проверки кэша
+// @ID				download-attachment
+// @Produce		octet-stream
+// @Param			item_id			path		int	true	"Item ID"
+// @Param			attachment_id	path		int	true	"Attachment ID"
+// @Success		200				{file}		file
+// @Success		206				{file}		file
+// @Success		304				"Not Modified"
+// @Failure		400,401			{object}	errorResponse
+// @Failure		404				{object}	errorResponse
+// @Failure		416				"Range Not Satisfiable"
+// @Failure		500				{object}	errorResponse
+// @Failure		default			{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/attachments/{attachment_id} [get]
+func (h *Handler) downloadAttachment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	attachmentId, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	attachment, content, err := h.services.Attachment.Open(userId, itemId, attachmentId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+	defer content.Close()
+
+	// Файл всегда скачивается, а не открывается в браузере, и браузер не угадывает тип заново
+	c.Header("Content-Type", attachment.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.FileName}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Header("Cache-Control", "private, no-cache")
+	c.Header("ETag", `"`+attachment.ETag+`"`)
+
+	http.ServeContent(c.Writer, c.Request, attachment.FileName, attachment.CreatedAt, content)
+}
+
+// @Summary		Delete attachment
+// @Security		ApiKeyAuth
+// @Tags			attachments
+// @Description	Удаление вложения. Файл удаляется из хранилища в фоне. Удалять вложения могут редакторы и владельцы списка
+// @ID				delete-attachment
+// @Accept			json
+// @Produce		json
+// @Param			item_id			path		int	true	"Item ID"
+// @Param			attachment_id	path		int	true	"Attachment ID"
+// @Success		200				{object}	statusResponse
+// @Failure		400,401			{object}	errorResponse
+// @Failure		403,404			{object}	errorResponse
+// @Failure		500				{object}	errorResponse
+// @Failure		default			{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/attachments/{attachment_id} [delete]
+func (h *Handler) deleteAttachment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	attachmentId, err := strconv.Atoi(c.Param("attachment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Attachment.Delete(userId, itemId, attachmentId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,316 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAttachmentHandler_createAttachment(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAttachment)
+
+	multipartBody := func(field, name, content string) (*bytes.Buffer, string) {
+		var body bytes.Buffer
+		w := multipart.NewWriter(&body)
+		part, _ := w.CreateFormFile(field, name)
+		_, _ = io.WriteString(part, content)
+		_ = w.Close()
+		return &body, w.FormDataContentType()
+	}
+
+	tt := []struct {
+		name                string
+		field               string
+		content             string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:    "Ok",
+			field:   "file",
+			content: "notes",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Create(1, 3, gomock.Any()).DoAndReturn(func(userId, itemId int, upload entity.AttachmentUpload) (int, error) {
+					content, _ := io.ReadAll(upload.Content)
+					assert.Equal(t, "notes.txt", upload.FileName)
+					assert.Equal(t, int64(5), upload.Size)
+					assert.Equal(t, "notes", string(content))
+					return 7, nil
+				})
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":7}`,
+		},
+		{
+			name:    "No file",
+			field:   "document",
+			content: "notes",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:    "Request body too large",
+			field:   "file",
+			content: string(bytes.Repeat([]byte("a"), multipartOverhead+2048)),
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+			},
+			expectedStatusCode:  413,
+			expectedRequestBody: `{"message":"attachment is too large"}`,
+		},
+		{
+			name:    "Too large",
+			field:   "file",
+			content: "notes",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Create(1, 3, gomock.Any()).Return(0, service.ErrAttachmentTooLarge)
+			},
+			expectedStatusCode:  413,
+			expectedRequestBody: `{"message":"attachment is too large"}`,
+		},
+		{
+			name:    "Unsupported type",
+			field:   "file",
+			content: "<html></html>",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Create(1, 3, gomock.Any()).Return(0, service.ErrUnsupportedFileType)
+			},
+			expectedStatusCode:  415,
+			expectedRequestBody: `{"message":"unsupported file type"}`,
+		},
+		{
+			name:    "Viewer",
+			field:   "file",
+			content: "notes",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().MaxSize().Return(int64(1024))
+				s.EXPECT().Create(1, 3, gomock.Any()).Return(0, service.ErrInsufficientRole)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"message":"insufficient role in list"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachments := mock_service.NewMockAttachment(c)
+			tc.mockBehavior(attachments)
+
+			handler := NewHandler(&service.Service{Attachment: attachments})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/items/:item_id/attachments", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.createAttachment)
+
+			body, contentType := multipartBody(tc.field, "notes.txt", tc.content)
+			req := httptest.NewRequest("POST", "/api/v1/items/3/attachments", body)
+			req.Header.Set("Content-Type", contentType)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+type readSeekNopCloser struct {
+	*bytes.Reader
+}
+
+func (readSeekNopCloser) Close() error { return nil }
+
+func TestAttachmentHandler_downloadAttachment(t *testing.T) {
+	attachment := entity.Attachment{
+		Id:          7,
+		ItemId:      3,
+		FileName:    "отчёт.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Size:        10,
+		ETag:        "abc123",
+		CreatedAt:   time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC),
+	}
+
+	tt := []struct {
+		name               string
+		headers            map[string]string
+		expectedStatusCode int
+		expectedBody       string
+		expectedHeaders    map[string]string
+	}{
+		{
+			name:               "Full",
+			expectedStatusCode: 200,
+			expectedBody:       "0123456789",
+			expectedHeaders: map[string]string{
+				"Content-Type":           "text/plain; charset=utf-8",
+				"Content-Length":         "10",
+				"Content-Disposition":    "attachment; filename*=utf-8''%D0%BE%D1%82%D1%87%D1%91%D1%82.txt",
+				"ETag":                   `"abc123"`,
+				"Accept-Ranges":          "bytes",
+				"X-Content-Type-Options": "nosniff",
+			},
+		},
+		{
+			name:               "Range",
+			headers:            map[string]string{"Range": "bytes=2-5"},
+			expectedStatusCode: 206,
+			expectedBody:       "2345",
+			expectedHeaders:    map[string]string{"Content-Range": "bytes 2-5/10"},
+		},
+		{
+			name:               "If-Range matches",
+			headers:            map[string]string{"Range": "bytes=8-", "If-Range": `"abc123"`},
+			expectedStatusCode: 206,
+			expectedBody:       "89",
+		},
+		{
+			name:               "If-Range changed",
+			headers:            map[string]string{"Range": "bytes=8-", "If-Range": `"old"`},
+			expectedStatusCode: 200,
+			expectedBody:       "0123456789",
+		},
+		{
+			name:               "Not modified",
+			headers:            map[string]string{"If-None-Match": `"abc123"`},
+			expectedStatusCode: 304,
+		},
+		{
+			name:               "Range not satisfiable",
+			headers:            map[string]string{"Range": "bytes=20-"},
+			expectedStatusCode: 416,
+			expectedBody:       "invalid range: failed to overlap\n",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachments := mock_service.NewMockAttachment(c)
+			attachments.EXPECT().Open(1, 3, 7).
+				Return(attachment, readSeekNopCloser{bytes.NewReader([]byte("0123456789"))}, nil)
+
+			handler := NewHandler(&service.Service{Attachment: attachments})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/v1/items/:item_id/attachments/:attachment_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.downloadAttachment)
+
+			req := httptest.NewRequest("GET", "/api/v1/items/3/attachments/7", nil)
+			for name, value := range tc.headers {
+				req.Header.Set(name, value)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedBody, w.Body.String())
+			for name, value := range tc.expectedHeaders {
+				assert.Equal(t, value, w.Header().Get(name), name)
+			}
+		})
+	}
+}
+
+func TestAttachmentHandler_downloadAttachmentNotFound(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	attachments := mock_service.NewMockAttachment(c)
+	attachments.EXPECT().Open(1, 3, 8).Return(entity.Attachment{}, nil, service.ErrAttachmentNotFound)
+
+	handler := NewHandler(&service.Service{Attachment: attachments})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.GET("/api/v1/items/:item_id/attachments/:attachment_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.downloadAttachment)
+
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/items/3/attachments/8", nil))
+
+	assert.Equal(t, 404, w.Code)
+	assert.Equal(t, `{"message":"attachment not found"}`, w.Body.String())
+}
+
+func TestAttachmentHandler_deleteAttachment(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAttachment)
+
+	tt := []struct {
+		name                string
+		attachmentId        string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:         "Ok",
+			attachmentId: "7",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().Delete(1, 3, 7).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:         "Not found",
+			attachmentId: "8",
+			mockBehavior: func(s *mock_service.MockAttachment) {
+				s.EXPECT().Delete(1, 3, 8).Return(service.ErrAttachmentNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"attachment not found"}`,
+		},
+		{
+			name:                "Invalid id",
+			attachmentId:        "abc",
+			mockBehavior:        func(s *mock_service.MockAttachment) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			attachments := mock_service.NewMockAttachment(c)
+			tc.mockBehavior(attachments)
+
+			handler := NewHandler(&service.Service{Attachment: attachments})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.DELETE("/api/v1/items/:item_id/attachments/:attachment_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.deleteAttachment)
+
+			r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/items/3/attachments/"+tc.attachmentId, nil))
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
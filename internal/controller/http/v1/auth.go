@@ -1,9 +1,14 @@
 package v1
 
 import (
+	"errors"
 	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
 	"github.com/gin-gonic/gin"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 )
 
 // @Summary			SignUp
@@ -15,6 +20,7 @@ import (
 // @Param			input	body		entity.User	true	"account info"
 // @Success			200		{object}	idResponse
 // @Failure			400		{object}	errorResponse
+// @Failure			409		{object}	errorResponse
 // @Failure			500		{object}	errorResponse
 // @Failure			default	{object}	errorResponse
 // @Router			/auth/sign-up [post]
@@ -28,7 +34,14 @@ func (h *Handler) signUp(c *gin.Context) {
 
 	id, err := h.services.Authorization.CreateUser(input)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		switch {
+		case errors.Is(err, service.ErrUsernameTaken):
+			newErrorResponse(c, http.StatusConflict, ErrUsernameTaken)
+		case errors.Is(err, service.ErrEmailTaken):
+			newErrorResponse(c, http.StatusConflict, ErrEmailTaken)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
 		return
 	}
 
@@ -50,7 +63,9 @@ type signInInput struct {
 // @Produce			json
 // @Param			input	body		signInInput	true	"credentials"
 // @Success			200		{object}	signInResponse
-// @Failure			400		{object}	errorResponse
+// @Success			202		{object}	twoFactorChallengeResponse
+// @Failure			400,401	{object}	errorResponse
+// @Failure			429		{object}	errorResponse
 // @Failure			500		{object}	errorResponse
 // @Failure			default	{object}	errorResponse
 // @Router			/auth/sign-in [post]
@@ -62,13 +77,151 @@ func (h *Handler) signIn(c *gin.Context) {
 		return
 	}
 
-	token, err := h.services.Authorization.GenerateToken(input.Username, input.Password)
+	tokens, err := h.services.Authorization.GenerateToken(input.Username, input.Password, getClient(c))
+	if err != nil {
+		var lockout *service.LockoutError
+		switch {
+		case errors.As(err, &lockout):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(lockout.Until).Seconds()))))
+			newErrorResponse(c, http.StatusTooManyRequests, ErrTooManyAttempts)
+		case errors.Is(err, service.ErrInvalidCredentials):
+			newErrorResponse(c, http.StatusUnauthorized, ErrInvalidCredentials)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
+		return
+	}
+
+	newSignInResponse(c, tokens)
+}
+
+// @Summary			SignIn 2FA
+// @Tags			auth
+// @Description		Второй шаг входа: обмен challenge-токена и кода TOTP (или резервного кода) на пару токенов
+// @ID				login-2fa
+// @Accept			json
+// @Produce			json
+// @Param			input	body		entity.TwoFactorSignInInput	true	"challenge and code"
+// @Success			200		{object}	signInResponse
+// @Failure			400,401	{object}	errorResponse
+// @Failure			429		{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/sign-in/2fa [post]
+func (h *Handler) signInTwoFactor(c *gin.Context) {
+	var input entity.TwoFactorSignInInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	tokens, err := h.services.Authorization.SignInTwoFactor(input.ChallengeToken, input.Code, getClient(c))
+	if err != nil {
+		var lockout *service.LockoutError
+		switch {
+		case errors.As(err, &lockout):
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(lockout.Until).Seconds()))))
+			newErrorResponse(c, http.StatusTooManyRequests, ErrTooManyAttempts)
+		case errors.Is(err, service.ErrInvalidChallenge):
+			newErrorResponse(c, http.StatusUnauthorized, ErrInvalidChallenge)
+		case errors.Is(err, service.ErrInvalidTwoFactorCode):
+			newErrorResponse(c, http.StatusUnauthorized, ErrInvalidTwoFactor)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, signInResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+type refreshInput struct {
+	RefreshToken string `json:"refresh_token" binding:"required"`
+}
+
+// @Summary			Refresh
+// @Tags			auth
+// @Description		Обновление пары токенов по refresh-токену
+// @ID				refresh
+// @Accept			json
+// @Produce			json
+// @Param			input	body		refreshInput	true	"refresh token"
+// @Success			200		{object}	signInResponse
+// @Failure			400,401	{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/refresh [post]
+func (h *Handler) refresh(c *gin.Context) {
+	var input refreshInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	tokens, err := h.services.Authorization.RefreshTokens(input.RefreshToken)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+			newErrorResponse(c, http.StatusUnauthorized, ErrInvalidRefreshToken)
+			return
+		}
 		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
 		return
 	}
 
 	c.JSON(http.StatusOK, signInResponse{
-		Token: token,
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
+// @Summary			Logout
+// @Tags			auth
+// @Description		Выход: отзыв сессии, к которой относится refresh-токен
+// @ID				logout
+// @Accept			json
+// @Produce			json
+// @Param			input	body		refreshInput	true	"refresh token"
+// @Success			200		{object}	statusResponse
+// @Failure			400,401	{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/logout [post]
+func (h *Handler) logout(c *gin.Context) {
+	var input refreshInput
+
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err := h.services.Authorization.Logout(input.RefreshToken); err != nil {
+		if errors.Is(err, service.ErrInvalidRefreshToken) {
+			newErrorResponse(c, http.StatusUnauthorized, ErrInvalidRefreshToken)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
 	})
 }
+
+// @Summary			JWKS
+// @Tags			auth
+// @Description		Открытые ключи для проверки подписи выданных токенов
+// @ID				jwks
+// @Produce			json
+// @Success			200		{object}	entity.JSONWebKeySet
+// @Router			/.well-known/jwks.json [get]
+func (h *Handler) jwks(c *gin.Context) {
+	// Ключи меняются только при ротации, проверяющие сервисы могут их кэшировать
+	c.Header("Cache-Control", "public, max-age=300")
+	c.JSON(http.StatusOK, h.services.Authorization.PublicKeys())
+}
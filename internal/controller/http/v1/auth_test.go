@@ -10,7 +10,10 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
+	"unicode/utf8"
 )
 
 func TestHandler_signUp(t *testing.T) {
@@ -26,6 +29,21 @@ func TestHandler_signUp(t *testing.T) {
 	}{
 		{
 			name:      "Ok",
+			inputBody: `{"name":"Test", "username":"test", "email":"test@example.com", "password":"qwerty"}`,
+			inputUser: entity.User{
+				Name:     "Test",
+				Username: "test",
+				Email:    "test@example.com",
+				Password: "qwerty",
+			},
+			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
+				s.EXPECT().CreateUser(user).Return(1, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":1}`,
+		},
+		{
+			name:      "Without email",
 			inputBody: `{"name":"Test", "username":"test", "password":"qwerty"}`,
 			inputUser: entity.User{
 				Name:     "Test",
@@ -46,12 +64,36 @@ func TestHandler_signUp(t *testing.T) {
 			expectedStatusCode:  400,
 			expectedRequestBody: `{"message":"invalid input body"}`,
 		},
+		{
+			name:                "Invalid email",
+			inputBody:           `{"name":"Test", "username":"test", "email":"test", "password":"qwerty"}`,
+			inputUser:           entity.User{},
+			mockBehavior:        func(s *mock_service.MockAuthorization, user entity.User) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Email taken",
+			inputBody: `{"name":"Test", "username":"test", "email":"test@example.com", "password":"qwerty"}`,
+			inputUser: entity.User{
+				Name:     "Test",
+				Username: "test",
+				Email:    "test@example.com",
+				Password: "qwerty",
+			},
+			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
+				s.EXPECT().CreateUser(user).Return(0, service.ErrEmailTaken)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"email is already taken"}`,
+		},
 		{
 			name:      "Service failure",
-			inputBody: `{"name":"Test", "username":"test", "password":"qwerty"}`,
+			inputBody: `{"name":"Test", "username":"test", "email":"test@example.com", "password":"qwerty"}`,
 			inputUser: entity.User{
 				Name:     "Test",
 				Username: "test",
+				Email:    "test@example.com",
 				Password: "qwerty",
 			},
 			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
@@ -98,6 +140,7 @@ func TestHandler_signIn(t *testing.T) {
 		mockBehavior        mockBehavior
 		expectedStatusCode  int
 		expectedRequestBody string
+		expectedRetryAfter  string
 	}{
 		{
 			name:      "Ok",
@@ -107,10 +150,28 @@ func TestHandler_signIn(t *testing.T) {
 				Password: "qwerty",
 			},
 			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
-				s.EXPECT().GenerateToken(user.Username, user.Password).Return("token", nil)
+				s.EXPECT().GenerateToken(user.Username, user.Password, gomock.Any()).Return(entity.Tokens{
+					AccessToken:  "token",
+					RefreshToken: "refresh",
+				}, nil)
 			},
 			expectedStatusCode:  200,
-			expectedRequestBody: `{"token":"token"}`,
+			expectedRequestBody: `{"token":"token","refresh_token":"refresh"}`,
+		},
+		{
+			name:      "Two-factor required",
+			inputBody: `{"username":"test", "password":"qwerty"}`,
+			inputUser: entity.User{
+				Username: "test",
+				Password: "qwerty",
+			},
+			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
+				s.EXPECT().GenerateToken(user.Username, user.Password, gomock.Any()).Return(entity.Tokens{
+					ChallengeToken: "challenge",
+				}, nil)
+			},
+			expectedStatusCode:  202,
+			expectedRequestBody: `{"two_factor_required":true,"challenge_token":"challenge"}`,
 		},
 		{
 			name:                "Empty Fields",
@@ -129,11 +190,39 @@ func TestHandler_signIn(t *testing.T) {
 				Password: "qwerty",
 			},
 			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
-				s.EXPECT().GenerateToken(user.Username, user.Password).Return("", errors.New(ErrServiceFailure))
+				s.EXPECT().GenerateToken(user.Username, user.Password, gomock.Any()).Return(entity.Tokens{}, errors.New(ErrServiceFailure))
 			},
 			expectedStatusCode:  500,
 			expectedRequestBody: `{"message":"service failure"}`,
 		},
+		{
+			name:      "Wrong password",
+			inputBody: `{"username":"test", "password":"wrong"}`,
+			inputUser: entity.User{
+				Username: "test",
+				Password: "wrong",
+			},
+			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
+				s.EXPECT().GenerateToken(user.Username, user.Password, gomock.Any()).Return(entity.Tokens{}, service.ErrInvalidCredentials)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"invalid username or password"}`,
+		},
+		{
+			name:      "Locked out",
+			inputBody: `{"username":"test", "password":"qwerty"}`,
+			inputUser: entity.User{
+				Username: "test",
+				Password: "qwerty",
+			},
+			mockBehavior: func(s *mock_service.MockAuthorization, user entity.User) {
+				s.EXPECT().GenerateToken(user.Username, user.Password, gomock.Any()).Return(entity.Tokens{},
+					&service.LockoutError{Until: time.Now().Add(time.Minute)})
+			},
+			expectedStatusCode:  429,
+			expectedRequestBody: `{"message":"too many failed sign-in attempts, try again later"}`,
+			expectedRetryAfter:  "60",
+		},
 	}
 
 	for _, tc := range tt {
@@ -158,6 +247,346 @@ func TestHandler_signIn(t *testing.T) {
 
 			assert.Equal(t, w.Code, tc.expectedStatusCode)
 			assert.Equal(t, w.Body.String(), tc.expectedRequestBody)
+			assert.Equal(t, tc.expectedRetryAfter, w.Header().Get("Retry-After"))
+		})
+	}
+}
+
+func TestHandler_signInUserAgent(t *testing.T) {
+	tt := []struct {
+		name              string
+		userAgent         string
+		expectedUserAgent string
+	}{
+		{
+			name:              "Long",
+			userAgent:         strings.Repeat("a", 511) + strings.Repeat("я", 100),
+			expectedUserAgent: strings.Repeat("a", 511),
+		},
+		{
+			name:              "Short invalid",
+			userAgent:         "curl/8.0\xff",
+			expectedUserAgent: "curl/8.0",
+		},
+		{
+			name:              "Invalid byte in the middle",
+			userAgent:         strings.Repeat("a", 300) + "\xc3" + strings.Repeat("я", 200),
+			expectedUserAgent: strings.Repeat("a", 300) + strings.Repeat("я", 106),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			auth.EXPECT().GenerateToken("test", "qwerty", gomock.Any()).DoAndReturn(
+				func(username, password string, client entity.Client) (entity.Tokens, error) {
+					assert.Equal(t, tc.expectedUserAgent, client.UserAgent, "многобайтовый символ на границе отбрасывается целиком")
+					assert.True(t, utf8.ValidString(client.UserAgent))
+					return entity.Tokens{AccessToken: "token", RefreshToken: "refresh"}, nil
+				})
+
+			handler := NewHandler(&service.Service{Authorization: auth})
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/sign-in", handler.signIn)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sign-in", bytes.NewBufferString(`{"username":"test", "password":"qwerty"}`))
+			req.Header.Set("User-Agent", tc.userAgent)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, 200, w.Code)
+		})
+	}
+}
+
+func TestHandler_refresh(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAuthorization, refreshToken string)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		refreshToken        string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:         "Ok",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().RefreshTokens(refreshToken).Return(entity.Tokens{
+					AccessToken:  "token",
+					RefreshToken: "new refresh",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"token":"token","refresh_token":"new refresh"}`,
+		},
+		{
+			name:                "Empty Fields",
+			inputBody:           `{}`,
+			mockBehavior:        func(s *mock_service.MockAuthorization, refreshToken string) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:         "Invalid Token",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().RefreshTokens(refreshToken).Return(entity.Tokens{}, service.ErrInvalidRefreshToken)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"invalid refresh token"}`,
+		},
+		{
+			name:         "Reused Token",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().RefreshTokens(refreshToken).Return(entity.Tokens{}, service.ErrRefreshTokenReused)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"invalid refresh token"}`,
+		},
+		{
+			name:         "Service failure",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().RefreshTokens(refreshToken).Return(entity.Tokens{}, errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			tc.mockBehavior(auth, tc.refreshToken)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/refresh", handler.refresh)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/refresh", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_logout(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAuthorization, refreshToken string)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		refreshToken        string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:         "Ok",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().Logout(refreshToken).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Empty Fields",
+			inputBody:           `{"refresh_token":""}`,
+			mockBehavior:        func(s *mock_service.MockAuthorization, refreshToken string) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:         "Invalid Token",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().Logout(refreshToken).Return(service.ErrInvalidRefreshToken)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"invalid refresh token"}`,
+		},
+		{
+			name:         "Service failure",
+			inputBody:    `{"refresh_token":"refresh"}`,
+			refreshToken: "refresh",
+			mockBehavior: func(s *mock_service.MockAuthorization, refreshToken string) {
+				s.EXPECT().Logout(refreshToken).Return(errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			tc.mockBehavior(auth, tc.refreshToken)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/logout", handler.logout)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/logout", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestHandler_signInTwoFactor(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAuthorization, input entity.TwoFactorSignInInput)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		input               entity.TwoFactorSignInInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"challenge_token":"challenge","code":"123456"}`,
+			input:     entity.TwoFactorSignInInput{ChallengeToken: "challenge", Code: "123456"},
+			mockBehavior: func(s *mock_service.MockAuthorization, input entity.TwoFactorSignInInput) {
+				s.EXPECT().SignInTwoFactor(input.ChallengeToken, input.Code, gomock.Any()).Return(entity.Tokens{
+					AccessToken:  "token",
+					RefreshToken: "refresh",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"token":"token","refresh_token":"refresh"}`,
+		},
+		{
+			name:                "Empty Fields",
+			inputBody:           `{"challenge_token":"challenge"}`,
+			mockBehavior:        func(s *mock_service.MockAuthorization, input entity.TwoFactorSignInInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Expired challenge",
+			inputBody: `{"challenge_token":"challenge","code":"123456"}`,
+			input:     entity.TwoFactorSignInInput{ChallengeToken: "challenge", Code: "123456"},
+			mockBehavior: func(s *mock_service.MockAuthorization, input entity.TwoFactorSignInInput) {
+				s.EXPECT().SignInTwoFactor(input.ChallengeToken, input.Code, gomock.Any()).Return(entity.Tokens{}, service.ErrInvalidChallenge)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"invalid or expired two-factor challenge"}`,
+		},
+		{
+			name:      "Wrong code",
+			inputBody: `{"challenge_token":"challenge","code":"000000"}`,
+			input:     entity.TwoFactorSignInInput{ChallengeToken: "challenge", Code: "000000"},
+			mockBehavior: func(s *mock_service.MockAuthorization, input entity.TwoFactorSignInInput) {
+				s.EXPECT().SignInTwoFactor(input.ChallengeToken, input.Code, gomock.Any()).Return(entity.Tokens{}, service.ErrInvalidTwoFactorCode)
+			},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"invalid two-factor code"}`,
+		},
+		{
+			name:      "Too many attempts",
+			inputBody: `{"challenge_token":"challenge","code":"000000"}`,
+			input:     entity.TwoFactorSignInInput{ChallengeToken: "challenge", Code: "000000"},
+			mockBehavior: func(s *mock_service.MockAuthorization, input entity.TwoFactorSignInInput) {
+				s.EXPECT().SignInTwoFactor(input.ChallengeToken, input.Code, gomock.Any()).
+					Return(entity.Tokens{}, &service.LockoutError{Until: time.Now().Add(time.Minute)})
+			},
+			expectedStatusCode:  429,
+			expectedRequestBody: `{"message":"too many failed sign-in attempts, try again later"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			tc.mockBehavior(auth, tc.input)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.POST("/sign-in/2fa", handler.signInTwoFactor)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("POST", "/sign-in/2fa", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
 		})
 	}
 }
+
+func TestHandler_jwks(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	auth := mock_service.NewMockAuthorization(c)
+	auth.EXPECT().PublicKeys().Return(entity.JSONWebKeySet{Keys: []entity.JSONWebKey{{
+		KeyType:   "OKP",
+		KeyId:     "2023-05",
+		Use:       "sig",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         "x",
+	}}})
+
+	services := &service.Service{Authorization: auth}
+	handler := NewHandler(services)
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.GET("/.well-known/jwks.json", handler.jwks)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/.well-known/jwks.json", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"keys":[{"kty":"OKP","kid":"2023-05","use":"sig","alg":"EdDSA","crv":"Ed25519","x":"x"}]}`, w.Body.String())
+	assert.Equal(t, "public, max-age=300", w.Header().Get("Cache-Control"))
+}
@@ -0,0 +1,193 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// @Summary		Create comment
+// @Security		ApiKeyAuth
+// @Tags			comments
+// @Description	Комментарий к задаче в Markdown, parent_id - комментарий, на который дан ответ. Участники списка,
+// @Description	упомянутые как @username, получают уведомление. Комментировать могут все участники списка
+// @ID				create-comment
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int							true	"Item ID"
+// @Param			input	body		entity.CreateCommentInput	true	"comment info"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/comments [post]
+func (h *Handler) createComment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.CreateCommentInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Comment.Create(userId, itemId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+type getAllCommentsResponse struct {
+	Data []entity.Comment `json:"data"`
+}
+
+// @Summary		Get item comments
+// @Security		ApiKeyAuth
+// @Tags			comments
+// @Description	Обсуждение задачи: комментарии верхнего уровня по порядку, ответы вложены в replies
+// @ID				get-all-comments
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int	true	"Item ID"
+// @Success		200		{object}	getAllCommentsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/comments [get]
+func (h *Handler) getAllComments(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	comments, err := h.services.Comment.GetAll(userId, itemId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllCommentsResponse{
+		Data: comments,
+	})
+}
+
+// @Summary		Update comment
+// @Security		ApiKeyAuth
+// @Tags			comments
+// @Description	Изменение своего комментария. Уведомления получают только впервые упомянутые участники
+// @ID				update-comment
+// @Accept			json
+// @Produce		json
+// @Param			item_id		path		int							true	"Item ID"
+// @Param			comment_id	path		int							true	"Comment ID"
+// @Param			input		body		entity.UpdateCommentInput	true	"comment info"
+// @Success		200			{object}	statusResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		403,404		{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/comments/{comment_id} [put]
+func (h *Handler) updateComment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	commentId, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.UpdateCommentInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.Comment.Update(userId, itemId, commentId, input); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Delete comment
+// @Security		ApiKeyAuth
+// @Tags			comments
+// @Description	Удаление своего комментария. Если на него уже ответили, в ветке остаётся пометка deleted без текста
+// @ID				delete-comment
+// @Accept			json
+// @Produce		json
+// @Param			item_id		path		int	true	"Item ID"
+// @Param			comment_id	path		int	true	"Comment ID"
+// @Success		200			{object}	statusResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		403,404		{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/comments/{comment_id} [delete]
+func (h *Handler) deleteComment(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	commentId, err := strconv.Atoi(c.Param("comment_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Comment.Delete(userId, itemId, commentId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,163 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestCommentHandler_createComment(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockComment)
+
+	parentId := 4
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"body":"@bob **done**","parent_id":4}`,
+			mockBehavior: func(s *mock_service.MockComment) {
+				s.EXPECT().Create(1, 3, entity.CreateCommentInput{Body: "@bob **done**", ParentId: &parentId}).Return(5, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":5}`,
+		},
+		{
+			name:                "Blank body",
+			inputBody:           `{"body":"   "}`,
+			mockBehavior:        func(s *mock_service.MockComment) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"comment body can not be empty"}`,
+		},
+		{
+			name:                "No body",
+			inputBody:           `{}`,
+			mockBehavior:        func(s *mock_service.MockComment) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Invalid parent",
+			inputBody: `{"body":"Thanks","parent_id":4}`,
+			mockBehavior: func(s *mock_service.MockComment) {
+				s.EXPECT().Create(1, 3, entity.CreateCommentInput{Body: "Thanks", ParentId: &parentId}).Return(0, service.ErrInvalidCommentParent)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"parent must be a comment of the same item"}`,
+		},
+		{
+			name:      "Item not found",
+			inputBody: `{"body":"Thanks"}`,
+			mockBehavior: func(s *mock_service.MockComment) {
+				s.EXPECT().Create(1, 3, entity.CreateCommentInput{Body: "Thanks"}).Return(0, service.ErrItemNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"item not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			comments := mock_service.NewMockComment(c)
+			tc.mockBehavior(comments)
+
+			handler := NewHandler(&service.Service{Comment: comments})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/items/:item_id/comments", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.createComment)
+
+			req := httptest.NewRequest("POST", "/api/v1/items/3/comments", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestCommentHandler_getAllComments(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	createdAt := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	parentId, aliceId := 1, 1
+
+	comments := mock_service.NewMockComment(c)
+	comments.EXPECT().GetAll(1, 3).Return([]entity.Comment{
+		{Id: 1, ItemId: 3, Author: entity.DeletedAuthor, Body: "Who books the hotel?", CreatedAt: createdAt, Replies: []entity.Comment{
+			{Id: 2, ItemId: 3, ParentId: &parentId, AuthorId: &aliceId, Author: "Alice", Body: "Done", CreatedAt: createdAt},
+		}},
+	}, nil)
+
+	handler := NewHandler(&service.Service{Comment: comments})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.GET("/api/v1/items/:item_id/comments", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.getAllComments)
+
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/items/3/comments", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":[{"id":1,"item_id":3,"author":"deleted user","body":"Who books the hotel?","created_at":"2024-05-03T09:00:00Z",`+
+		`"replies":[{"id":2,"item_id":3,"parent_id":1,"author_id":1,"author":"Alice","body":"Done","created_at":"2024-05-03T09:00:00Z"}]}]}`,
+		w.Body.String())
+}
+
+func TestCommentHandler_updateComment(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	comments := mock_service.NewMockComment(c)
+	comments.EXPECT().Update(1, 3, 5, entity.UpdateCommentInput{Body: "Edited"}).Return(service.ErrNotCommentAuthor)
+
+	handler := NewHandler(&service.Service{Comment: comments})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.PUT("/api/v1/items/:item_id/comments/:comment_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.updateComment)
+
+	r.ServeHTTP(w, httptest.NewRequest("PUT", "/api/v1/items/3/comments/5", bytes.NewBufferString(`{"body":"Edited"}`)))
+
+	assert.Equal(t, 403, w.Code)
+	assert.Equal(t, `{"message":"only the author can change a comment"}`, w.Body.String())
+}
+
+func TestCommentHandler_deleteComment(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	comments := mock_service.NewMockComment(c)
+	comments.EXPECT().Delete(1, 3, 5).Return(service.ErrCommentNotFound)
+
+	handler := NewHandler(&service.Service{Comment: comments})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.DELETE("/api/v1/items/:item_id/comments/:comment_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.deleteComment)
+
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/items/3/comments/5", nil))
+
+	assert.Equal(t, 404, w.Code)
+	assert.Equal(t, `{"message":"comment not found"}`, w.Body.String())
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	_ "github.com/IncubusX/go-todo-app/docs"
+	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/IncubusX/go-todo-app/internal/service"
 	"github.com/gin-gonic/gin"
 	"github.com/swaggo/files"       // swagger embed files
@@ -19,34 +20,149 @@ func NewHandler(services *service.Service) *Handler {
 func (h *Handler) InitRoutes() *gin.Engine {
 	router := gin.New()
 	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.GET("/.well-known/jwks.json", h.jwks)
 
 	auth := router.Group("/auth")
 	{
 		auth.POST("/sign-up", h.signUp)
 		auth.POST("/sign-in", h.signIn)
+		auth.POST("/sign-in/2fa", h.signInTwoFactor)
+		auth.POST("/refresh", h.refresh)
+		auth.POST("/logout", h.logout)
+		auth.POST("/email/verify", h.verifyEmail)
+		auth.POST("/password/forgot", h.forgotPassword)
+		auth.POST("/password/reset", h.resetPassword)
+		auth.GET("/oidc/:provider/login", h.oidcLogin)
+		auth.GET("/oidc/:provider/callback", h.oidcCallback)
+	}
+
+	// Публичные ссылки доступны без авторизации, доступ определяется только токеном в адресе
+	public := router.Group("/public")
+	{
+		public.GET("/lists/:token", h.getPublicList)
+		public.POST("/lists/:token", h.getPublicList)
 	}
 
 	api := router.Group("/api/v1", h.userIdentity)
 	{
-		lists := api.Group("/lists")
+		me := api.Group("/me", h.requireScope(entity.ScopeAdmin, entity.ScopeAdmin))
+		{
+			me.GET("/", h.getProfile)
+			me.PATCH("/", h.updateProfile)
+			me.DELETE("/", h.deleteProfile)
+			me.POST("/password", h.changePassword)
+			me.POST("/email/verification", h.sendVerification)
+
+			sessions := me.Group("/sessions")
+			{
+				sessions.GET("/", h.getAllSessions)
+				sessions.DELETE("/:id", h.deleteSession)
+			}
+
+			twoFactor := me.Group("/2fa")
+			{
+				twoFactor.POST("/enroll", h.enrollTwoFactor)
+				twoFactor.POST("/confirm", h.confirmTwoFactor)
+				twoFactor.POST("/disable", h.disableTwoFactor)
+			}
+
+			invitations := me.Group("/invitations")
+			{
+				invitations.GET("/", h.getAllInvitations)
+				invitations.POST("/:id/accept", h.acceptInvitation)
+				invitations.POST("/:id/decline", h.declineInvitation)
+			}
+
+			tokens := me.Group("/tokens")
+			{
+				tokens.POST("/", h.createAccessToken)
+				tokens.GET("/", h.getAllAccessTokens)
+				tokens.DELETE("/:id", h.deleteAccessToken)
+			}
+
+			notifications := me.Group("/notifications")
+			{
+				notifications.GET("/", h.getAllNotifications)
+				notifications.POST("/:id/read", h.markNotificationRead)
+				notifications.POST("/read-all", h.markAllNotificationsRead)
+				notifications.GET("/preferences", h.getNotificationPreferences)
+				notifications.PUT("/preferences", h.updateNotificationPreferences)
+			}
+		}
+
+		lists := api.Group("/lists", h.requireScope(entity.ScopeListsRead, entity.ScopeListsWrite))
 		{
 			lists.POST("/", h.createList)
 			lists.GET("/", h.getAllLists)
 			lists.GET("/:id", h.getListById)
 			lists.PUT("/:id", h.updateList)
 			lists.DELETE("/:id", h.deleteList)
-
-			items := lists.Group(":id/items")
-			{
-				items.POST("/", h.createItem)
-				items.GET("/", h.getAllItems)
-			}
+			lists.POST("/:id/move", h.moveList)
+			lists.POST("/:id/duplicate", h.duplicateList)
+			lists.POST("/:id/template", h.saveListTemplate)
+			lists.GET("/:id/members", h.getAllListMembers)
+			lists.POST("/:id/members", h.inviteListMember)
+			lists.PATCH("/:id/members/:user_id", h.updateListMember)
+			lists.DELETE("/:id/members/:user_id", h.deleteListMember)
+			lists.POST("/:id/invite-links", h.createInviteLink)
+			lists.DELETE("/:id/invite-links/:link_id", h.deleteInviteLink)
+			lists.PUT("/:id/public-link", h.publishList)
+			lists.DELETE("/:id/public-link", h.unpublishList)
+		}
+		templates := api.Group("/templates", h.requireScope(entity.ScopeListsRead, entity.ScopeListsWrite))
+		{
+			templates.GET("/", h.getAllListTemplates)
+			templates.GET("/:id", h.getListTemplateById)
+			templates.DELETE("/:id", h.deleteListTemplate)
+			templates.POST("/:id/instantiate", h.instantiateListTemplate)
 		}
-		items := api.Group("items")
+		inviteLinks := api.Group("/invite-links", h.requireScope(entity.ScopeListsRead, entity.ScopeListsWrite))
 		{
+			inviteLinks.POST("/accept", h.acceptInviteLink)
+		}
+		listItems := api.Group("/lists/:id/items", h.requireScope(entity.ScopeItemsRead, entity.ScopeItemsWrite))
+		{
+			listItems.POST("/", h.createItem)
+			listItems.GET("/", h.getAllItems)
+		}
+		items := api.Group("items", h.requireScope(entity.ScopeItemsRead, entity.ScopeItemsWrite))
+		{
+			items.GET("/overdue", h.getOverdueItems)
+			items.GET("/today", h.getItemsDueToday)
+			items.GET("/week", h.getItemsDueThisWeek)
+			items.GET("/search", h.searchItems)
 			items.GET("/:item_id", h.getItemById)
 			items.PUT("/:item_id", h.updateItem)
 			items.DELETE("/:item_id", h.deleteItem)
+			items.POST("/:item_id/move", h.moveItem)
+			items.POST("/:item_id/copy", h.copyItem)
+			items.POST("/:item_id/skip", h.skipItemOccurrence)
+			items.GET("/:item_id/occurrences", h.getItemOccurrences)
+			items.POST("/:item_id/labels/:label_id", h.attachLabel)
+			items.DELETE("/:item_id/labels/:label_id", h.detachLabel)
+			items.GET("/:item_id/reminders", h.getAllReminders)
+			items.POST("/:item_id/reminders", h.createReminder)
+			items.DELETE("/:item_id/reminders/:reminder_id", h.deleteReminder)
+			items.GET("/:item_id/comments", h.getAllComments)
+			items.POST("/:item_id/comments", h.createComment)
+			items.PUT("/:item_id/comments/:comment_id", h.updateComment)
+			items.DELETE("/:item_id/comments/:comment_id", h.deleteComment)
+			items.GET("/:item_id/attachments", h.getAllAttachments)
+			items.POST("/:item_id/attachments", h.createAttachment)
+			items.GET("/:item_id/attachments/:attachment_id", h.downloadAttachment)
+			items.DELETE("/:item_id/attachments/:attachment_id", h.deleteAttachment)
+		}
+		assigned := api.Group("/me/assigned", h.requireScope(entity.ScopeItemsRead, entity.ScopeItemsWrite))
+		{
+			assigned.GET("/", h.getAssignedItems)
+		}
+		labels := api.Group("/labels", h.requireScope(entity.ScopeItemsRead, entity.ScopeItemsWrite))
+		{
+			labels.POST("/", h.createLabel)
+			labels.GET("/", h.getAllLabels)
+			labels.GET("/:id", h.getLabelById)
+			labels.PATCH("/:id", h.updateLabel)
+			labels.DELETE("/:id", h.deleteLabel)
 		}
 	}
 
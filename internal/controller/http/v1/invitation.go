@@ -0,0 +1,283 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// @Summary		Invite list member
+// @Security		ApiKeyAuth
+// @Tags			members
+// @Description	Приглашение пользователя в список по логину. Роли: owner, editor, viewer. Участником он станет после принятия приглашения. Доступно только владельцу
+// @ID				invite-list-member
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"List ID"
+// @Param			input	body		entity.InviteMemberInput	true	"invitation info"
+// @Success		200		{object}	entity.ListInvitation
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/members [post]
+func (h *Handler) inviteListMember(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.InviteMemberInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	invitation, err := h.services.Invitation.Invite(userId, listId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, invitation)
+}
+
+type getAllInvitationsResponse struct {
+	Data []entity.ListInvitation `json:"data"`
+}
+
+// @Summary		Get invitations
+// @Security		ApiKeyAuth
+// @Tags			invitations
+// @Description	Приглашения в списки, ожидающие ответа
+// @ID				get-all-invitations
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllInvitationsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/invitations [get]
+func (h *Handler) getAllInvitations(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	invitations, err := h.services.Invitation.GetAll(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllInvitationsResponse{
+		Data: invitations,
+	})
+}
+
+// @Summary		Accept invitation
+// @Security		ApiKeyAuth
+// @Tags			invitations
+// @Description	Принятие приглашения в список
+// @ID				accept-invitation
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Invitation ID"
+// @Success		200		{object}	entity.TodoList
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/invitations/{id}/accept [post]
+func (h *Handler) acceptInvitation(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	invitationId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	list, err := h.services.Invitation.Accept(userId, invitationId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+// @Summary		Decline invitation
+// @Security		ApiKeyAuth
+// @Tags			invitations
+// @Description	Отказ от приглашения в список
+// @ID				decline-invitation
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Invitation ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/invitations/{id}/decline [post]
+func (h *Handler) declineInvitation(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	invitationId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Invitation.Decline(userId, invitationId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Create invite link
+// @Security		ApiKeyAuth
+// @Tags			invitations
+// @Description	Создание подписанной ссылки-приглашения в список. По умолчанию ссылка действует 7 дней, максимум - 30. Доступно только владельцу
+// @ID				create-invite-link
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"List ID"
+// @Param			input	body		entity.CreateInviteLinkInput	true	"link info"
+// @Success		200		{object}	entity.InviteLink
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/invite-links [post]
+func (h *Handler) createInviteLink(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.CreateInviteLinkInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := h.services.Invitation.CreateLink(userId, listId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// @Summary		Delete invite link
+// @Security		ApiKeyAuth
+// @Tags			invitations
+// @Description	Отзыв ссылки-приглашения. Доступно только владельцу
+// @ID				delete-invite-link
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"List ID"
+// @Param			link_id	path		int	true	"Link ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/invite-links/{link_id} [delete]
+func (h *Handler) deleteInviteLink(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	linkId, err := strconv.Atoi(c.Param("link_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Invitation.RevokeLink(userId, listId, linkId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Accept invite link
+// @Security		ApiKeyAuth
+// @Tags			invitations
+// @Description	Вступление в список по ссылке-приглашению
+// @ID				accept-invite-link
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.AcceptInviteLinkInput	true	"invite token"
+// @Success		200		{object}	entity.TodoList
+// @Failure		400,401	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/invite-links/accept [post]
+func (h *Handler) acceptInviteLink(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.AcceptInviteLinkInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	list, err := h.services.Invitation.AcceptLink(userId, input.Token)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
@@ -0,0 +1,182 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestInvitationHandler_inviteListMember(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockInvitation)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"username":"bob","role":"editor"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().Invite(1, 2, entity.InviteMemberInput{Username: "bob", Role: entity.ListRoleEditor}).
+					Return(entity.ListInvitation{Id: 5, ListId: 2, Role: entity.ListRoleEditor, CreatedAt: time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":5,"list_id":2,"list_title":"","invited_by":"","role":"editor","created_at":"2023-05-01T12:00:00Z"}`,
+		},
+		{
+			name:                "Unknown role",
+			inputBody:           `{"username":"bob","role":"admin"}`,
+			mockBehavior:        func(s *mock_service.MockInvitation) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"unknown role admin"}`,
+		},
+		{
+			name:                "BindJSON",
+			inputBody:           `{"role":"editor"}`,
+			mockBehavior:        func(s *mock_service.MockInvitation) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Not owner",
+			inputBody: `{"username":"bob","role":"viewer"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().Invite(1, 2, gomock.Any()).Return(entity.ListInvitation{}, service.ErrInsufficientRole)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"message":"insufficient role in list"}`,
+		},
+		{
+			name:      "Unknown user",
+			inputBody: `{"username":"nobody","role":"viewer"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().Invite(1, 2, gomock.Any()).Return(entity.ListInvitation{}, service.ErrUserNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"user not found"}`,
+		},
+		{
+			name:      "Already invited",
+			inputBody: `{"username":"bob","role":"viewer"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().Invite(1, 2, gomock.Any()).Return(entity.ListInvitation{}, service.ErrAlreadyInvited)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"user is already invited to the list"}`,
+		},
+		{
+			name:      "Already member",
+			inputBody: `{"username":"bob","role":"viewer"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().Invite(1, 2, gomock.Any()).Return(entity.ListInvitation{}, service.ErrAlreadyMember)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"user is already a member of the list"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			invitations := mock_service.NewMockInvitation(c)
+			tc.mockBehavior(invitations)
+
+			handler := NewHandler(&service.Service{Invitation: invitations})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/lists/:id/members", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.inviteListMember)
+
+			req := httptest.NewRequest("POST", "/api/v1/lists/2/members", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestInvitationHandler_acceptInviteLink(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockInvitation)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"token":"signed"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().AcceptLink(1, "signed").Return(entity.TodoList{Id: 2, Title: "List", Role: entity.ListRoleViewer}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":2,"title":"List","description":"","role":"viewer"}`,
+		},
+		{
+			name:                "BindJSON",
+			inputBody:           `{}`,
+			mockBehavior:        func(s *mock_service.MockInvitation) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Expired",
+			inputBody: `{"token":"signed"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().AcceptLink(1, "signed").Return(entity.TodoList{}, service.ErrInvalidInviteLink)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid or expired invite link"}`,
+		},
+		{
+			name:      "Already member",
+			inputBody: `{"token":"signed"}`,
+			mockBehavior: func(s *mock_service.MockInvitation) {
+				s.EXPECT().AcceptLink(1, "signed").Return(entity.TodoList{}, service.ErrAlreadyMember)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"user is already a member of the list"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			invitations := mock_service.NewMockInvitation(c)
+			tc.mockBehavior(invitations)
+
+			handler := NewHandler(&service.Service{Invitation: invitations})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/invite-links/accept", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.acceptInviteLink)
+
+			req := httptest.NewRequest("POST", "/api/v1/invite-links/accept", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
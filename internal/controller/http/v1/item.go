@@ -5,6 +5,8 @@ import (
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 )
 
 // @Summary		Create item
@@ -18,6 +20,7 @@ import (
 // @Param			input	body		entity.TodoItem	true	"item info"
 // @Success		200		{object}	idResponse
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
 // @Router			/api/v1/lists/{id}/items [post]
@@ -38,9 +41,13 @@ func (h *Handler) createItem(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
 		return
 	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 	id, err := h.services.TodoItem.Create(userId, listId, input)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		newListErrorResponse(c, err)
 		return
 	}
 
@@ -56,15 +63,18 @@ type getAllItemsResponse struct {
 // @Summary		Get All list item
 // @Security		ApiKeyAuth
 // @Tags			items
-// @Description	Получение списка задач
+// @Description	Получение списка задач. По умолчанию плоский список, где подзадачи идут сразу за родителем и имеют depth; с view=tree подзадачи вложены в children
 // @ID				get-all-list-items
 // @Accept			json
 // @Produce		json
-// @Param			id		path		int	true	"List ID"
-// @Success		200		{object}	getAllItemsResponse
-// @Failure		400,401	{object}	errorResponse
-// @Failure		500		{object}	errorResponse
-// @Failure		default	{object}	errorResponse
+// @Param			id			path		int			true	"List ID"
+// @Param			view		query		string		false	"Вид списка"	Enums(flat, tree)
+// @Param			label		query		[]string	false	"Метки, задача должна иметь хотя бы одну из них"	collectionFormat(csv)
+// @Param			priority	query		[]string	false	"Приоритеты: none, low, medium, high, urgent"		collectionFormat(csv)
+// @Success		200			{object}	getAllItemsResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
 // @Router			/api/v1/lists/{id}/items [get]
 func (h *Handler) getAllItems(c *gin.Context) {
 	userId, err := getUserId(c)
@@ -78,11 +88,20 @@ func (h *Handler) getAllItems(c *gin.Context) {
 		return
 	}
 
-	items, err := h.services.TodoItem.GetAll(userId, listId)
+	filter, err := bindItemFilter(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	items, err := h.services.TodoItem.GetAll(userId, listId, filter)
 	if err != nil {
 		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
 		return
 	}
+	if c.Query("view") == "tree" {
+		items = entity.BuildItemTree(items)
+	}
 
 	c.JSON(http.StatusOK, getAllItemsResponse{
 		Data: items,
@@ -99,6 +118,7 @@ func (h *Handler) getAllItems(c *gin.Context) {
 // @Param			id		path		int	true	"Item ID"
 // @Success		200		{object}	entity.TodoItem
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
 // @Router			/api/v1/items/{id} [get]
@@ -116,7 +136,7 @@ func (h *Handler) getItemById(c *gin.Context) {
 
 	item, err := h.services.TodoItem.GetById(userId, itemId)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		newListErrorResponse(c, err)
 		return
 	}
 
@@ -126,14 +146,15 @@ func (h *Handler) getItemById(c *gin.Context) {
 // @Summary		Update list item
 // @Security		ApiKeyAuth
 // @Tags			items
-// @Description	Обновление задачи
+// @Description	Обновление задачи. assignee_id назначает исполнителя из участников списка, null снимает назначение
 // @ID				update-item
 // @Accept			json
 // @Produce		json
 // @Param			id		path		int				true	"List ID"
-// @Param			input	body		entity.TodoItem	true	"item info"
+// @Param			input	body		entity.UpdateItemInput	true	"item info. start_at/due_at: null очищает срок, отсутствие поля оставляет его"
 // @Success		200		{object}	statusResponse
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
 // @Router			/api/v1/items/{id} [put]
@@ -154,9 +175,13 @@ func (h *Handler) updateItem(c *gin.Context) {
 		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
 		return
 	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
 
 	if err = h.services.TodoItem.Update(userId, itemId, input); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		newListErrorResponse(c, err)
 		return
 	}
 
@@ -169,17 +194,60 @@ func (h *Handler) updateItem(c *gin.Context) {
 // @Summary		Delete list item
 // @Security		ApiKeyAuth
 // @Tags			items
-// @Description	Удаление задачи
+// @Description	Удаление задачи вместе с подзадачами. С keep_children=true подзадачи переносятся на уровень удаляемой задачи
 // @ID				delete-item
 // @Accept			json
 // @Produce		json
-// @Param			id		path		int	true	"Item ID"
+// @Param			id				path		int		true	"Item ID"
+// @Param			keep_children	query		bool	false	"Сохранить подзадачи"
+// @Success		200				{object}	statusResponse
+// @Failure		400,401			{object}	errorResponse
+// @Failure		403,404			{object}	errorResponse
+// @Failure		500				{object}	errorResponse
+// @Failure		default			{object}	errorResponse
+// @Router			/api/v1/items/{id} [delete]
+func (h *Handler) deleteItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	keepChildren, _ := strconv.ParseBool(c.Query("keep_children"))
+
+	if err = h.services.TodoItem.Delete(userId, itemId, keepChildren); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+
+}
+
+// @Summary		Move list item
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Перестановка задачи сразу перед before_id или сразу после after_id. Задача становится подзадачей того же родителя, что и якорь.
+// @Description	С list_id задача вместе с подзадачами переносится в другой список: без якоря в конец, с якорем из целевого списка - рядом с ним
+// @ID				move-item
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int						true	"Item ID"
+// @Param			input	body		entity.MoveItemInput	true	"target list and anchor"
 // @Success		200		{object}	statusResponse
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
-// @Router			/api/v1/items/{id} [delete]
-func (h *Handler) deleteItem(c *gin.Context) {
+// @Router			/api/v1/items/{item_id}/move [post]
+func (h *Handler) moveItem(c *gin.Context) {
 	userId, err := getUserId(c)
 	if err != nil {
 		return
@@ -191,13 +259,314 @@ func (h *Handler) deleteItem(c *gin.Context) {
 		return
 	}
 
-	if err = h.services.TodoItem.Delete(userId, itemId); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+	var input entity.MoveItemInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.TodoItem.Move(userId, itemId, input); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Copy list item
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Копирование задачи вместе с подзадачами и метками. Без list_id копия создаётся в том же списке рядом с исходной задачей
+// @ID				copy-item
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int						true	"Item ID"
+// @Param			input	body		entity.CopyItemInput	false	"target list"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/copy [post]
+func (h *Handler) copyItem(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.CopyItemInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+			return
+		}
+	}
+
+	id, err := h.services.TodoItem.Copy(userId, itemId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+// @Summary		Skip occurrence
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Пропуск текущего повторения: задача переносится на следующее повторение, не отмечаясь выполненной
+// @ID				skip-item-occurrence
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int	true	"Item ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/skip [post]
+func (h *Handler) skipItemOccurrence(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.TodoItem.Skip(userId, itemId); err != nil {
+		newListErrorResponse(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, statusResponse{
 		Status: "ok",
 	})
+}
+
+type getOccurrencesResponse struct {
+	Data []time.Time `json:"data"`
+}
+
+// @Summary		Preview occurrences
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Ближайшие повторения задачи начиная с текущего срока, время - в часовом поясе пользователя
+// @ID				get-item-occurrences
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int	true	"Item ID"
+// @Param			count	query		int	false	"Сколько повторений показать, от 1 до 100"	default(10)
+// @Success		200		{object}	getOccurrencesResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/occurrences [get]
+func (h *Handler) getItemOccurrences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	count, err := strconv.Atoi(c.DefaultQuery("count", "10"))
+	if err != nil || count < 1 || count > 100 {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidCount)
+		return
+	}
+
+	occurrences, err := h.services.TodoItem.Occurrences(userId, itemId, count)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getOccurrencesResponse{
+		Data: occurrences,
+	})
+}
+
+// @Summary		Get overdue items
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Невыполненные задачи из всех списков с истёкшим сроком
+// @ID				get-overdue-items
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllItemsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/overdue [get]
+func (h *Handler) getOverdueItems(c *gin.Context) {
+	h.getDueItems(c, entity.DueOverdue)
+}
+
+// @Summary		Get items due today
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Невыполненные задачи из всех списков со сроком на сегодня в часовом поясе пользователя
+// @ID				get-items-due-today
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllItemsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/today [get]
+func (h *Handler) getItemsDueToday(c *gin.Context) {
+	h.getDueItems(c, entity.DueToday)
+}
+
+// @Summary		Get items due this week
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Невыполненные задачи из всех списков со сроком с сегодняшнего дня до конца недели (воскресенья) в часовом поясе пользователя
+// @ID				get-items-due-this-week
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllItemsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/week [get]
+func (h *Handler) getItemsDueThisWeek(c *gin.Context) {
+	h.getDueItems(c, entity.DueWeek)
+}
+
+func (h *Handler) getDueItems(c *gin.Context, period string) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	items, err := h.services.TodoItem.GetDue(userId, period)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllItemsResponse{
+		Data: items,
+	})
+}
+
+// @Summary		Get assigned items
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Невыполненные задачи из всех списков, назначенные на пользователя. Сначала задачи с ближайшим сроком
+// @ID				get-assigned-items
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllItemsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/assigned [get]
+func (h *Handler) getAssignedItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	items, err := h.services.TodoItem.GetAssigned(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllItemsResponse{
+		Data: items,
+	})
+}
+
+// @Summary		Search items
+// @Security		ApiKeyAuth
+// @Tags			items
+// @Description	Поиск задач во всех списках пользователя по тексту, меткам и приоритету. Результат упорядочен по убыванию приоритета
+// @ID				search-items
+// @Accept			json
+// @Produce		json
+// @Param			q			query		string		false	"Текст в названии или описании"
+// @Param			label		query		[]string	false	"Метки, задача должна иметь хотя бы одну из них"	collectionFormat(csv)
+// @Param			priority	query		[]string	false	"Приоритеты: none, low, medium, high, urgent"		collectionFormat(csv)
+// @Success		200			{object}	getAllItemsResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
+// @Router			/api/v1/items/search [get]
+func (h *Handler) searchItems(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	filter, err := bindItemFilter(c)
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	filter.Query = strings.TrimSpace(c.Query("q"))
+
+	items, err := h.services.TodoItem.Search(userId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
 
+	c.JSON(http.StatusOK, getAllItemsResponse{
+		Data: items,
+	})
+}
+
+// bindItemFilter читает фильтр из параметров label и priority. Значения можно перечислять через запятую или повторять параметр.
+func bindItemFilter(c *gin.Context) (entity.ItemFilter, error) {
+	var filter entity.ItemFilter
+
+	filter.Labels = splitQueryArray(c, "label")
+	for _, name := range splitQueryArray(c, "priority") {
+		priority, err := entity.ParsePriority(name)
+		if err != nil {
+			return filter, err
+		}
+		filter.Priorities = append(filter.Priorities, priority)
+	}
+
+	return filter, nil
+}
+
+func splitQueryArray(c *gin.Context, key string) []string {
+	var values []string
+	for _, param := range c.QueryArray(key) {
+		for _, value := range strings.Split(param, ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
 }
@@ -3,6 +3,7 @@ package v1
 import (
 	"bytes"
 	"errors"
+	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/IncubusX/go-todo-app/internal/service"
 	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestTodoItemHandler_createItem(t *testing.T) {
@@ -169,11 +171,66 @@ func TestTodoItemHandler_getAllItems(t *testing.T) {
 			},
 			url: "/api/v1/lists/1/items",
 			mockBehavior: func(s *mock_service.MockTodoItem, userId, listId int) {
-				s.EXPECT().GetAll(userId, listId).Return([]entity.TodoItem{}, nil)
+				s.EXPECT().GetAll(userId, listId, entity.ItemFilter{}).Return([]entity.TodoItem{}, nil)
 			},
 			expectedStatusCode:  200,
 			expectedRequestBody: `{"data":[]}`,
 		},
+		{
+			name:   "Ok_Filter",
+			userId: 1,
+			listId: 1,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/lists/1/items?label=work,%23errands&priority=high&priority=urgent",
+			mockBehavior: func(s *mock_service.MockTodoItem, userId, listId int) {
+				filter := entity.ItemFilter{
+					Labels:     []string{"work", "#errands"},
+					Priorities: []entity.Priority{entity.PriorityHigh, entity.PriorityUrgent},
+				}
+				s.EXPECT().GetAll(userId, listId, filter).Return([]entity.TodoItem{
+					{Id: 1, Title: "test", Priority: entity.PriorityHigh, Labels: entity.LabelList{{Id: 2, Name: "work", Color: "#ff0000"}}},
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"id":1,"title":"test","description":"","done":false,"priority":"high","labels":[{"id":2,"name":"work","color":"#ff0000"}]}]}`,
+		},
+		{
+			name:   "Ok_Tree",
+			userId: 1,
+			listId: 1,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/lists/1/items?view=tree",
+			mockBehavior: func(s *mock_service.MockTodoItem, userId, listId int) {
+				parentId := 1
+				progress := 0.5
+				s.EXPECT().GetAll(userId, listId, entity.ItemFilter{}).Return([]entity.TodoItem{
+					{Id: 1, Title: "parent", Progress: &progress},
+					{Id: 2, Title: "child", Done: true, ParentId: &parentId, Depth: 1},
+					{Id: 3, Title: "other"},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"data":[{"id":1,"title":"parent","description":"","done":false,"progress":0.5,` +
+				`"children":[{"id":2,"title":"child","description":"","done":true,"parent_id":1,"depth":1}]},` +
+				`{"id":3,"title":"other","description":"","done":false}]}`,
+		},
+		{
+			name:   "Unknown priority",
+			userId: 1,
+			listId: 1,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/lists/1/items?priority=later",
+			mockBehavior: func(s *mock_service.MockTodoItem, userId, listId int) {
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"unknown priority \"later\""}`,
+		},
 		{
 			name:   "Bad Request",
 			userId: 1,
@@ -196,7 +253,7 @@ func TestTodoItemHandler_getAllItems(t *testing.T) {
 			},
 			url: "/api/v1/lists/1/items",
 			mockBehavior: func(s *mock_service.MockTodoItem, userId, listId int) {
-				s.EXPECT().GetAll(userId, listId).Return([]entity.TodoItem{}, errors.New(ErrServiceFailure))
+				s.EXPECT().GetAll(userId, listId, entity.ItemFilter{}).Return([]entity.TodoItem{}, errors.New(ErrServiceFailure))
 			},
 			expectedStatusCode:  500,
 			expectedRequestBody: `{"message":"service failure"}`,
@@ -339,6 +396,7 @@ func TestTodoItemHandler_updateItem(t *testing.T) {
 	type mockBehavior func(s *mock_service.MockTodoItem, userId, itemId int, inputItem entity.UpdateItemInput)
 	var testString = "test"
 	var testBool = true
+	var testAssignee = 3
 
 	tt := []struct {
 		name                string
@@ -430,6 +488,42 @@ func TestTodoItemHandler_updateItem(t *testing.T) {
 			expectedStatusCode:  500,
 			expectedRequestBody: `{"message":"service failure"}`,
 		},
+		{
+			name:      "Invalid assignee",
+			userId:    1,
+			itemId:    1,
+			inputBody: `{"assignee_id":3}`,
+			inputItem: entity.UpdateItemInput{
+				AssigneeId: entity.NullableInt{Set: true, Value: &testAssignee},
+			},
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/items/1",
+			mockBehavior: func(s *mock_service.MockTodoItem, userId, itemId int, inputItem entity.UpdateItemInput) {
+				s.EXPECT().Update(userId, itemId, inputItem).Return(service.ErrInvalidAssignee)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"assignee must be a member of the item's list"}`,
+		},
+		{
+			name:      "Invalid schedule",
+			userId:    1,
+			itemId:    1,
+			inputBody: `{"due_at":null}`,
+			inputItem: entity.UpdateItemInput{
+				DueAt: entity.NullableTime{Set: true},
+			},
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/items/1",
+			mockBehavior: func(s *mock_service.MockTodoItem, userId, itemId int, inputItem entity.UpdateItemInput) {
+				s.EXPECT().Update(userId, itemId, inputItem).Return(fmt.Errorf("%w: rrule requires due_at", service.ErrInvalidSchedule))
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid schedule: rrule requires due_at"}`,
+		},
 		{
 			name:      "Bad Ctx",
 			userId:    1,
@@ -498,7 +592,7 @@ func TestTodoItemHandler_deleteItem(t *testing.T) {
 			},
 			url: "/api/v1/items/1",
 			mockBehavior: func(s *mock_service.MockTodoItem, userId, itemId int) {
-				s.EXPECT().Delete(userId, itemId)
+				s.EXPECT().Delete(userId, itemId, false)
 			},
 			expectedStatusCode:  200,
 			expectedRequestBody: `{"status":"ok"}`,
@@ -525,7 +619,7 @@ func TestTodoItemHandler_deleteItem(t *testing.T) {
 			},
 			url: "/api/v1/items/1",
 			mockBehavior: func(s *mock_service.MockTodoItem, userId, itemId int) {
-				s.EXPECT().Delete(userId, itemId).Return(errors.New(ErrServiceFailure))
+				s.EXPECT().Delete(userId, itemId, false).Return(errors.New(ErrServiceFailure))
 			},
 			expectedStatusCode:  500,
 			expectedRequestBody: `{"message":"service failure"}`,
@@ -569,3 +663,293 @@ func TestTodoItemHandler_deleteItem(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoItemHandler_getDueItems(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	dueAt := time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name                string
+		url                 string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Today",
+			url:  "/api/v1/items/today",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetDue(1, entity.DueToday).Return([]entity.TodoItem{{Id: 3, ListId: 2, Title: "Item", DueAt: &dueAt, AllDay: true}}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"id":3,"title":"Item","description":"","done":false,"list_id":2,"due_at":"2023-05-03T00:00:00Z","all_day":true}]}`,
+		},
+		{
+			name: "Overdue",
+			url:  "/api/v1/items/overdue",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetDue(1, entity.DueOverdue).Return([]entity.TodoItem{}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[]}`,
+		},
+		{
+			name: "Service failure",
+			url:  "/api/v1/items/week",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetDue(1, entity.DueWeek).Return(nil, errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			tc.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			setCtx := func(c *gin.Context) { c.Set(userCtx, 1) }
+			r.GET("/api/v1/items/overdue", setCtx, handler.getOverdueItems)
+			r.GET("/api/v1/items/today", setCtx, handler.getItemsDueToday)
+			r.GET("/api/v1/items/week", setCtx, handler.getItemsDueThisWeek)
+
+			req := httptest.NewRequest("GET", tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestTodoItemHandler_getAssignedItems(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	assigneeId := 1
+
+	tt := []struct {
+		name                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetAssigned(1).Return([]entity.TodoItem{{Id: 3, ListId: 2, Title: "Item", AssigneeId: &assigneeId}}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[{"id":3,"title":"Item","description":"","done":false,"list_id":2,"assignee_id":1}]}`,
+		},
+		{
+			name: "Service failure",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().GetAssigned(1).Return(nil, errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			tc.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/v1/me/assigned", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.getAssignedItems)
+
+			req := httptest.NewRequest("GET", "/api/v1/me/assigned", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestTodoItemHandler_searchItems(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	todoItem := mock_service.NewMockTodoItem(c)
+	todoItem.EXPECT().Search(1, entity.ItemFilter{
+		Labels:     []string{"errands"},
+		Priorities: []entity.Priority{entity.PriorityUrgent},
+		Query:      "milk",
+	}).Return([]entity.TodoItem{{Id: 4, ListId: 2, Title: "Buy milk", Priority: entity.PriorityUrgent}}, nil)
+
+	handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.GET("/api/v1/items/search", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.searchItems)
+
+	req := httptest.NewRequest("GET", "/api/v1/items/search?q=+milk&label=errands&priority=URGENT", nil)
+
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"data":[{"id":4,"title":"Buy milk","description":"","done":false,"list_id":2,"priority":"urgent"}]}`, w.Body.String())
+}
+
+func TestTodoItemHandler_copyItem(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	listId := 3
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok_SameList",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Copy(1, 5, entity.CopyItemInput{}).Return(8, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":8}`,
+		},
+		{
+			name:      "Ok_OtherList",
+			inputBody: `{"list_id":3}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Copy(1, 5, entity.CopyItemInput{ListId: &listId}).Return(9, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":9}`,
+		},
+		{
+			name:      "Read-only target",
+			inputBody: `{"list_id":3}`,
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Copy(1, 5, entity.CopyItemInput{ListId: &listId}).Return(0, service.ErrInsufficientRole)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"message":"insufficient role in list"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			tc.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/items/:item_id/copy", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.copyItem)
+
+			req := httptest.NewRequest("POST", "/api/v1/items/5/copy", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestTodoItemHandler_getItemOccurrences(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoItem)
+
+	moscow := time.FixedZone("MSK", 3*60*60)
+
+	tt := []struct {
+		name                string
+		url                 string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/v1/items/5/occurrences?count=2",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Occurrences(1, 5, 2).Return([]time.Time{
+					time.Date(2023, 5, 5, 9, 0, 0, 0, moscow),
+					time.Date(2023, 5, 12, 9, 0, 0, 0, moscow),
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":["2023-05-05T09:00:00+03:00","2023-05-12T09:00:00+03:00"]}`,
+		},
+		{
+			name: "Default count",
+			url:  "/api/v1/items/5/occurrences",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Occurrences(1, 5, 10).Return([]time.Time{}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[]}`,
+		},
+		{
+			name:                "Invalid count",
+			url:                 "/api/v1/items/5/occurrences?count=1000",
+			mockBehavior:        func(s *mock_service.MockTodoItem) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"count must be between 1 and 100"}`,
+		},
+		{
+			name: "Not recurring",
+			url:  "/api/v1/items/5/occurrences",
+			mockBehavior: func(s *mock_service.MockTodoItem) {
+				s.EXPECT().Occurrences(1, 5, 10).Return(nil, service.ErrNotRecurring)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"item is not recurring"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoItem := mock_service.NewMockTodoItem(c)
+			tc.mockBehavior(todoItem)
+
+			handler := NewHandler(&service.Service{TodoItem: todoItem})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/v1/items/:item_id/occurrences", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.getItemOccurrences)
+
+			req := httptest.NewRequest("GET", tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,265 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// @Summary		Create label
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Создание метки. Ведущий # в названии отбрасывается, цвет задаётся в формате #rrggbb
+// @ID				create-label
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.Label	true	"label info"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/labels [post]
+func (h *Handler) createLabel(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.Label
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Label.Create(userId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+type getAllLabelsResponse struct {
+	Data []entity.Label `json:"data"`
+}
+
+// @Summary		Get all labels
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Метки пользователя
+// @ID				get-all-labels
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllLabelsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/labels [get]
+func (h *Handler) getAllLabels(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labels, err := h.services.Label.GetAll(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllLabelsResponse{
+		Data: labels,
+	})
+}
+
+// @Summary		Get label by ID
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Получение метки по ИД
+// @ID				get-label-by-id
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Label ID"
+// @Success		200		{object}	entity.Label
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/labels/{id} [get]
+func (h *Handler) getLabelById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	label, err := h.services.Label.GetById(userId, labelId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, label)
+}
+
+// @Summary		Update label
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Переименование метки или смена цвета
+// @ID				update-label
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int						true	"Label ID"
+// @Param			input	body		entity.UpdateLabelInput	true	"label info"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404,409	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/labels/{id} [patch]
+func (h *Handler) updateLabel(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.UpdateLabelInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.Label.Update(userId, labelId, input); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Delete label
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Удаление метки, она снимается со всех задач
+// @ID				delete-label
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Label ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/labels/{id} [delete]
+func (h *Handler) deleteLabel(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	labelId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Label.Delete(userId, labelId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Attach label to item
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Добавление метки к задаче. Нужны права на редактирование списка
+// @ID				attach-label
+// @Accept			json
+// @Produce		json
+// @Param			item_id		path		int	true	"Item ID"
+// @Param			label_id	path		int	true	"Label ID"
+// @Success		200			{object}	statusResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		403,404		{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/labels/{label_id} [post]
+func (h *Handler) attachLabel(c *gin.Context) {
+	h.changeItemLabel(c, h.services.Label.Attach)
+}
+
+// @Summary		Detach label from item
+// @Security		ApiKeyAuth
+// @Tags			labels
+// @Description	Снятие метки с задачи. Нужны права на редактирование списка
+// @ID				detach-label
+// @Accept			json
+// @Produce		json
+// @Param			item_id		path		int	true	"Item ID"
+// @Param			label_id	path		int	true	"Label ID"
+// @Success		200			{object}	statusResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		403,404		{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/labels/{label_id} [delete]
+func (h *Handler) detachLabel(c *gin.Context) {
+	h.changeItemLabel(c, h.services.Label.Detach)
+}
+
+func (h *Handler) changeItemLabel(c *gin.Context, change func(userId, itemId, labelId int) error) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	labelId, err := strconv.Atoi(c.Param("label_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = change(userId, itemId, labelId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
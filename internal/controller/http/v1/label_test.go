@@ -0,0 +1,153 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLabelHandler_createLabel(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockLabel)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"name":"#work","color":"#ff0000"}`,
+			mockBehavior: func(s *mock_service.MockLabel) {
+				s.EXPECT().Create(1, entity.Label{Name: "work", Color: "#ff0000"}).Return(2, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":2}`,
+		},
+		{
+			name:                "Invalid color",
+			inputBody:           `{"name":"work","color":"red"}`,
+			mockBehavior:        func(s *mock_service.MockLabel) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"color must be in #rrggbb format"}`,
+		},
+		{
+			name:                "Empty name",
+			inputBody:           `{"name":"#"}`,
+			mockBehavior:        func(s *mock_service.MockLabel) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"label name must be 1 to 64 characters long"}`,
+		},
+		{
+			name:      "Already exists",
+			inputBody: `{"name":"work"}`,
+			mockBehavior: func(s *mock_service.MockLabel) {
+				s.EXPECT().Create(1, entity.Label{Name: "work", Color: "#808080"}).Return(0, service.ErrLabelExists)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"label with this name already exists"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			labels := mock_service.NewMockLabel(c)
+			tc.mockBehavior(labels)
+
+			handler := NewHandler(&service.Service{Label: labels})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/labels", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.createLabel)
+
+			req := httptest.NewRequest("POST", "/api/v1/labels", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestLabelHandler_attachLabel(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockLabel)
+
+	tt := []struct {
+		name                string
+		url                 string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/v1/items/3/labels/2",
+			mockBehavior: func(s *mock_service.MockLabel) {
+				s.EXPECT().Attach(1, 3, 2).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Bad Request",
+			url:                 "/api/v1/items/3/labels/WrongPath",
+			mockBehavior:        func(s *mock_service.MockLabel) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name: "Viewer",
+			url:  "/api/v1/items/3/labels/2",
+			mockBehavior: func(s *mock_service.MockLabel) {
+				s.EXPECT().Attach(1, 3, 2).Return(service.ErrInsufficientRole)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"message":"insufficient role in list"}`,
+		},
+		{
+			name: "Label not found",
+			url:  "/api/v1/items/3/labels/2",
+			mockBehavior: func(s *mock_service.MockLabel) {
+				s.EXPECT().Attach(1, 3, 2).Return(service.ErrLabelNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"label not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			labels := mock_service.NewMockLabel(c)
+			tc.mockBehavior(labels)
+
+			handler := NewHandler(&service.Service{Label: labels})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/items/:item_id/labels/:label_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.attachLabel)
+
+			req := httptest.NewRequest("POST", tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package v1
 
 import (
+	"errors"
 	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strconv"
@@ -85,6 +87,7 @@ func (h *Handler) getAllLists(c *gin.Context) {
 // @Param			id		path		int	true	"List ID"
 // @Success		200		{object}	entity.TodoList
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
 // @Router			/api/v1/lists/{id} [get]
@@ -102,7 +105,7 @@ func (h *Handler) getListById(c *gin.Context) {
 
 	list, err := h.services.TodoList.GetById(userId, listId)
 	if err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		newListErrorResponse(c, err)
 		return
 	}
 
@@ -121,6 +124,7 @@ func (h *Handler) getListById(c *gin.Context) {
 // @Success		200		{object}	statusResponse
 // @Success		200		{object}	statusResponse
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
 // @Router			/api/v1/lists/{id} [put]
@@ -143,7 +147,7 @@ func (h *Handler) updateList(c *gin.Context) {
 	}
 
 	if err = h.services.TodoList.Update(userId, listId, input); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		newListErrorResponse(c, err)
 		return
 	}
 
@@ -162,6 +166,7 @@ func (h *Handler) updateList(c *gin.Context) {
 // @Param			id		path		int	true	"List ID"
 // @Success		200		{object}	statusResponse
 // @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
 // @Failure		500		{object}	errorResponse
 // @Failure		default	{object}	errorResponse
 // @Router			/api/v1/lists/{id} [delete]
@@ -178,7 +183,7 @@ func (h *Handler) deleteList(c *gin.Context) {
 	}
 
 	if err = h.services.TodoList.Delete(userId, listId); err != nil {
-		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		newListErrorResponse(c, err)
 		return
 	}
 
@@ -186,3 +191,168 @@ func (h *Handler) deleteList(c *gin.Context) {
 		Status: "ok",
 	})
 }
+
+// @Summary		Move list
+// @Security		ApiKeyAuth
+// @Tags			lists
+// @Description	Перестановка списка в боковой панели пользователя: сразу перед before_id или сразу после after_id
+// @ID				move-list
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int					true	"List ID"
+// @Param			input	body		entity.MoveInput	true	"anchor"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/move [post]
+func (h *Handler) moveList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.MoveInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.TodoList.Move(userId, listId, input); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Duplicate list
+// @Security		ApiKeyAuth
+// @Tags			lists
+// @Description	Копирование списка со всеми задачами, подзадачами и метками. Задачи в копии не выполнены
+// @ID				duplicate-list
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"List ID"
+// @Param			input	body		entity.DuplicateListInput	false	"copy title"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/duplicate [post]
+func (h *Handler) duplicateList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.DuplicateListInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+			return
+		}
+	}
+
+	id, err := h.services.TodoList.Duplicate(userId, listId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+// newListErrorResponse отвечает на ошибки доступа к спискам, задачам, участникам и приглашениям.
+func newListErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrListNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrListNotFound)
+	case errors.Is(err, service.ErrItemNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrItemNotFound)
+	case errors.Is(err, service.ErrMemberNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrMemberNotFound)
+	case errors.Is(err, service.ErrInvitationNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrInvitationNotFound)
+	case errors.Is(err, service.ErrInviteLinkNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrInviteLinkNotFound)
+	case errors.Is(err, service.ErrPublicLinkNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrPublicLinkNotFound)
+	case errors.Is(err, service.ErrLabelNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrLabelNotFound)
+	case errors.Is(err, service.ErrTemplateNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrTemplateNotFound)
+	case errors.Is(err, service.ErrNotificationNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrNotificationNotFound)
+	case errors.Is(err, service.ErrReminderNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrReminderNotFound)
+	case errors.Is(err, service.ErrCommentNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrCommentNotFound)
+	case errors.Is(err, service.ErrAttachmentNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrAttachmentNotFound)
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		newErrorResponse(c, http.StatusRequestEntityTooLarge, ErrAttachmentTooLarge)
+	case errors.Is(err, service.ErrUnsupportedFileType):
+		newErrorResponse(c, http.StatusUnsupportedMediaType, ErrUnsupportedFileType)
+	case errors.Is(err, service.ErrMissingVariables), errors.Is(err, service.ErrInvalidSchedule):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrInvalidParent):
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidParent)
+	case errors.Is(err, service.ErrInvalidAnchor):
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidAnchor)
+	case errors.Is(err, service.ErrTitleTooLong):
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+	case errors.Is(err, service.ErrInvalidAssignee):
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidAssignee)
+	case errors.Is(err, service.ErrInvalidCommentParent):
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidCommentParent)
+	case errors.Is(err, service.ErrNotRecurring):
+		newErrorResponse(c, http.StatusBadRequest, ErrNotRecurring)
+	case errors.Is(err, service.ErrNoDueDate):
+		newErrorResponse(c, http.StatusBadRequest, ErrNoDueDate)
+	case errors.Is(err, service.ErrReminderInPast):
+		newErrorResponse(c, http.StatusBadRequest, ErrReminderInPast)
+	case errors.Is(err, service.ErrInvalidInviteLink):
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInviteLink)
+	case errors.Is(err, service.ErrUserNotFound):
+		newErrorResponse(c, http.StatusNotFound, ErrUnknownUser)
+	case errors.Is(err, service.ErrInsufficientRole):
+		newErrorResponse(c, http.StatusForbidden, ErrInsufficientRole)
+	case errors.Is(err, service.ErrNotCommentAuthor):
+		newErrorResponse(c, http.StatusForbidden, ErrNotCommentAuthor)
+	case errors.Is(err, service.ErrAlreadyMember):
+		newErrorResponse(c, http.StatusConflict, ErrAlreadyMember)
+	case errors.Is(err, service.ErrAlreadyInvited):
+		newErrorResponse(c, http.StatusConflict, ErrAlreadyInvited)
+	case errors.Is(err, service.ErrLastOwner):
+		newErrorResponse(c, http.StatusConflict, ErrLastOwner)
+	case errors.Is(err, service.ErrLabelExists):
+		newErrorResponse(c, http.StatusConflict, ErrLabelExists)
+	case errors.Is(err, service.ErrNoMoreOccurrences):
+		newErrorResponse(c, http.StatusConflict, ErrNoMoreOccurrences)
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+	}
+}
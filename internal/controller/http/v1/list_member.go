@@ -0,0 +1,146 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type getAllListMembersResponse struct {
+	Data []entity.ListMember `json:"data"`
+}
+
+// @Summary		Get list members
+// @Security		ApiKeyAuth
+// @Tags			members
+// @Description	Участники списка и их роли
+// @ID				get-all-list-members
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"List ID"
+// @Success		200		{object}	getAllListMembersResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/members [get]
+func (h *Handler) getAllListMembers(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	members, err := h.services.ListMember.GetAll(userId, listId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllListMembersResponse{
+		Data: members,
+	})
+}
+
+// @Summary		Update list member
+// @Security		ApiKeyAuth
+// @Tags			members
+// @Description	Изменение роли участника списка. Доступно только владельцу
+// @ID				update-list-member
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"List ID"
+// @Param			user_id	path		int							true	"User ID"
+// @Param			input	body		entity.UpdateMemberInput	true	"member role"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/members/{user_id} [patch]
+func (h *Handler) updateListMember(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	memberId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.UpdateMemberInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.ListMember.UpdateRole(userId, listId, memberId, input); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Delete list member
+// @Security		ApiKeyAuth
+// @Tags			members
+// @Description	Исключение участника из списка. Владелец может исключить любого, остальные - только выйти сами
+// @ID				delete-list-member
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"List ID"
+// @Param			user_id	path		int	true	"User ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/members/{user_id} [delete]
+func (h *Handler) deleteListMember(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	memberId, err := strconv.Atoi(c.Param("user_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.ListMember.Remove(userId, listId, memberId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
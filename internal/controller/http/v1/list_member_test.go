@@ -0,0 +1,82 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestListMemberHandler_deleteListMember(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListMember)
+
+	tt := []struct {
+		name                string
+		url                 string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok",
+			url:  "/api/v1/lists/2/members/3",
+			mockBehavior: func(s *mock_service.MockListMember) {
+				s.EXPECT().Remove(1, 2, 3).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Bad Request",
+			url:                 "/api/v1/lists/2/members/WrongPath",
+			mockBehavior:        func(s *mock_service.MockListMember) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name: "Last owner",
+			url:  "/api/v1/lists/2/members/1",
+			mockBehavior: func(s *mock_service.MockListMember) {
+				s.EXPECT().Remove(1, 2, 1).Return(service.ErrLastOwner)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"list must keep at least one owner"}`,
+		},
+		{
+			name: "Member not found",
+			url:  "/api/v1/lists/2/members/3",
+			mockBehavior: func(s *mock_service.MockListMember) {
+				s.EXPECT().Remove(1, 2, 3).Return(service.ErrMemberNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"list member not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			members := mock_service.NewMockListMember(c)
+			tc.mockBehavior(members)
+
+			handler := NewHandler(&service.Service{ListMember: members})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.DELETE("/api/v1/lists/:id/members/:user_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.deleteListMember)
+
+			req := httptest.NewRequest("DELETE", tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
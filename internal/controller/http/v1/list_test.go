@@ -493,6 +493,34 @@ func TestTodoListHandler_deleteList(t *testing.T) {
 			expectedStatusCode:  500,
 			expectedRequestBody: `{"message":"service failure"}`,
 		},
+		{
+			name:   "Not owner",
+			userId: 1,
+			listId: 1,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/lists/1",
+			mockBehavior: func(s *mock_service.MockTodoList, userId, listId int) {
+				s.EXPECT().Delete(userId, listId).Return(service.ErrInsufficientRole)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"message":"insufficient role in list"}`,
+		},
+		{
+			name:   "Not found",
+			userId: 1,
+			listId: 1,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/lists/1",
+			mockBehavior: func(s *mock_service.MockTodoList, userId, listId int) {
+				s.EXPECT().Delete(userId, listId).Return(service.ErrListNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"list not found"}`,
+		},
 		{
 			name:   "Bad Ctx",
 			userId: 1,
@@ -532,3 +560,67 @@ func TestTodoListHandler_deleteList(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoListHandler_moveList(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTodoList)
+
+	anchorId := 2
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"before_id":2}`,
+			mockBehavior: func(s *mock_service.MockTodoList) {
+				s.EXPECT().Move(1, 3, entity.MoveInput{BeforeId: &anchorId}).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Both anchors",
+			inputBody:           `{"before_id":2,"after_id":4}`,
+			mockBehavior:        func(s *mock_service.MockTodoList) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"exactly one of before_id and after_id is required"}`,
+		},
+		{
+			name:      "Invalid anchor",
+			inputBody: `{"after_id":2}`,
+			mockBehavior: func(s *mock_service.MockTodoList) {
+				s.EXPECT().Move(1, 3, entity.MoveInput{AfterId: &anchorId}).Return(service.ErrInvalidAnchor)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid move anchor"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			todoList := mock_service.NewMockTodoList(c)
+			tc.mockBehavior(todoList)
+
+			handler := NewHandler(&service.Service{TodoList: todoList})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/lists/:id/move", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.moveList)
+
+			req := httptest.NewRequest("POST", "/api/v1/lists/3/move", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
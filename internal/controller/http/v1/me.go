@@ -0,0 +1,154 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// @Summary		Get profile
+// @Security		ApiKeyAuth
+// @Tags			me
+// @Description	Профиль текущего пользователя
+// @ID				get-profile
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	entity.Profile
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me [get]
+func (h *Handler) getProfile(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	profile, err := h.services.Authorization.GetProfile(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, profile)
+}
+
+// @Summary		Update profile
+// @Security		ApiKeyAuth
+// @Tags			me
+// @Description	Изменение имени, логина и адреса почты текущего пользователя. Новый адрес нужно подтвердить
+// @ID				update-profile
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.UpdateUserInput	true	"profile info"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me [patch]
+func (h *Handler) updateProfile(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.UpdateUserInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.Authorization.UpdateProfile(userId, input); err != nil {
+		switch {
+		case errors.Is(err, service.ErrUsernameTaken):
+			newErrorResponse(c, http.StatusConflict, ErrUsernameTaken)
+		case errors.Is(err, service.ErrEmailTaken):
+			newErrorResponse(c, http.StatusConflict, ErrEmailTaken)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Delete account
+// @Security		ApiKeyAuth
+// @Tags			me
+// @Description	Удаление аккаунта вместе со списками, которые больше никому не доступны. В общих списках, где пользователь
+// @Description	был единственным владельцем, владельцем становится самый давний участник
+// @ID				delete-profile
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me [delete]
+func (h *Handler) deleteProfile(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	if err = h.services.Authorization.DeleteUser(userId); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Change password
+// @Security		ApiKeyAuth
+// @Tags			me
+// @Description	Смена пароля. Все сессии и персональные токены отзываются, в ответе - новая пара токенов
+// @ID				change-password
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.ChangePasswordInput	true	"passwords"
+// @Success		200		{object}	signInResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/password [post]
+func (h *Handler) changePassword(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.ChangePasswordInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	tokens, err := h.services.Authorization.ChangePassword(userId, input, getClient(c))
+	if err != nil {
+		if errors.Is(err, service.ErrInvalidCredentials) {
+			newErrorResponse(c, http.StatusForbidden, ErrInvalidCredentials)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, signInResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
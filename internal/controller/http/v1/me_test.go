@@ -0,0 +1,229 @@
+package v1
+
+import (
+	"bytes"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestMeHandler_getProfile(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAuthorization, userId int)
+
+	tt := []struct {
+		name                string
+		userId              int
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:   "Ok",
+			userId: 1,
+			mockBehavior: func(s *mock_service.MockAuthorization, userId int) {
+				s.EXPECT().GetProfile(userId).Return(entity.Profile{
+					Id:            1,
+					Name:          "Test",
+					Username:      "test",
+					Email:         "test@example.com",
+					EmailVerified: true,
+					TimeZone:      "Europe/Moscow",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":1,"name":"Test","username":"test","email":"test@example.com","email_verified":true,"time_zone":"Europe/Moscow"}`,
+		},
+		{
+			name:   "Service failure",
+			userId: 1,
+			mockBehavior: func(s *mock_service.MockAuthorization, userId int) {
+				s.EXPECT().GetProfile(userId).Return(entity.Profile{}, errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			tc.mockBehavior(auth, tc.userId)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/v1/me", func(c *gin.Context) {
+				c.Set(userCtx, tc.userId)
+			}, handler.getProfile)
+
+			req := httptest.NewRequest("GET", "/api/v1/me", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestMeHandler_updateProfile(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAuthorization, userId int, input entity.UpdateUserInput)
+
+	name := "New name"
+	username := "taken"
+
+	tt := []struct {
+		name                string
+		userId              int
+		inputBody           string
+		input               entity.UpdateUserInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			userId:    1,
+			inputBody: `{"name":"New name"}`,
+			input:     entity.UpdateUserInput{Name: &name},
+			mockBehavior: func(s *mock_service.MockAuthorization, userId int, input entity.UpdateUserInput) {
+				s.EXPECT().UpdateProfile(userId, input).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:                "Empty update",
+			userId:              1,
+			inputBody:           `{}`,
+			mockBehavior:        func(s *mock_service.MockAuthorization, userId int, input entity.UpdateUserInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"update structure has no values"}`,
+		},
+		{
+			name:      "Username taken",
+			userId:    1,
+			inputBody: `{"username":"taken"}`,
+			input:     entity.UpdateUserInput{Username: &username},
+			mockBehavior: func(s *mock_service.MockAuthorization, userId int, input entity.UpdateUserInput) {
+				s.EXPECT().UpdateProfile(userId, input).Return(service.ErrUsernameTaken)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"username is already taken"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			tc.mockBehavior(auth, tc.userId, tc.input)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.PATCH("/api/v1/me", func(c *gin.Context) {
+				c.Set(userCtx, tc.userId)
+			}, handler.updateProfile)
+
+			req := httptest.NewRequest("PATCH", "/api/v1/me", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestMeHandler_changePassword(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockAuthorization, userId int, input entity.ChangePasswordInput)
+
+	tt := []struct {
+		name                string
+		userId              int
+		inputBody           string
+		input               entity.ChangePasswordInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			userId:    1,
+			inputBody: `{"old_password":"old","new_password":"new"}`,
+			input:     entity.ChangePasswordInput{OldPassword: "old", NewPassword: "new"},
+			mockBehavior: func(s *mock_service.MockAuthorization, userId int, input entity.ChangePasswordInput) {
+				s.EXPECT().ChangePassword(userId, input, gomock.Any()).Return(entity.Tokens{
+					AccessToken:  "token",
+					RefreshToken: "refresh",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"token":"token","refresh_token":"refresh"}`,
+		},
+		{
+			name:                "BindJSON",
+			userId:              1,
+			inputBody:           `{"new_password":"new"}`,
+			mockBehavior:        func(s *mock_service.MockAuthorization, userId int, input entity.ChangePasswordInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Wrong old password",
+			userId:    1,
+			inputBody: `{"old_password":"wrong","new_password":"new"}`,
+			input:     entity.ChangePasswordInput{OldPassword: "wrong", NewPassword: "new"},
+			mockBehavior: func(s *mock_service.MockAuthorization, userId int, input entity.ChangePasswordInput) {
+				s.EXPECT().ChangePassword(userId, input, gomock.Any()).Return(entity.Tokens{}, service.ErrInvalidCredentials)
+			},
+			expectedStatusCode:  403,
+			expectedRequestBody: `{"message":"invalid username or password"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			auth := mock_service.NewMockAuthorization(c)
+			tc.mockBehavior(auth, tc.userId, tc.input)
+
+			services := &service.Service{Authorization: auth}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/me/password", func(c *gin.Context) {
+				c.Set(userCtx, tc.userId)
+			}, handler.changePassword)
+
+			req := httptest.NewRequest("POST", "/api/v1/me/password", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -2,6 +2,7 @@ package v1
 
 import (
 	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/gin-gonic/gin"
 	"net/http"
 	"strings"
@@ -10,12 +11,15 @@ import (
 const (
 	AuthorizationHeader  = "Authorization"
 	userCtx              = "userId"
+	sessionCtx           = "sessionId"
+	identityCtx          = "identity"
 	ErrEmptyAuthHeader   = "auth header is empty"
 	ErrEmptyToken        = "token is empty"
 	ErrInvalidAuthHeader = "invalid auth header"
 	ErrFailedParseToken  = "failed to parse token"
 	ErrUserNotFound      = "user id not found"
 	ErrUserInvalidType   = "user id is of invalid type"
+	ErrInsufficientScope = "insufficient token scope"
 )
 
 func (h *Handler) userIdentity(c *gin.Context) {
@@ -37,13 +41,32 @@ func (h *Handler) userIdentity(c *gin.Context) {
 		return
 	}
 
-	userId, err := h.services.Authorization.ParseToken(headerParts[1])
+	identity, err := h.services.Authorization.ParseToken(headerParts[1])
 	if err != nil {
 		newErrorResponse(c, http.StatusUnauthorized, ErrFailedParseToken)
 		return
 	}
 
-	c.Set(userCtx, userId)
+	c.Set(userCtx, identity.UserId)
+	c.Set(sessionCtx, identity.SessionId)
+	c.Set(identityCtx, identity)
+}
+
+// requireScope ограничивает группу маршрутов для персональных токенов:
+// чтение (GET, HEAD) требует readScope, остальные методы - writeScope.
+func (h *Handler) requireScope(readScope, writeScope string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		scope := writeScope
+		if c.Request.Method == http.MethodGet || c.Request.Method == http.MethodHead {
+			scope = readScope
+		}
+
+		identity, ok := c.Value(identityCtx).(entity.Identity)
+		if !ok || !identity.HasScope(scope) {
+			newErrorResponse(c, http.StatusForbidden, ErrInsufficientScope)
+			return
+		}
+	}
 }
 
 func getUserId(c *gin.Context) (int, error) {
@@ -60,3 +83,7 @@ func getUserId(c *gin.Context) (int, error) {
 	}
 	return idInt, nil
 }
+
+func getClient(c *gin.Context) entity.Client {
+	return entity.NewClient(c.Request.UserAgent(), c.ClientIP())
+}
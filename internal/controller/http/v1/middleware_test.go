@@ -3,6 +3,7 @@ package v1
 import (
 	"errors"
 	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/IncubusX/go-todo-app/internal/service"
 	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
 	"github.com/gin-gonic/gin"
@@ -30,7 +31,7 @@ func TestHandler_userIdentity(t *testing.T) {
 			headerValue: "Bearer token",
 			token:       "token",
 			mockBehavior: func(s *mock_service.MockAuthorization, token string) {
-				s.EXPECT().ParseToken(token).Return(1, nil)
+				s.EXPECT().ParseToken(token).Return(entity.Identity{UserId: 1, SessionId: 2}, nil)
 			},
 			expectedStatusCode:   200,
 			expectedResponseBody: "1",
@@ -65,7 +66,7 @@ func TestHandler_userIdentity(t *testing.T) {
 			headerValue: "Bearer token",
 			token:       "token",
 			mockBehavior: func(s *mock_service.MockAuthorization, token string) {
-				s.EXPECT().ParseToken(token).Return(0, errors.New(ErrFailedParseToken))
+				s.EXPECT().ParseToken(token).Return(entity.Identity{}, errors.New(ErrFailedParseToken))
 			},
 			expectedStatusCode:   401,
 			expectedResponseBody: `{"message":"failed to parse token"}`,
@@ -165,3 +166,72 @@ func TestHandler_getUserId(t *testing.T) {
 	}
 
 }
+
+func TestHandler_requireScope(t *testing.T) {
+	tt := []struct {
+		name                 string
+		method               string
+		identity             interface{}
+		expectedStatusCode   int
+		expectedResponseBody string
+	}{
+		{
+			name:                 "Session has full access",
+			method:               "POST",
+			identity:             entity.Identity{UserId: 1, SessionId: 1, Scopes: []string{entity.ScopeAdmin}},
+			expectedStatusCode:   200,
+			expectedResponseBody: "ok",
+		},
+		{
+			name:                 "Read scope on GET",
+			method:               "GET",
+			identity:             entity.Identity{UserId: 1, Scopes: []string{entity.ScopeListsRead}},
+			expectedStatusCode:   200,
+			expectedResponseBody: "ok",
+		},
+		{
+			name:                 "Read scope on POST",
+			method:               "POST",
+			identity:             entity.Identity{UserId: 1, Scopes: []string{entity.ScopeListsRead}},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"message":"insufficient token scope"}`,
+		},
+		{
+			name:                 "Foreign scope",
+			method:               "GET",
+			identity:             entity.Identity{UserId: 1, Scopes: []string{entity.ScopeItemsRead}},
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"message":"insufficient token scope"}`,
+		},
+		{
+			name:                 "No identity",
+			method:               "GET",
+			expectedStatusCode:   403,
+			expectedResponseBody: `{"message":"insufficient token scope"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			handler := NewHandler(&service.Service{})
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.Handle(tc.method, "/test", func(c *gin.Context) {
+				if tc.identity != nil {
+					c.Set(identityCtx, tc.identity)
+				}
+			}, handler.requireScope(entity.ScopeListsRead, entity.ScopeListsWrite), func(c *gin.Context) {
+				c.String(200, "ok")
+			})
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(tc.method, "/test", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedResponseBody, w.Body.String())
+		})
+	}
+}
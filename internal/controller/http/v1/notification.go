@@ -0,0 +1,209 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type getAllNotificationsResponse struct {
+	Data         []entity.Notification `json:"data"`
+	Unread       int                   `json:"unread"`
+	UnreadByType map[string]int        `json:"unread_by_type"`
+}
+
+// @Summary		Get notifications
+// @Security		ApiKeyAuth
+// @Tags			notifications
+// @Description	Входящие уведомления, от новых к старым, и число непрочитанных всего и по типам.
+// @Description	Следующая страница запрашивается с before - id последнего полученного уведомления
+// @ID				get-all-notifications
+// @Accept			json
+// @Produce		json
+// @Param			unread	query		bool	false	"Только непрочитанные"
+// @Param			before	query		int		false	"Уведомления старше указанного id"
+// @Param			limit	query		int		false	"Размер страницы, от 1 до 100"	default(50)
+// @Success		200		{object}	getAllNotificationsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/notifications [get]
+func (h *Handler) getAllNotifications(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var filter entity.NotificationFilter
+	filter.Unread, _ = strconv.ParseBool(c.Query("unread"))
+	filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || filter.Limit < 1 || filter.Limit > 100 {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidLimit)
+		return
+	}
+	if before := c.Query("before"); before != "" {
+		if filter.BeforeId, err = strconv.Atoi(before); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+			return
+		}
+	}
+
+	notifications, err := h.services.Notification.GetAll(userId, filter)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+	unreadByType, err := h.services.Notification.CountUnread(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	unread := 0
+	for _, count := range unreadByType {
+		unread += count
+	}
+
+	c.JSON(http.StatusOK, getAllNotificationsResponse{
+		Data:         notifications,
+		Unread:       unread,
+		UnreadByType: unreadByType,
+	})
+}
+
+// @Summary		Mark notification read
+// @Security		ApiKeyAuth
+// @Tags			notifications
+// @Description	Отметка уведомления прочитанным
+// @ID				mark-notification-read
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Notification ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/notifications/{id}/read [post]
+func (h *Handler) markNotificationRead(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	notificationId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Notification.MarkRead(userId, notificationId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Mark all notifications read
+// @Security		ApiKeyAuth
+// @Tags			notifications
+// @Description	Отметка всех уведомлений прочитанными
+// @ID				mark-all-notifications-read
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/notifications/read-all [post]
+func (h *Handler) markAllNotificationsRead(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	if err = h.services.Notification.MarkAllRead(userId); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+type getNotificationPreferencesResponse struct {
+	Data []entity.NotificationPreference `json:"data"`
+}
+
+// @Summary		Get notification preferences
+// @Security		ApiKeyAuth
+// @Tags			notifications
+// @Description	Типы уведомлений и отметка, отключён ли тип
+// @ID				get-notification-preferences
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getNotificationPreferencesResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/notifications/preferences [get]
+func (h *Handler) getNotificationPreferences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	preferences, err := h.services.Notification.GetPreferences(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getNotificationPreferencesResponse{
+		Data: preferences,
+	})
+}
+
+// @Summary		Update notification preferences
+// @Security		ApiKeyAuth
+// @Tags			notifications
+// @Description	Замена списка отключённых типов уведомлений. Уведомления отключённых типов не попадают во входящие
+// @ID				update-notification-preferences
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.UpdateNotificationPreferencesInput	true	"muted types"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/notifications/preferences [put]
+func (h *Handler) updateNotificationPreferences(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.UpdateNotificationPreferencesInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if err = h.services.Notification.UpdatePreferences(userId, input); err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotificationHandler_getAllNotifications(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockNotification)
+
+	createdAt := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	listId := 2
+
+	tt := []struct {
+		name                string
+		query               string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:  "Ok",
+			query: "?unread=true&before=10&limit=20",
+			mockBehavior: func(s *mock_service.MockNotification) {
+				s.EXPECT().GetAll(1, entity.NotificationFilter{Unread: true, BeforeId: 10, Limit: 20}).Return([]entity.Notification{
+					{Id: 9, Type: entity.NotificationListInvitation, Title: "Trip", ListId: &listId, Actor: "Alice", CreatedAt: createdAt},
+				}, nil)
+				s.EXPECT().CountUnread(1).Return(map[string]int{entity.NotificationListInvitation: 1, entity.NotificationReminder: 2}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"data":[{"id":9,"type":"list_invitation","title":"Trip","body":"","list_id":2,"actor":"Alice","created_at":"2024-05-03T09:00:00Z"}],` +
+				`"unread":3,"unread_by_type":{"list_invitation":1,"reminder":2}}`,
+		},
+		{
+			name:  "Default limit",
+			query: "",
+			mockBehavior: func(s *mock_service.MockNotification) {
+				s.EXPECT().GetAll(1, entity.NotificationFilter{Limit: 50}).Return([]entity.Notification{}, nil)
+				s.EXPECT().CountUnread(1).Return(map[string]int{}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"data":[],"unread":0,"unread_by_type":{}}`,
+		},
+		{
+			name:                "Invalid limit",
+			query:               "?limit=500",
+			mockBehavior:        func(s *mock_service.MockNotification) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"limit must be between 1 and 100"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			notifications := mock_service.NewMockNotification(c)
+			tc.mockBehavior(notifications)
+
+			handler := NewHandler(&service.Service{Notification: notifications})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/v1/me/notifications", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.getAllNotifications)
+
+			req := httptest.NewRequest("GET", "/api/v1/me/notifications"+tc.query, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestNotificationHandler_updateNotificationPreferences(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	notifications := mock_service.NewMockNotification(c)
+	notifications.EXPECT().UpdatePreferences(1, entity.UpdateNotificationPreferencesInput{Muted: []string{"reminder"}}).Return(nil)
+
+	handler := NewHandler(&service.Service{Notification: notifications})
+
+	gin.SetMode(gin.ReleaseMode)
+	r := gin.New()
+	r.PUT("/api/v1/me/notifications/preferences", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.updateNotificationPreferences)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PUT", "/api/v1/me/notifications/preferences", bytes.NewBufferString(`{"muted":["reminder"]}`)))
+	assert.Equal(t, 200, w.Code)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest("PUT", "/api/v1/me/notifications/preferences", bytes.NewBufferString(`{"muted":["spam"]}`)))
+	assert.Equal(t, 400, w.Code)
+	assert.Equal(t, `{"message":"unknown notification type \"spam\""}`, w.Body.String())
+}
@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+const (
+	oidcStateCookie     = "oidc_state"
+	oidcStateCookiePath = "/auth/oidc/"
+	oidcStateMaxAge     = 600
+)
+
+// @Summary			OIDC Login
+// @Tags			auth
+// @Description		Переход на страницу входа внешнего провайдера (OpenID Connect, authorization code + PKCE)
+// @ID				oidc-login
+// @Param			provider	path	string	true	"provider name"
+// @Success			302
+// @Failure			404		{object}	errorResponse
+// @Failure			500		{object}	errorResponse
+// @Failure			default	{object}	errorResponse
+// @Router			/auth/oidc/{provider}/login [get]
+func (h *Handler) oidcLogin(c *gin.Context) {
+	login, err := h.services.OIDC.LoginURL(c.Param("provider"))
+	if err != nil {
+		if errors.Is(err, service.ErrUnknownProvider) {
+			newErrorResponse(c, http.StatusNotFound, ErrUnknownProvider)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	// Cookie должна прийти на callback после перехода с сайта провайдера, поэтому SameSite=Lax
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookie, login.StateToken, oidcStateMaxAge, oidcStateCookiePath, "", c.Request.TLS != nil, true)
+	c.Redirect(http.StatusFound, login.URL)
+}
+
+// @Summary			OIDC Callback
+// @Tags			auth
+// @Description		Возврат от внешнего провайдера: вход или автоматическая регистрация пользователя
+// @ID				oidc-callback
+// @Produce			json
+// @Param			provider	path		string	true	"provider name"
+// @Param			code		query		string	true	"authorization code"
+// @Param			state		query		string	true	"state"
+// @Success			200			{object}	signInResponse
+// @Success			202			{object}	twoFactorChallengeResponse
+// @Failure			400,401,404	{object}	errorResponse
+// @Failure			500,502		{object}	errorResponse
+// @Failure			default		{object}	errorResponse
+// @Router			/auth/oidc/{provider}/callback [get]
+func (h *Handler) oidcCallback(c *gin.Context) {
+	if c.Query("error") != "" {
+		newErrorResponse(c, http.StatusUnauthorized, ErrOIDCDenied)
+		return
+	}
+
+	stateToken, err := c.Cookie(oidcStateCookie)
+	if err != nil || c.Query("code") == "" {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidOIDCState)
+		return
+	}
+	c.SetCookie(oidcStateCookie, "", -1, oidcStateCookiePath, "", c.Request.TLS != nil, true)
+
+	tokens, err := h.services.OIDC.Callback(c.Param("provider"), entity.OIDCCallbackInput{
+		Code:       c.Query("code"),
+		State:      c.Query("state"),
+		StateToken: stateToken,
+	}, getClient(c))
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrUnknownProvider):
+			newErrorResponse(c, http.StatusNotFound, ErrUnknownProvider)
+		case errors.Is(err, service.ErrInvalidOIDCState):
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidOIDCState)
+		case errors.Is(err, oidc.ErrInvalidIDToken):
+			newErrorResponse(c, http.StatusUnauthorized, ErrOIDCDenied)
+		case errors.Is(err, oidc.ErrExchangeFailed):
+			newErrorResponse(c, http.StatusBadGateway, ErrOIDCFailure)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
+		return
+	}
+
+	newSignInResponse(c, tokens)
+}
@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestHandler_oidcLogin(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockOIDC, provider string)
+
+	tt := []struct {
+		name               string
+		provider           string
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedLocation   string
+		expectedCookie     string
+	}{
+		{
+			name:     "Ok",
+			provider: "corp",
+			mockBehavior: func(s *mock_service.MockOIDC, provider string) {
+				s.EXPECT().LoginURL(provider).Return(entity.OIDCLogin{
+					URL:        "https://sso.example.com/authorize?state=s",
+					StateToken: "state-token",
+				}, nil)
+			},
+			expectedStatusCode: 302,
+			expectedLocation:   "https://sso.example.com/authorize?state=s",
+			expectedCookie:     "oidc_state=state-token; Path=/auth/oidc/; Max-Age=600; HttpOnly; SameSite=Lax",
+		},
+		{
+			name:     "Unknown provider",
+			provider: "other",
+			mockBehavior: func(s *mock_service.MockOIDC, provider string) {
+				s.EXPECT().LoginURL(provider).Return(entity.OIDCLogin{}, service.ErrUnknownProvider)
+			},
+			expectedStatusCode: 404,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			oidcService := mock_service.NewMockOIDC(c)
+			tc.mockBehavior(oidcService, tc.provider)
+
+			services := &service.Service{OIDC: oidcService}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/auth/oidc/:provider/login", handler.oidcLogin)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/auth/oidc/"+tc.provider+"/login", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedLocation, w.Header().Get("Location"))
+			assert.Equal(t, tc.expectedCookie, w.Header().Get("Set-Cookie"))
+		})
+	}
+}
+
+func TestHandler_oidcCallback(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockOIDC, input entity.OIDCCallbackInput)
+
+	tt := []struct {
+		name                string
+		query               string
+		cookie              *http.Cookie
+		input               entity.OIDCCallbackInput
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:   "Ok",
+			query:  "?code=code&state=state",
+			cookie: &http.Cookie{Name: "oidc_state", Value: "state-token"},
+			input:  entity.OIDCCallbackInput{Code: "code", State: "state", StateToken: "state-token"},
+			mockBehavior: func(s *mock_service.MockOIDC, input entity.OIDCCallbackInput) {
+				s.EXPECT().Callback("corp", input, gomock.Any()).Return(entity.Tokens{
+					AccessToken:  "token",
+					RefreshToken: "refresh",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"token":"token","refresh_token":"refresh"}`,
+		},
+		{
+			name:                "No state cookie",
+			query:               "?code=code&state=state",
+			mockBehavior:        func(s *mock_service.MockOIDC, input entity.OIDCCallbackInput) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid or expired login state"}`,
+		},
+		{
+			name:                "Denied by provider",
+			query:               "?error=access_denied&state=state",
+			cookie:              &http.Cookie{Name: "oidc_state", Value: "state-token"},
+			mockBehavior:        func(s *mock_service.MockOIDC, input entity.OIDCCallbackInput) {},
+			expectedStatusCode:  401,
+			expectedRequestBody: `{"message":"sign-in was denied by identity provider"}`,
+		},
+		{
+			name:   "Invalid state",
+			query:  "?code=code&state=forged",
+			cookie: &http.Cookie{Name: "oidc_state", Value: "state-token"},
+			input:  entity.OIDCCallbackInput{Code: "code", State: "forged", StateToken: "state-token"},
+			mockBehavior: func(s *mock_service.MockOIDC, input entity.OIDCCallbackInput) {
+				s.EXPECT().Callback("corp", input, gomock.Any()).Return(entity.Tokens{}, service.ErrInvalidOIDCState)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid or expired login state"}`,
+		},
+		{
+			name:   "Exchange failed",
+			query:  "?code=code&state=state",
+			cookie: &http.Cookie{Name: "oidc_state", Value: "state-token"},
+			input:  entity.OIDCCallbackInput{Code: "code", State: "state", StateToken: "state-token"},
+			mockBehavior: func(s *mock_service.MockOIDC, input entity.OIDCCallbackInput) {
+				s.EXPECT().Callback("corp", input, gomock.Any()).Return(entity.Tokens{}, oidc.ErrExchangeFailed)
+			},
+			expectedStatusCode:  502,
+			expectedRequestBody: `{"message":"identity provider failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			oidcService := mock_service.NewMockOIDC(c)
+			tc.mockBehavior(oidcService, tc.input)
+
+			services := &service.Service{OIDC: oidcService}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			r := gin.New()
+			r.GET("/auth/oidc/:provider/callback", handler.oidcCallback)
+
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/auth/oidc/corp/callback"+tc.query, nil)
+			if tc.cookie != nil {
+				req.AddCookie(tc.cookie)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,232 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/render"
+	"html/template"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// SharePasswordHeader передаёт пароль публичной ссылки в JSON-запросах, HTML-страница отправляет его формой.
+const SharePasswordHeader = "X-Share-Password"
+
+var publicListTemplate = template.Must(template.New("public-list").Parse(`<!DOCTYPE html>
+<html lang="ru">
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<meta name="robots" content="noindex">
+<title>{{if .List}}{{.List.Title}}{{else}}Список задач{{end}}</title>
+<style>
+body { font-family: sans-serif; max-width: 40rem; margin: 2rem auto; padding: 0 1rem; color: #222; }
+ul { list-style: none; padding: 0; }
+li { padding: .5rem 0; border-bottom: 1px solid #eee; }
+.done .title { text-decoration: line-through; color: #888; }
+.description, .due { color: #666; font-size: .9rem; }
+li ul { padding-left: 1.5rem; margin-top: .5rem; }
+li li:last-child { border-bottom: none; }
+</style>
+</head>
+<body>
+{{- if .List}}
+<h1>{{.List.Title}}</h1>
+{{- if .List.Description}}
+<p>{{.List.Description}}</p>
+{{- end}}
+{{- template "items" .List.Items}}
+{{- else if .PasswordRequired}}
+<h1>Список защищён паролем</h1>
+{{- if .Message}}
+<p>{{.Message}}</p>
+{{- end}}
+<form method="post">
+<input type="password" name="password" autofocus required>
+<button type="submit">Открыть</button>
+</form>
+{{- else}}
+<h1>{{.Message}}</h1>
+{{- end}}
+</body>
+</html>
+{{- define "items"}}
+<ul>
+{{- range .}}
+<li{{if .Done}} class="done"{{end}}><span class="title">{{if .Done}}&#9745;{{else}}&#9744;{{end}} {{.Title}}</span>
+{{- if .DueAt}} <time class="due" datetime="{{.DueAt.Format "2006-01-02T15:04:05Z07:00"}}">{{.DueAt.Format "02.01.2006"}}</time>{{end}}
+{{- if .Description}}<div class="description">{{.Description}}</div>{{end}}
+{{- if .Children}}{{template "items" .Children}}{{end}}</li>
+{{- end}}
+</ul>
+{{- end}}
+`))
+
+type publicListPage struct {
+	List             *entity.PublicList
+	PasswordRequired bool
+	Message          string
+}
+
+// @Summary		Publish list
+// @Security		ApiKeyAuth
+// @Tags			lists
+// @Description	Публикация списка по ссылке для чтения без авторизации. Повторная публикация заменяет прежнюю ссылку. Доступно только владельцу
+// @ID				publish-list
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int						true	"List ID"
+// @Param			input	body		entity.PublishListInput	true	"link options"
+// @Success		200		{object}	entity.PublicLink
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/public-link [put]
+func (h *Handler) publishList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.PublishListInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	link, err := h.services.PublicLink.Publish(userId, listId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, link)
+}
+
+// @Summary		Unpublish list
+// @Security		ApiKeyAuth
+// @Tags			lists
+// @Description	Отзыв публичной ссылки на список. Доступно только владельцу
+// @ID				unpublish-list
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"List ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		403,404	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/public-link [delete]
+func (h *Handler) unpublishList(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.PublicLink.Unpublish(userId, listId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Get public list
+// @Tags			public
+// @Description	Просмотр опубликованного списка без авторизации. Отвечает HTML-страницей, если клиент её предпочитает, иначе JSON.
+// @Description	Пароль передаётся заголовком X-Share-Password или полем password формы (POST).
+// @Description	После нескольких неверных паролей подряд проверка блокируется на время, указанное в Retry-After
+// @ID				get-public-list
+// @Produce		json,html
+// @Param			token				path		string	true	"Share token"
+// @Param			X-Share-Password	header		string	false	"Link password"
+// @Success		200					{object}	entity.PublicList
+// @Failure		401,404				{object}	errorResponse
+// @Failure		429					{object}	errorResponse
+// @Failure		500					{object}	errorResponse
+// @Failure		default				{object}	errorResponse
+// @Router			/public/lists/{token} [get]
+func (h *Handler) getPublicList(c *gin.Context) {
+	// Токен в адресе не должен утекать через Referer, кэши и поисковики
+	c.Header("Cache-Control", "no-store")
+	c.Header("Referrer-Policy", "no-referrer")
+	c.Header("X-Robots-Tag", "noindex")
+
+	password := c.GetHeader(SharePasswordHeader)
+	if c.Request.Method == http.MethodPost {
+		password = c.PostForm("password")
+	}
+
+	list, err := h.services.PublicLink.Get(c.Param("token"), password, c.ClientIP())
+
+	var lockout *service.LockoutError
+	if errors.As(err, &lockout) {
+		c.Header("Retry-After", strconv.Itoa(int(math.Ceil(time.Until(lockout.Until).Seconds()))))
+	}
+
+	if c.NegotiateFormat(gin.MIMEJSON, gin.MIMEHTML) == gin.MIMEHTML {
+		renderPublicListPage(c, list, err)
+		return
+	}
+
+	if err != nil {
+		switch {
+		case lockout != nil:
+			newErrorResponse(c, http.StatusTooManyRequests, ErrTooManyAttempts)
+		case errors.Is(err, service.ErrPublicLinkNotFound):
+			newErrorResponse(c, http.StatusNotFound, ErrPublicLinkNotFound)
+		case errors.Is(err, service.ErrPasswordRequired):
+			newErrorResponse(c, http.StatusUnauthorized, ErrPasswordRequired)
+		case errors.Is(err, service.ErrInvalidPassword):
+			newErrorResponse(c, http.StatusUnauthorized, ErrInvalidSharePassword)
+		default:
+			newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, list)
+}
+
+func renderPublicListPage(c *gin.Context, list entity.PublicList, err error) {
+	status, page := http.StatusOK, publicListPage{List: &list}
+	switch {
+	case err == nil:
+	case errors.Is(err, service.ErrPublicLinkNotFound):
+		status, page = http.StatusNotFound, publicListPage{Message: "Ссылка не найдена или больше не действует"}
+	case errors.Is(err, service.ErrPasswordRequired):
+		status, page = http.StatusUnauthorized, publicListPage{PasswordRequired: true}
+	case errors.Is(err, service.ErrInvalidPassword):
+		status, page = http.StatusUnauthorized, publicListPage{PasswordRequired: true, Message: "Неверный пароль"}
+	case errors.Is(err, service.ErrTooManyAttempts):
+		status, page = http.StatusTooManyRequests, publicListPage{PasswordRequired: true, Message: "Слишком много неверных попыток, попробуйте позже"}
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.Render(status, render.HTML{Template: publicListTemplate, Data: page})
+}
@@ -0,0 +1,158 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPublicLinkHandler_getPublicList(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockPublicLink)
+
+	list := entity.PublicList{
+		Title: "Shopping <list>",
+		Items: []entity.PublicItem{{Title: "Milk", Description: "2l", Done: true,
+			Children: []entity.PublicItem{{Title: "Oat <milk>"}}}},
+	}
+
+	tt := []struct {
+		name               string
+		method             string
+		accept             string
+		password           string
+		form               url.Values
+		mockBehavior       mockBehavior
+		expectedStatusCode int
+		expectedBody       string
+		expectedContains   []string
+	}{
+		{
+			name:   "JSON",
+			method: "GET",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "", "192.0.2.1").Return(list, nil)
+			},
+			expectedStatusCode: 200,
+			expectedBody:       `{"title":"Shopping \u003clist\u003e","description":"","items":[{"title":"Milk","description":"2l","done":true,"children":[{"title":"Oat \u003cmilk\u003e","description":"","done":false}]}]}`,
+		},
+		{
+			name:     "JSON with password",
+			method:   "GET",
+			password: "pass",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "pass", "192.0.2.1").Return(list, nil)
+			},
+			expectedStatusCode: 200,
+			expectedContains:   []string{`"title":"Milk"`},
+		},
+		{
+			name:   "Not found",
+			method: "GET",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "", "192.0.2.1").Return(entity.PublicList{}, service.ErrPublicLinkNotFound)
+			},
+			expectedStatusCode: 404,
+			expectedBody:       `{"message":"public link not found"}`,
+		},
+		{
+			name:   "Password required",
+			method: "GET",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "", "192.0.2.1").Return(entity.PublicList{}, service.ErrPasswordRequired)
+			},
+			expectedStatusCode: 401,
+			expectedBody:       `{"message":"password required"}`,
+		},
+		{
+			name:   "HTML",
+			method: "GET",
+			accept: "text/html,application/xhtml+xml,*/*;q=0.8",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "", "192.0.2.1").Return(list, nil)
+			},
+			expectedStatusCode: 200,
+			expectedContains: []string{"<h1>Shopping &lt;list&gt;</h1>", `<li class="done">`, "Milk",
+				"<h1>Shopping &lt;list&gt;</h1>\n<ul>", "<ul>\n<li><span class=\"title\">&#9744; Oat &lt;milk&gt;</span></li>\n</ul></li>"},
+		},
+		{
+			name:   "HTML password form",
+			method: "GET",
+			accept: "text/html",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "", "192.0.2.1").Return(entity.PublicList{}, service.ErrPasswordRequired)
+			},
+			expectedStatusCode: 401,
+			expectedContains:   []string{`<form method="post">`},
+		},
+		{
+			name:   "HTML wrong password",
+			method: "POST",
+			accept: "text/html",
+			form:   url.Values{"password": {"wrong"}},
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "wrong", "192.0.2.1").Return(entity.PublicList{}, service.ErrInvalidPassword)
+			},
+			expectedStatusCode: 401,
+			expectedContains:   []string{"Неверный пароль", `<form method="post">`},
+		},
+		{
+			name:     "Too many attempts",
+			method:   "GET",
+			password: "guess",
+			mockBehavior: func(s *mock_service.MockPublicLink) {
+				s.EXPECT().Get("secret", "guess", "192.0.2.1").
+					Return(entity.PublicList{}, &service.LockoutError{Until: time.Now().Add(time.Minute)})
+			},
+			expectedStatusCode: 429,
+			expectedBody:       `{"message":"too many failed sign-in attempts, try again later"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			publicLink := mock_service.NewMockPublicLink(c)
+			tc.mockBehavior(publicLink)
+
+			handler := NewHandler(&service.Service{PublicLink: publicLink})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/public/lists/:token", handler.getPublicList)
+			r.POST("/public/lists/:token", handler.getPublicList)
+
+			req := httptest.NewRequest(tc.method, "/public/lists/secret", strings.NewReader(tc.form.Encode()))
+			if tc.form != nil {
+				req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			}
+			if tc.accept != "" {
+				req.Header.Set("Accept", tc.accept)
+			}
+			if tc.password != "" {
+				req.Header.Set(SharePasswordHeader, tc.password)
+			}
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+			if tc.expectedBody != "" {
+				assert.Equal(t, tc.expectedBody, w.Body.String())
+			}
+			for _, s := range tc.expectedContains {
+				assert.Contains(t, w.Body.String(), s)
+			}
+		})
+	}
+}
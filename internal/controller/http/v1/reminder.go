@@ -0,0 +1,140 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// @Summary		Create reminder
+// @Security		ApiKeyAuth
+// @Tags			reminders
+// @Description	Напоминание о задаче за offset_minutes до срока или в момент remind_at. Приходит только создавшему его
+// @Description	пользователю по каналу inbox (по умолчанию), email (на подтверждённый адрес) или webhook (POST на webhook_url)
+// @ID				create-reminder
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int				true	"Item ID"
+// @Param			input	body		entity.Reminder	true	"reminder info"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/reminders [post]
+func (h *Handler) createReminder(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.Reminder
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	if err := input.Validate(); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	id, err := h.services.Reminder.Create(userId, itemId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+type getAllRemindersResponse struct {
+	Data []entity.Reminder `json:"data"`
+}
+
+// @Summary		Get item reminders
+// @Security		ApiKeyAuth
+// @Tags			reminders
+// @Description	Напоминания пользователя о задаче. fire_at - ближайший момент срабатывания, last_error - ошибка последней доставки
+// @ID				get-all-reminders
+// @Accept			json
+// @Produce		json
+// @Param			item_id	path		int	true	"Item ID"
+// @Success		200		{object}	getAllRemindersResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/reminders [get]
+func (h *Handler) getAllReminders(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	reminders, err := h.services.Reminder.GetAll(userId, itemId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllRemindersResponse{
+		Data: reminders,
+	})
+}
+
+// @Summary		Delete reminder
+// @Security		ApiKeyAuth
+// @Tags			reminders
+// @Description	Удаление напоминания
+// @ID				delete-reminder
+// @Accept			json
+// @Produce		json
+// @Param			item_id		path		int	true	"Item ID"
+// @Param			reminder_id	path		int	true	"Reminder ID"
+// @Success		200			{object}	statusResponse
+// @Failure		400,401		{object}	errorResponse
+// @Failure		404			{object}	errorResponse
+// @Failure		500			{object}	errorResponse
+// @Failure		default		{object}	errorResponse
+// @Router			/api/v1/items/{item_id}/reminders/{reminder_id} [delete]
+func (h *Handler) deleteReminder(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	itemId, err := strconv.Atoi(c.Param("item_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+	reminderId, err := strconv.Atoi(c.Param("reminder_id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Reminder.Delete(userId, itemId, reminderId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
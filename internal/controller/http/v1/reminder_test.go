@@ -0,0 +1,120 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestReminderHandler_createReminder(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockReminder)
+
+	offset := 60
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"offset_minutes":60}`,
+			mockBehavior: func(s *mock_service.MockReminder) {
+				s.EXPECT().Create(1, 3, entity.Reminder{OffsetMinutes: &offset, Channel: entity.ReminderChannelInbox}).Return(5, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":5}`,
+		},
+		{
+			name:                "Both offset and time",
+			inputBody:           `{"offset_minutes":60,"remind_at":"2024-05-03T09:00:00Z"}`,
+			mockBehavior:        func(s *mock_service.MockReminder) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"exactly one of offset_minutes and remind_at is required"}`,
+		},
+		{
+			name:                "Webhook without url",
+			inputBody:           `{"offset_minutes":60,"channel":"webhook","webhook_url":"ftp://example.com"}`,
+			mockBehavior:        func(s *mock_service.MockReminder) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"webhook_url must be an absolute http(s) URL"}`,
+		},
+		{
+			name:                "Unknown channel",
+			inputBody:           `{"offset_minutes":60,"channel":"sms"}`,
+			mockBehavior:        func(s *mock_service.MockReminder) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"channel must be one of inbox, email, webhook"}`,
+		},
+		{
+			name:      "No due date",
+			inputBody: `{"offset_minutes":60}`,
+			mockBehavior: func(s *mock_service.MockReminder) {
+				s.EXPECT().Create(1, 3, entity.Reminder{OffsetMinutes: &offset, Channel: entity.ReminderChannelInbox}).Return(0, service.ErrNoDueDate)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"reminder offset requires item due date"}`,
+		},
+		{
+			name:      "Item not found",
+			inputBody: `{"offset_minutes":60}`,
+			mockBehavior: func(s *mock_service.MockReminder) {
+				s.EXPECT().Create(1, 3, entity.Reminder{OffsetMinutes: &offset, Channel: entity.ReminderChannelInbox}).Return(0, service.ErrItemNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"item not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reminders := mock_service.NewMockReminder(c)
+			tc.mockBehavior(reminders)
+
+			handler := NewHandler(&service.Service{Reminder: reminders})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/items/:item_id/reminders", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.createReminder)
+
+			req := httptest.NewRequest("POST", "/api/v1/items/3/reminders", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestReminderHandler_deleteReminder(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	reminders := mock_service.NewMockReminder(c)
+	reminders.EXPECT().Delete(1, 3, 5).Return(service.ErrReminderNotFound)
+
+	handler := NewHandler(&service.Service{Reminder: reminders})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.DELETE("/api/v1/items/:item_id/reminders/:reminder_id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.deleteReminder)
+
+	r.ServeHTTP(w, httptest.NewRequest("DELETE", "/api/v1/items/3/reminders/5", nil))
+
+	assert.Equal(t, 404, w.Code)
+	assert.Equal(t, `{"message":"reminder not found"}`, w.Body.String())
+}
@@ -1,17 +1,77 @@
 package v1
 
 import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"net/http"
 )
 
 const (
-	ErrInvalidInputBody = "invalid input body"
-	ErrServiceFailure   = "service failure"
+	ErrInvalidInputBody     = "invalid input body"
+	ErrServiceFailure       = "service failure"
+	ErrInvalidRefreshToken  = "invalid refresh token"
+	ErrSessionNotFound      = "session not found"
+	ErrAccessTokenNotFound  = "personal access token not found"
+	ErrInvalidCredentials   = "invalid username or password"
+	ErrUsernameTaken        = "username is already taken"
+	ErrEmailTaken           = "email is already taken"
+	ErrInvalidUserToken     = "invalid or expired token"
+	ErrEmailVerified        = "email is already verified"
+	ErrNoEmail              = "account has no email"
+	ErrTooManyAttempts      = "too many failed sign-in attempts, try again later"
+	ErrInvalidChallenge     = "invalid or expired two-factor challenge"
+	ErrInvalidTwoFactor     = "invalid two-factor code"
+	ErrTwoFactorEnabled     = "two-factor authentication is already enabled"
+	ErrTwoFactorNotEnabled  = "two-factor authentication is not enrolled"
+	ErrUnknownProvider      = "unknown identity provider"
+	ErrInvalidOIDCState     = "invalid or expired login state"
+	ErrOIDCDenied           = "sign-in was denied by identity provider"
+	ErrOIDCFailure          = "identity provider failure"
+	ErrListNotFound         = "list not found"
+	ErrItemNotFound         = "item not found"
+	ErrInsufficientRole     = "insufficient role in list"
+	ErrUnknownUser          = "user not found"
+	ErrMemberNotFound       = "list member not found"
+	ErrAlreadyMember        = "user is already a member of the list"
+	ErrLastOwner            = "list must keep at least one owner"
+	ErrAlreadyInvited       = "user is already invited to the list"
+	ErrInvitationNotFound   = "invitation not found"
+	ErrInviteLinkNotFound   = "invite link not found"
+	ErrInvalidInviteLink    = "invalid or expired invite link"
+	ErrPublicLinkNotFound   = "public link not found"
+	ErrPasswordRequired     = "password required"
+	ErrInvalidSharePassword = "invalid password"
+	ErrLabelNotFound        = "label not found"
+	ErrLabelExists          = "label with this name already exists"
+	ErrInvalidParent        = "parent must be an item of the same list outside the item's subtasks"
+	ErrInvalidAnchor        = "invalid move anchor"
+	ErrInvalidAssignee      = "assignee must be a member of the item's list"
+	ErrTemplateNotFound     = "template not found"
+	ErrNotRecurring         = "item is not recurring"
+	ErrNoMoreOccurrences    = "recurring series has no more occurrences"
+	ErrInvalidCount         = "count must be between 1 and 100"
+	ErrReminderNotFound     = "reminder not found"
+	ErrNoDueDate            = "reminder offset requires item due date"
+	ErrReminderInPast       = "remind_at must be in the future"
+	ErrNotificationNotFound = "notification not found"
+	ErrInvalidLimit         = "limit must be between 1 and 100"
+	ErrCommentNotFound      = "comment not found"
+	ErrInvalidCommentParent = "parent must be a comment of the same item"
+	ErrNotCommentAuthor     = "only the author can change a comment"
+	ErrAttachmentNotFound   = "attachment not found"
+	ErrAttachmentTooLarge   = "attachment is too large"
+	ErrUnsupportedFileType  = "unsupported file type"
 )
 
 type signInResponse struct {
-	Token string `json:"token"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+type twoFactorChallengeResponse struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
 }
 
 type idResponse struct {
@@ -26,6 +86,22 @@ type statusResponse struct {
 	Status string `json:"status"`
 }
 
+// newSignInResponse отвечает парой токенов или, если нужен второй фактор, challenge-токеном со статусом 202.
+func newSignInResponse(c *gin.Context, tokens entity.Tokens) {
+	if tokens.ChallengeToken != "" {
+		c.JSON(http.StatusAccepted, twoFactorChallengeResponse{
+			TwoFactorRequired: true,
+			ChallengeToken:    tokens.ChallengeToken,
+		})
+		return
+	}
+
+	c.JSON(http.StatusOK, signInResponse{
+		Token:        tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+	})
+}
+
 func newErrorResponse(c *gin.Context, statusCode int, message string) {
 	logrus.Error(message)
 	c.AbortWithStatusJSON(statusCode, errorResponse{message})
@@ -0,0 +1,83 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+type getAllSessionsResponse struct {
+	Data []entity.Session `json:"data"`
+}
+
+// @Summary		Get all sessions
+// @Security		ApiKeyAuth
+// @Tags			sessions
+// @Description	Список активных сессий пользователя
+// @ID				get-all-sessions
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllSessionsResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/sessions [get]
+func (h *Handler) getAllSessions(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	sessions, err := h.services.Session.GetAll(userId, c.GetInt(sessionCtx))
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllSessionsResponse{
+		Data: sessions,
+	})
+}
+
+// @Summary		Delete session
+// @Security		ApiKeyAuth
+// @Tags			sessions
+// @Description	Завершение сессии на другом устройстве
+// @ID				delete-session
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Session ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/sessions/{id} [delete]
+func (h *Handler) deleteSession(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	sessionId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.Session.Revoke(userId, sessionId); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			newErrorResponse(c, http.StatusNotFound, ErrSessionNotFound)
+			return
+		}
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
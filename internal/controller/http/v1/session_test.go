@@ -0,0 +1,189 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSessionHandler_getAllSessions(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockSession, userId, sessionId int)
+
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name                string
+		setCtx              func(c *gin.Context)
+		userId              int
+		sessionId           int
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			userId:    1,
+			sessionId: 2,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+				c.Set(sessionCtx, 2)
+			},
+			mockBehavior: func(s *mock_service.MockSession, userId, sessionId int) {
+				s.EXPECT().GetAll(userId, sessionId).Return([]entity.Session{
+					{Id: 2, UserAgent: "curl/8.0", IP: "127.0.0.1", CreatedAt: ts, LastSeenAt: ts, ExpiresAt: ts, Current: true},
+				}, nil)
+			},
+			expectedStatusCode: 200,
+			expectedRequestBody: `{"data":[{"id":2,"user_agent":"curl/8.0","ip":"127.0.0.1","created_at":"2023-01-01T00:00:00Z",` +
+				`"last_seen_at":"2023-01-01T00:00:00Z","expires_at":"2023-01-01T00:00:00Z","current":true}]}`,
+		},
+		{
+			name:      "Service failure",
+			userId:    1,
+			sessionId: 2,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+				c.Set(sessionCtx, 2)
+			},
+			mockBehavior: func(s *mock_service.MockSession, userId, sessionId int) {
+				s.EXPECT().GetAll(userId, sessionId).Return(nil, errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+		{
+			name: "Bad Ctx",
+			setCtx: func(c *gin.Context) {
+			},
+			mockBehavior:        func(s *mock_service.MockSession, userId, sessionId int) {},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"user id not found"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			session := mock_service.NewMockSession(c)
+			tc.mockBehavior(session, tc.userId, tc.sessionId)
+
+			services := &service.Service{Session: session}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.GET("/api/v1/me/sessions", tc.setCtx, handler.getAllSessions)
+
+			req := httptest.NewRequest("GET", "/api/v1/me/sessions", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestSessionHandler_deleteSession(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockSession, userId, sessionId int)
+
+	tt := []struct {
+		name                string
+		setCtx              func(c *gin.Context)
+		userId              int
+		sessionId           int
+		url                 string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			userId:    1,
+			sessionId: 2,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/me/sessions/2",
+			mockBehavior: func(s *mock_service.MockSession, userId, sessionId int) {
+				s.EXPECT().Revoke(userId, sessionId).Return(nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"status":"ok"}`,
+		},
+		{
+			name:   "Bad Request",
+			userId: 1,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url:                 "/api/v1/me/sessions/WrongPath",
+			mockBehavior:        func(s *mock_service.MockSession, userId, sessionId int) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Not Found",
+			userId:    1,
+			sessionId: 3,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/me/sessions/3",
+			mockBehavior: func(s *mock_service.MockSession, userId, sessionId int) {
+				s.EXPECT().Revoke(userId, sessionId).Return(service.ErrSessionNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"session not found"}`,
+		},
+		{
+			name:      "Service failure",
+			userId:    1,
+			sessionId: 2,
+			setCtx: func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			},
+			url: "/api/v1/me/sessions/2",
+			mockBehavior: func(s *mock_service.MockSession, userId, sessionId int) {
+				s.EXPECT().Revoke(userId, sessionId).Return(errors.New(ErrServiceFailure))
+			},
+			expectedStatusCode:  500,
+			expectedRequestBody: `{"message":"service failure"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			session := mock_service.NewMockSession(c)
+			tc.mockBehavior(session, tc.userId, tc.sessionId)
+
+			services := &service.Service{Session: session}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.DELETE("/api/v1/me/sessions/:id", tc.setCtx, handler.deleteSession)
+
+			req := httptest.NewRequest("DELETE", tc.url, nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,204 @@
+package v1
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// @Summary		Save list as template
+// @Security		ApiKeyAuth
+// @Tags			templates
+// @Description	Сохранение списка как шаблона. В названиях и описаниях задач можно использовать переменные {{name}}
+// @ID				save-list-template
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int							true	"List ID"
+// @Param			input	body		entity.SaveTemplateInput	false	"template title"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/lists/{id}/template [post]
+func (h *Handler) saveListTemplate(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	listId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.SaveTemplateInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+			return
+		}
+	}
+
+	id, err := h.services.ListTemplate.Save(userId, listId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
+
+type getAllListTemplatesResponse struct {
+	Data []entity.ListTemplate `json:"data"`
+}
+
+// @Summary		Get all templates
+// @Security		ApiKeyAuth
+// @Tags			templates
+// @Description	Шаблоны списков пользователя
+// @ID				get-all-list-templates
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	getAllListTemplatesResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/templates [get]
+func (h *Handler) getAllListTemplates(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templates, err := h.services.ListTemplate.GetAll(userId)
+	if err != nil {
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+		return
+	}
+
+	c.JSON(http.StatusOK, getAllListTemplatesResponse{
+		Data: templates,
+	})
+}
+
+// @Summary		Get template by ID
+// @Security		ApiKeyAuth
+// @Tags			templates
+// @Description	Шаблон с задачами и списком переменных, которые нужно заполнить при создании списка
+// @ID				get-list-template-by-id
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Template ID"
+// @Success		200		{object}	entity.ListTemplate
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/templates/{id} [get]
+func (h *Handler) getListTemplateById(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	template, err := h.services.ListTemplate.GetById(userId, templateId)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, template)
+}
+
+// @Summary		Delete template
+// @Security		ApiKeyAuth
+// @Tags			templates
+// @Description	Удаление шаблона. Созданные по нему списки не меняются
+// @ID				delete-list-template
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int	true	"Template ID"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/templates/{id} [delete]
+func (h *Handler) deleteListTemplate(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.ListTemplate.Delete(userId, templateId); err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+// @Summary		Instantiate template
+// @Security		ApiKeyAuth
+// @Tags			templates
+// @Description	Создание списка по шаблону. Значения нужны для всех переменных шаблона
+// @ID				instantiate-list-template
+// @Accept			json
+// @Produce		json
+// @Param			id		path		int								true	"Template ID"
+// @Param			input	body		entity.InstantiateTemplateInput	true	"variable values"
+// @Success		200		{object}	idResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		404		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/templates/{id}/instantiate [post]
+func (h *Handler) instantiateListTemplate(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	templateId, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	var input entity.InstantiateTemplateInput
+	if c.Request.ContentLength != 0 {
+		if err := c.BindJSON(&input); err != nil {
+			newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+			return
+		}
+	}
+
+	id, err := h.services.ListTemplate.Instantiate(userId, templateId, input)
+	if err != nil {
+		newListErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, idResponse{
+		Id: id,
+	})
+}
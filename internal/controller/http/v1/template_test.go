@@ -0,0 +1,118 @@
+package v1
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTemplateHandler_instantiateListTemplate(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockListTemplate)
+
+	tt := []struct {
+		name                string
+		url                 string
+		inputBody           string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			url:       "/api/v1/templates/3/instantiate",
+			inputBody: `{"title":"Rome","variables":{"city":"Rome"}}`,
+			mockBehavior: func(s *mock_service.MockListTemplate) {
+				s.EXPECT().Instantiate(1, 3, entity.InstantiateTemplateInput{
+					Title:     "Rome",
+					Variables: map[string]string{"city": "Rome"},
+				}).Return(10, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"id":10}`,
+		},
+		{
+			name: "Missing variables",
+			url:  "/api/v1/templates/3/instantiate",
+			mockBehavior: func(s *mock_service.MockListTemplate) {
+				s.EXPECT().Instantiate(1, 3, entity.InstantiateTemplateInput{}).
+					Return(0, fmt.Errorf("%w: city", service.ErrMissingVariables))
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"missing template variables: city"}`,
+		},
+		{
+			name:      "Not found",
+			url:       "/api/v1/templates/3/instantiate",
+			inputBody: `{}`,
+			mockBehavior: func(s *mock_service.MockListTemplate) {
+				s.EXPECT().Instantiate(1, 3, entity.InstantiateTemplateInput{}).Return(0, service.ErrTemplateNotFound)
+			},
+			expectedStatusCode:  404,
+			expectedRequestBody: `{"message":"template not found"}`,
+		},
+		{
+			name:                "Invalid id",
+			url:                 "/api/v1/templates/abc/instantiate",
+			mockBehavior:        func(s *mock_service.MockListTemplate) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			templates := mock_service.NewMockListTemplate(c)
+			tc.mockBehavior(templates)
+
+			handler := NewHandler(&service.Service{ListTemplate: templates})
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/templates/:id/instantiate", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.instantiateListTemplate)
+
+			req := httptest.NewRequest("POST", tc.url, bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestTemplateHandler_getListTemplateById(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	templates := mock_service.NewMockListTemplate(c)
+	templates.EXPECT().GetById(1, 3).Return(entity.ListTemplate{
+		Id:        3,
+		Title:     "Trip to {{city}}",
+		Variables: []string{"city"},
+		Items:     []entity.TemplateItem{{Id: 7, Title: "Tickets", Priority: entity.PriorityHigh, Position: 65536}},
+	}, nil)
+
+	handler := NewHandler(&service.Service{ListTemplate: templates})
+
+	gin.SetMode(gin.ReleaseMode)
+	w := httptest.NewRecorder()
+	r := gin.New()
+	r.GET("/api/v1/templates/:id", func(c *gin.Context) { c.Set(userCtx, 1) }, handler.getListTemplateById)
+
+	r.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/templates/3", nil))
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, `{"id":3,"title":"Trip to {{city}}","description":"","created_at":"0001-01-01T00:00:00Z","variables":["city"],`+
+		`"items":[{"id":7,"title":"Tickets","description":"","priority":"high"}]}`, w.Body.String())
+}
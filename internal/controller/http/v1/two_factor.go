@@ -0,0 +1,126 @@
+package v1
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+type recoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// @Summary		Enroll 2FA
+// @Security		ApiKeyAuth
+// @Tags			2fa
+// @Description	Создание секрета TOTP. Второй фактор включается после подтверждения первым кодом
+// @ID				enroll-2fa
+// @Accept			json
+// @Produce		json
+// @Success		200		{object}	entity.TwoFactorEnrollment
+// @Failure		400,401	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/2fa/enroll [post]
+func (h *Handler) enrollTwoFactor(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	enrollment, err := h.services.TwoFactor.Enroll(userId)
+	if err != nil {
+		newTwoFactorErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, enrollment)
+}
+
+// @Summary		Confirm 2FA
+// @Security		ApiKeyAuth
+// @Tags			2fa
+// @Description	Включение второго фактора первым кодом из приложения. В ответе - резервные коды, они показываются один раз
+// @ID				confirm-2fa
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.TwoFactorCodeInput	true	"code"
+// @Success		200		{object}	recoveryCodesResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		409		{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/2fa/confirm [post]
+func (h *Handler) confirmTwoFactor(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.TwoFactorCodeInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	codes, err := h.services.TwoFactor.Confirm(userId, input.Code)
+	if err != nil {
+		newTwoFactorErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, recoveryCodesResponse{
+		RecoveryCodes: codes,
+	})
+}
+
+// @Summary		Disable 2FA
+// @Security		ApiKeyAuth
+// @Tags			2fa
+// @Description	Отключение второго фактора по коду из приложения или резервному коду
+// @ID				disable-2fa
+// @Accept			json
+// @Produce		json
+// @Param			input	body		entity.TwoFactorCodeInput	true	"code"
+// @Success		200		{object}	statusResponse
+// @Failure		400,401	{object}	errorResponse
+// @Failure		500		{object}	errorResponse
+// @Failure		default	{object}	errorResponse
+// @Router			/api/v1/me/2fa/disable [post]
+func (h *Handler) disableTwoFactor(c *gin.Context) {
+	userId, err := getUserId(c)
+	if err != nil {
+		return
+	}
+
+	var input entity.TwoFactorCodeInput
+	if err := c.BindJSON(&input); err != nil {
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidInputBody)
+		return
+	}
+
+	if err = h.services.TwoFactor.Disable(userId, input.Code); err != nil {
+		newTwoFactorErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, statusResponse{
+		Status: "ok",
+	})
+}
+
+func newTwoFactorErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidTwoFactorCode):
+		newErrorResponse(c, http.StatusBadRequest, ErrInvalidTwoFactor)
+	case errors.Is(err, service.ErrTwoFactorNotEnrolled):
+		newErrorResponse(c, http.StatusBadRequest, ErrTwoFactorNotEnabled)
+	case errors.Is(err, service.ErrTwoFactorAlreadyEnabled):
+		newErrorResponse(c, http.StatusConflict, ErrTwoFactorEnabled)
+	default:
+		newErrorResponse(c, http.StatusInternalServerError, ErrServiceFailure)
+	}
+}
@@ -0,0 +1,149 @@
+package v1
+
+import (
+	"bytes"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/service"
+	mock_service "github.com/IncubusX/go-todo-app/internal/service/mocks"
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestTwoFactorHandler_enrollTwoFactor(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTwoFactor, userId int)
+
+	tt := []struct {
+		name                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(s *mock_service.MockTwoFactor, userId int) {
+				s.EXPECT().Enroll(userId).Return(entity.TwoFactorEnrollment{
+					Secret: "SECRET",
+					URI:    "otpauth://totp/Todo%20App:test?secret=SECRET",
+				}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"secret":"SECRET","uri":"otpauth://totp/Todo%20App:test?secret=SECRET"}`,
+		},
+		{
+			name: "Already enabled",
+			mockBehavior: func(s *mock_service.MockTwoFactor, userId int) {
+				s.EXPECT().Enroll(userId).Return(entity.TwoFactorEnrollment{}, service.ErrTwoFactorAlreadyEnabled)
+			},
+			expectedStatusCode:  409,
+			expectedRequestBody: `{"message":"two-factor authentication is already enabled"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			twoFactor := mock_service.NewMockTwoFactor(c)
+			tc.mockBehavior(twoFactor, 1)
+
+			services := &service.Service{TwoFactor: twoFactor}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/me/2fa/enroll", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.enrollTwoFactor)
+
+			req := httptest.NewRequest("POST", "/api/v1/me/2fa/enroll", nil)
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
+
+func TestTwoFactorHandler_confirmTwoFactor(t *testing.T) {
+	type mockBehavior func(s *mock_service.MockTwoFactor, userId int, code string)
+
+	tt := []struct {
+		name                string
+		inputBody           string
+		code                string
+		mockBehavior        mockBehavior
+		expectedStatusCode  int
+		expectedRequestBody string
+	}{
+		{
+			name:      "Ok",
+			inputBody: `{"code":"123456"}`,
+			code:      "123456",
+			mockBehavior: func(s *mock_service.MockTwoFactor, userId int, code string) {
+				s.EXPECT().Confirm(userId, code).Return([]string{"abcde-fghij"}, nil)
+			},
+			expectedStatusCode:  200,
+			expectedRequestBody: `{"recovery_codes":["abcde-fghij"]}`,
+		},
+		{
+			name:                "BindJSON",
+			inputBody:           `{}`,
+			mockBehavior:        func(s *mock_service.MockTwoFactor, userId int, code string) {},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid input body"}`,
+		},
+		{
+			name:      "Wrong code",
+			inputBody: `{"code":"000000"}`,
+			code:      "000000",
+			mockBehavior: func(s *mock_service.MockTwoFactor, userId int, code string) {
+				s.EXPECT().Confirm(userId, code).Return(nil, service.ErrInvalidTwoFactorCode)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"invalid two-factor code"}`,
+		},
+		{
+			name:      "Not enrolled",
+			inputBody: `{"code":"123456"}`,
+			code:      "123456",
+			mockBehavior: func(s *mock_service.MockTwoFactor, userId int, code string) {
+				s.EXPECT().Confirm(userId, code).Return(nil, service.ErrTwoFactorNotEnrolled)
+			},
+			expectedStatusCode:  400,
+			expectedRequestBody: `{"message":"two-factor authentication is not enrolled"}`,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			twoFactor := mock_service.NewMockTwoFactor(c)
+			tc.mockBehavior(twoFactor, 1, tc.code)
+
+			services := &service.Service{TwoFactor: twoFactor}
+			handler := NewHandler(services)
+
+			gin.SetMode(gin.ReleaseMode)
+			w := httptest.NewRecorder()
+			r := gin.New()
+			r.POST("/api/v1/me/2fa/confirm", func(c *gin.Context) {
+				c.Set(userCtx, 1)
+			}, handler.confirmTwoFactor)
+
+			req := httptest.NewRequest("POST", "/api/v1/me/2fa/confirm", bytes.NewBufferString(tc.inputBody))
+
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tc.expectedStatusCode, w.Code)
+			assert.Equal(t, tc.expectedRequestBody, w.Body.String())
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package entity
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	"time"
+)
+
+// PersonalAccessTokenPrefix позволяет отличить персональный токен от JWT по заголовку Authorization.
+const PersonalAccessTokenPrefix = "tdp_"
+
+const (
+	ScopeListsRead  = "lists:read"
+	ScopeListsWrite = "lists:write"
+	ScopeItemsRead  = "items:read"
+	ScopeItemsWrite = "items:write"
+	// ScopeAdmin даёт полный доступ, в том числе к управлению аккаунтом, сессиями и токенами.
+	ScopeAdmin = "admin"
+)
+
+var knownScopes = map[string]bool{
+	ScopeListsRead:  true,
+	ScopeListsWrite: true,
+	ScopeItemsRead:  true,
+	ScopeItemsWrite: true,
+	ScopeAdmin:      true,
+}
+
+type PersonalAccessToken struct {
+	Id         int            `json:"id" db:"id"`
+	UserId     int            `json:"-" db:"user_id"`
+	Name       string         `json:"name" db:"name"`
+	Scopes     pq.StringArray `json:"scopes" db:"scopes" swaggertype:"array,string"`
+	TokenHash  string         `json:"-" db:"token_hash"`
+	Token      string         `json:"token,omitempty" db:"-"`
+	CreatedAt  time.Time      `json:"created_at" db:"created_at"`
+	LastUsedAt *time.Time     `json:"last_used_at" db:"last_used_at"`
+	ExpiresAt  *time.Time     `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time     `json:"-" db:"revoked_at"`
+}
+
+func (t PersonalAccessToken) Active(now time.Time) bool {
+	return t.RevokedAt == nil && (t.ExpiresAt == nil || now.Before(*t.ExpiresAt))
+}
+
+type CreatePersonalAccessTokenInput struct {
+	Name      string     `json:"name" binding:"required"`
+	Scopes    []string   `json:"scopes" binding:"required"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+func (i *CreatePersonalAccessTokenInput) Validate() error {
+	if len(i.Scopes) == 0 {
+		return errors.New("token must have at least one scope")
+	}
+	for _, scope := range i.Scopes {
+		if !knownScopes[scope] {
+			return errors.New("unknown scope " + scope)
+		}
+	}
+	if i.ExpiresAt != nil && i.ExpiresAt.Before(time.Now()) {
+		return errors.New("expiration date is in the past")
+	}
+	return nil
+}
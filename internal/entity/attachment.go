@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"io"
+	"mime"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const maxFileNameLength = 255
+
+// AttachmentContentTypes - типы файлов, которые можно прикрепить к задаче. Тип определяется по содержимому файла.
+var AttachmentContentTypes = []string{
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"application/pdf",
+	"text/plain",
+}
+
+// AllowedAttachmentType проверяет тип без параметров, например text/plain; charset=utf-8 - это text/plain.
+func AllowedAttachmentType(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, allowed := range AttachmentContentTypes {
+		if mediaType == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+// Attachment - метаданные прикреплённого к задаче файла. ETag - SHA-256 содержимого.
+type Attachment struct {
+	Id          int       `json:"id" db:"id"`
+	ItemId      int       `json:"item_id" db:"item_id"`
+	UserId      *int      `json:"user_id,omitempty" db:"user_id"`
+	FileName    string    `json:"file_name" db:"file_name"`
+	ContentType string    `json:"content_type" db:"content_type"`
+	Size        int64     `json:"size" db:"size"`
+	BlobKey     string    `json:"-" db:"blob_key"`
+	ETag        string    `json:"etag" db:"etag"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+}
+
+// AttachmentUpload - загружаемый файл. Size - размер из запроса, содержимое читается из Content один раз.
+type AttachmentUpload struct {
+	FileName string
+	Size     int64
+	Content  io.Reader
+}
+
+// SanitizeFileName оставляет от имени файла последний элемент пути без управляющих символов, не длиннее 255 символов.
+func SanitizeFileName(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+
+	if runes := []rune(name); len(runes) > maxFileNameLength {
+		name = string(runes[:maxFileNameLength])
+	}
+	if name == "" || name == "." || name == ".." {
+		return "file"
+	}
+	return name
+}
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const maxCommentLength = 10000
+
+// DeletedAuthor - имя автора комментариев удалённого пользователя.
+const DeletedAuthor = "deleted user"
+
+// mentionPattern находит упоминания @username в начале строки или после символа, который не может быть частью
+// адреса почты или другого упоминания.
+var mentionPattern = regexp.MustCompile(`(?:^|[^\w@])@([\w.-]+)`)
+
+// Comment - комментарий к задаче, тело в Markdown. ParentId - комментарий, на который это ответ.
+// Удалённый комментарий, на который есть ответы, остаётся в ветке с пустым телом и Deleted.
+// У комментариев удалённого пользователя нет AuthorId, а Author - DeletedAuthor.
+type Comment struct {
+	Id        int        `json:"id" db:"id"`
+	ItemId    int        `json:"item_id" db:"item_id"`
+	ParentId  *int       `json:"parent_id,omitempty" db:"parent_id"`
+	AuthorId  *int       `json:"author_id,omitempty" db:"user_id"`
+	Author    string     `json:"author" db:"author"`
+	Body      string     `json:"body" db:"body"`
+	Deleted   bool       `json:"deleted,omitempty" db:"deleted"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt *time.Time `json:"updated_at,omitempty" db:"updated_at"`
+	Replies   []Comment  `json:"replies,omitempty" db:"-"`
+}
+
+type CreateCommentInput struct {
+	Body     string `json:"body" binding:"required" example:"@bob, **please** check the tickets"`
+	ParentId *int   `json:"parent_id"`
+}
+
+func (i *CreateCommentInput) Validate() error {
+	return validateCommentBody(i.Body)
+}
+
+type UpdateCommentInput struct {
+	Body string `json:"body" binding:"required"`
+}
+
+func (i *UpdateCommentInput) Validate() error {
+	return validateCommentBody(i.Body)
+}
+
+func validateCommentBody(body string) error {
+	if strings.TrimSpace(body) == "" {
+		return errors.New("comment body can not be empty")
+	}
+	if utf8.RuneCountInString(body) > maxCommentLength {
+		return fmt.Errorf("comment body must be at most %d characters", maxCommentLength)
+	}
+	return nil
+}
+
+// Mentions возвращает имена пользователей, упомянутых в тексте через @username, без повторов.
+// Точки в конце имени считаются знаками препинания.
+func Mentions(body string) []string {
+	seen := make(map[string]bool)
+	usernames := make([]string, 0)
+	for _, match := range mentionPattern.FindAllStringSubmatch(body, -1) {
+		username := strings.TrimRight(match[1], ".")
+		if username == "" || seen[username] {
+			continue
+		}
+		seen[username] = true
+		usernames = append(usernames, username)
+	}
+	return usernames
+}
+
+// BuildCommentTree собирает ветки обсуждения из плоского списка, упорядоченного по времени создания.
+func BuildCommentTree(comments []Comment) []Comment {
+	replies := make(map[int][]Comment)
+	roots := make([]Comment, 0)
+	for _, comment := range comments {
+		if comment.ParentId != nil {
+			replies[*comment.ParentId] = append(replies[*comment.ParentId], comment)
+		} else {
+			roots = append(roots, comment)
+		}
+	}
+
+	var attach func(comments []Comment) []Comment
+	attach = func(comments []Comment) []Comment {
+		for i := range comments {
+			if sub, ok := replies[comments[i].Id]; ok {
+				comments[i].Replies = attach(sub)
+			}
+		}
+		return comments
+	}
+
+	return attach(roots)
+}
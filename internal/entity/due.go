@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Периоды выборки задач по сроку.
+const (
+	DueOverdue = "overdue"
+	DueToday   = "today"
+	DueWeek    = "week"
+)
+
+// DueRange - интервал сроков [From, To). Задачи со временем сравниваются по моментам From/To,
+// задачи на весь день - по датам FromDate/ToDate (полночь UTC). Нулевое начало означает открытый интервал.
+type DueRange struct {
+	From     time.Time
+	To       time.Time
+	FromDate time.Time
+	ToDate   time.Time
+}
+
+// NullableTime отличает отсутствующее в JSON поле от явного null.
+// Set=true и Time=nil означает, что значение нужно очистить.
+type NullableTime struct {
+	Set  bool
+	Time *time.Time
+}
+
+func (t *NullableTime) UnmarshalJSON(data []byte) error {
+	t.Set = true
+	if string(data) == "null" {
+		t.Time = nil
+		return nil
+	}
+
+	var value time.Time
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	t.Time = &value
+	return nil
+}
+
+// ValidTimeZone проверяет, что зона есть в базе часовых поясов IANA.
+func ValidTimeZone(name string) bool {
+	_, err := time.LoadLocation(name)
+	return err == nil && name != "" && name != "Local"
+}
+
+// DateOf возвращает полночь UTC календарной даты момента t в его собственной зоне.
+func DateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,67 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// MaxInviteLinkTTL ограничивает срок действия ссылки-приглашения, DefaultInviteLinkTTL используется, если срок не задан.
+const (
+	DefaultInviteLinkTTL = 7 * 24 * time.Hour
+	MaxInviteLinkTTL     = 30 * 24 * time.Hour
+)
+
+// ListInvitation - приглашение в список, ожидающее ответа приглашённого.
+type ListInvitation struct {
+	Id        int       `json:"id" db:"id"`
+	ListId    int       `json:"list_id" db:"list_id"`
+	ListTitle string    `json:"list_title" db:"list_title"`
+	UserId    int       `json:"-" db:"user_id"`
+	InviterId int       `json:"-" db:"inviter_id"`
+	InvitedBy string    `json:"invited_by" db:"invited_by"`
+	Role      string    `json:"role" db:"role"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
+
+// InviteLink - ссылка, по которой любой её обладатель может вступить в список с заданной ролью.
+// Token отдаётся только при создании и в БД не хранится.
+type InviteLink struct {
+	Id        int        `json:"id" db:"id"`
+	ListId    int        `json:"list_id" db:"list_id"`
+	CreatedBy int        `json:"-" db:"created_by"`
+	Role      string     `json:"role" db:"role"`
+	SingleUse bool       `json:"single_use" db:"single_use"`
+	ExpiresAt time.Time  `json:"expires_at" db:"expires_at"`
+	UsedAt    *time.Time `json:"-" db:"used_at"`
+	Token     string     `json:"token,omitempty" db:"-"`
+	URL       string     `json:"url,omitempty" db:"-"`
+}
+
+type CreateInviteLinkInput struct {
+	Role string `json:"role" binding:"required"`
+	// ExpiresIn - срок действия в секундах
+	ExpiresIn int  `json:"expires_in"`
+	SingleUse bool `json:"single_use"`
+}
+
+func (i *CreateInviteLinkInput) Validate() error {
+	if !ValidListRole(i.Role) {
+		return errors.New("unknown role " + i.Role)
+	}
+	if i.ExpiresIn < 0 || time.Duration(i.ExpiresIn)*time.Second > MaxInviteLinkTTL {
+		return errors.New("expires_in must be between 0 and 30 days")
+	}
+	return nil
+}
+
+// TTL возвращает срок действия ссылки с учётом значения по умолчанию.
+func (i *CreateInviteLinkInput) TTL() time.Duration {
+	if i.ExpiresIn == 0 {
+		return DefaultInviteLinkTTL
+	}
+	return time.Duration(i.ExpiresIn) * time.Second
+}
+
+type AcceptInviteLinkInput struct {
+	Token string `json:"token" binding:"required"`
+}
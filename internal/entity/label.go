@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const maxLabelNameLength = 64
+
+var labelColor = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Label - метка пользователя (#work, #errands). Метки принадлежат пользователю, но видны на задаче всем участникам списка.
+type Label struct {
+	Id     int    `json:"id" db:"id"`
+	UserId int    `json:"-" db:"user_id"`
+	Name   string `json:"name" db:"name" binding:"required"`
+	Color  string `json:"color" db:"color"`
+}
+
+func (l *Label) Validate() error {
+	l.Name = NormalizeLabelName(l.Name)
+	if l.Name == "" || len(l.Name) > maxLabelNameLength {
+		return fmt.Errorf("label name must be 1 to %d characters long", maxLabelNameLength)
+	}
+	if l.Color == "" {
+		l.Color = "#808080"
+	}
+	if !labelColor.MatchString(l.Color) {
+		return errors.New("color must be in #rrggbb format")
+	}
+	return nil
+}
+
+type UpdateLabelInput struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"`
+}
+
+func (i *UpdateLabelInput) Validate() error {
+	if i.Name == nil && i.Color == nil {
+		return errors.New("update structure has no values")
+	}
+	if i.Name != nil {
+		name := NormalizeLabelName(*i.Name)
+		if name == "" || len(name) > maxLabelNameLength {
+			return fmt.Errorf("label name must be 1 to %d characters long", maxLabelNameLength)
+		}
+		i.Name = &name
+	}
+	if i.Color != nil && !labelColor.MatchString(*i.Color) {
+		return errors.New("color must be in #rrggbb format")
+	}
+	return nil
+}
+
+// NormalizeLabelName убирает пробелы и ведущий #, чтобы "#work" и "work" были одной меткой.
+func NormalizeLabelName(name string) string {
+	return strings.TrimPrefix(strings.TrimSpace(name), "#")
+}
+
+// LabelList читается из JSON-массива, который собирает запрос задач, чтобы получить метки всех задач одним запросом.
+type LabelList []Label
+
+func (l *LabelList) Scan(src interface{}) error {
+	var data []byte
+	switch v := src.(type) {
+	case nil:
+		*l = nil
+		return nil
+	case []byte:
+		data = v
+	case string:
+		data = []byte(v)
+	default:
+		return fmt.Errorf("unsupported label list type %T", src)
+	}
+	return json.Unmarshal(data, l)
+}
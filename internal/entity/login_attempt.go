@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// LoginAttempt - счётчик неудачных попыток входа по одному ключу (логину или IP-адресу).
+type LoginAttempt struct {
+	Key           string     `db:"key"`
+	Failures      int        `db:"failures"`
+	LastFailureAt *time.Time `db:"last_failure_at"`
+	LockedUntil   *time.Time `db:"locked_until"`
+}
+
+func (a LoginAttempt) Locked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
+
+const AuditLoginLockout = "login.lockout"
+
+type AuditEntry struct {
+	Id        int       `json:"id" db:"id"`
+	Event     string    `json:"event" db:"event"`
+	Subject   string    `json:"subject" db:"subject"`
+	IP        string    `json:"ip" db:"ip"`
+	Details   string    `json:"details" db:"details"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,51 @@
+package entity
+
+import "errors"
+
+// MoveInput ставит элемент сразу перед BeforeId или сразу после AfterId. Задать нужно ровно один якорь.
+type MoveInput struct {
+	BeforeId *int `json:"before_id"`
+	AfterId  *int `json:"after_id"`
+}
+
+func (i *MoveInput) Validate() error {
+	if (i.BeforeId == nil) == (i.AfterId == nil) {
+		return errors.New("exactly one of before_id and after_id is required")
+	}
+	return nil
+}
+
+// Anchor возвращает якорь и признак того, что элемент ставится после него.
+func (i *MoveInput) Anchor() (int, bool) {
+	if i.AfterId != nil {
+		return *i.AfterId, true
+	}
+	return *i.BeforeId, false
+}
+
+// MoveItemInput переносит задачу вместе с подзадачами в список ListId. Без якоря задача встаёт последней
+// среди задач верхнего уровня, с якорем - рядом с ним; якорь должен быть в целевом списке.
+type MoveItemInput struct {
+	ListId *int `json:"list_id"`
+	MoveInput
+}
+
+func (i *MoveItemInput) Validate() error {
+	if i.ListId == nil {
+		return i.MoveInput.Validate()
+	}
+	if i.BeforeId != nil && i.AfterId != nil {
+		return errors.New("only one of before_id and after_id can be set")
+	}
+	return nil
+}
+
+// HasAnchor сообщает, что задан before_id или after_id.
+func (i *MoveItemInput) HasAnchor() bool {
+	return i.BeforeId != nil || i.AfterId != nil
+}
+
+// CopyItemInput копирует задачу с подзадачами и метками в список ListId, по умолчанию в тот же список.
+type CopyItemInput struct {
+	ListId *int `json:"list_id"`
+}
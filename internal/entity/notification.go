@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// Типы уведомлений.
+const (
+	NotificationListInvitation = "list_invitation"
+	NotificationListDeleted    = "list_deleted"
+	NotificationItemCompleted  = "item_completed"
+	NotificationItemAssigned   = "item_assigned"
+	NotificationMention        = "mention"
+	NotificationReminder       = "reminder"
+)
+
+// NotificationTypes - все типы уведомлений, которые можно отключить.
+var NotificationTypes = []string{
+	NotificationListInvitation,
+	NotificationListDeleted,
+	NotificationItemCompleted,
+	NotificationItemAssigned,
+	NotificationMention,
+	NotificationReminder,
+}
+
+// Notification - запись во входящих уведомлениях пользователя. ActorId - пользователь, совершивший действие,
+// Actor - его имя.
+type Notification struct {
+	Id        int        `json:"id" db:"id"`
+	UserId    int        `json:"-" db:"user_id"`
+	Type      string     `json:"type" db:"type"`
+	Title     string     `json:"title" db:"title"`
+	Body      string     `json:"body" db:"body"`
+	ListId    *int       `json:"list_id,omitempty" db:"list_id"`
+	ItemId    *int       `json:"item_id,omitempty" db:"item_id"`
+	ActorId   *int       `json:"actor_id,omitempty" db:"actor_id"`
+	Actor     string     `json:"actor,omitempty" db:"actor"`
+	DedupKey  string     `json:"-" db:"-"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+	ReadAt    *time.Time `json:"read_at,omitempty" db:"read_at"`
+}
+
+// NotificationFilter - страница входящих, от новых к старым. BeforeId - id последнего уведомления предыдущей страницы.
+type NotificationFilter struct {
+	Unread   bool
+	BeforeId int
+	Limit    int
+}
+
+type NotificationPreference struct {
+	Type  string `json:"type"`
+	Muted bool   `json:"muted"`
+}
+
+// UpdateNotificationPreferencesInput заменяет набор отключённых типов уведомлений.
+type UpdateNotificationPreferencesInput struct {
+	Muted []string `json:"muted"`
+}
+
+func (i *UpdateNotificationPreferencesInput) Validate() error {
+	for _, muted := range i.Muted {
+		if !ValidNotificationType(muted) {
+			return fmt.Errorf("unknown notification type %q", muted)
+		}
+	}
+	return nil
+}
+
+func ValidNotificationType(notificationType string) bool {
+	for _, t := range NotificationTypes {
+		if t == notificationType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+// ExternalIdentity связывает пользователя с учётной записью у внешнего провайдера OpenID Connect.
+type ExternalIdentity struct {
+	Id        int       `db:"id"`
+	UserId    int       `db:"user_id"`
+	Provider  string    `db:"provider"`
+	Subject   string    `db:"subject"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type OIDCLogin struct {
+	URL        string
+	StateToken string
+}
+
+type OIDCCallbackInput struct {
+	Code       string
+	State      string
+	StateToken string
+}
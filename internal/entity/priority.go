@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Priority хранится в БД числом для сортировки, а в API передаётся названием.
+type Priority int
+
+const (
+	PriorityNone Priority = iota
+	PriorityLow
+	PriorityMedium
+	PriorityHigh
+	PriorityUrgent
+)
+
+var priorityNames = []string{"none", "low", "medium", "high", "urgent"}
+
+func ParsePriority(name string) (Priority, error) {
+	for i, priorityName := range priorityNames {
+		if strings.EqualFold(name, priorityName) {
+			return Priority(i), nil
+		}
+	}
+	return PriorityNone, fmt.Errorf("unknown priority %q", name)
+}
+
+func (p Priority) String() string {
+	if p < PriorityNone || p > PriorityUrgent {
+		return fmt.Sprintf("Priority(%d)", int(p))
+	}
+	return priorityNames[p]
+}
+
+func (p Priority) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *Priority) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return err
+	}
+
+	priority, err := ParsePriority(name)
+	if err != nil {
+		return err
+	}
+	*p = priority
+	return nil
+}
@@ -0,0 +1,50 @@
+package entity
+
+import (
+	"errors"
+	"time"
+)
+
+// PublicLink публикует список по неугадываемой ссылке для чтения без авторизации.
+// У списка может быть только одна публичная ссылка, повторная публикация заменяет её.
+type PublicLink struct {
+	Id           int        `json:"-" db:"id"`
+	ListId       int        `json:"list_id" db:"list_id"`
+	CreatedBy    int        `json:"-" db:"created_by"`
+	TokenHash    string     `json:"-" db:"token_hash"`
+	PasswordHash string     `json:"-" db:"password_hash"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty" db:"expires_at"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	HasPassword  bool       `json:"has_password" db:"-"`
+	Token        string     `json:"token,omitempty" db:"-"`
+	URL          string     `json:"url,omitempty" db:"-"`
+}
+
+type PublishListInput struct {
+	// ExpiresIn - срок действия в секундах, 0 - бессрочно
+	ExpiresIn int    `json:"expires_in"`
+	Password  string `json:"password"`
+}
+
+func (i *PublishListInput) Validate() error {
+	if i.ExpiresIn < 0 {
+		return errors.New("expires_in must not be negative")
+	}
+	return nil
+}
+
+// PublicList - то, что видит посетитель публичной ссылки.
+type PublicList struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Items       []PublicItem `json:"items"`
+}
+
+// PublicItem - задача на публичной странице. Идентификаторы, метки и участники списка наружу не отдаются.
+type PublicItem struct {
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Done        bool         `json:"done"`
+	DueAt       *time.Time   `json:"due_at,omitempty"`
+	Children    []PublicItem `json:"children,omitempty"`
+}
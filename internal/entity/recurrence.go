@@ -0,0 +1,38 @@
+package entity
+
+import (
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/rrule"
+	"strings"
+	"time"
+)
+
+// Occurrence - сроки и правило повторяющейся задачи после переноса на следующее повторение.
+type Occurrence struct {
+	StartAt *time.Time
+	DueAt   *time.Time
+	RRule   string
+}
+
+// NormalizeRRule приводит правило к каноническому виду. Пустая строка означает, что задача не повторяется.
+func NormalizeRRule(s string) (string, error) {
+	if strings.TrimSpace(s) == "" {
+		return "", nil
+	}
+	rule, err := rrule.Parse(s)
+	if err != nil {
+		return "", err
+	}
+	return rule.String(), nil
+}
+
+func validateRRule(s string, hasDue bool) error {
+	normalized, err := NormalizeRRule(s)
+	if err != nil {
+		return err
+	}
+	if normalized != "" && !hasDue {
+		return errors.New("rrule requires due_at")
+	}
+	return nil
+}
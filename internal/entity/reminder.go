@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+)
+
+// Каналы доставки напоминаний.
+const (
+	ReminderChannelInbox   = "inbox"
+	ReminderChannelEmail   = "email"
+	ReminderChannelWebhook = "webhook"
+)
+
+const maxReminderOffset = 366 * 24 * 60
+
+// Reminder срабатывает за OffsetMinutes до срока задачи или в момент RemindAt, задаётся ровно одно из них.
+// Напоминание принадлежит пользователю, который его создал, и приходит только ему.
+type Reminder struct {
+	Id            int        `json:"id" db:"id"`
+	ItemId        int        `json:"item_id" db:"item_id"`
+	UserId        int        `json:"-" db:"user_id"`
+	OffsetMinutes *int       `json:"offset_minutes,omitempty" db:"offset_minutes"`
+	RemindAt      *time.Time `json:"remind_at,omitempty" db:"remind_at"`
+	Channel       string     `json:"channel" db:"channel"`
+	WebhookURL    string     `json:"webhook_url,omitempty" db:"webhook_url"`
+	FireAt        *time.Time `json:"fire_at,omitempty" db:"fire_at"`
+	SentFor       *time.Time `json:"sent_for,omitempty" db:"sent_for"`
+	LastError     string     `json:"last_error,omitempty" db:"last_error"`
+}
+
+func (r *Reminder) Validate() error {
+	if (r.OffsetMinutes == nil) == (r.RemindAt == nil) {
+		return errors.New("exactly one of offset_minutes and remind_at is required")
+	}
+	if r.OffsetMinutes != nil && (*r.OffsetMinutes < 0 || *r.OffsetMinutes > maxReminderOffset) {
+		return fmt.Errorf("offset_minutes must be between 0 and %d", maxReminderOffset)
+	}
+
+	if r.Channel == "" {
+		r.Channel = ReminderChannelInbox
+	}
+	switch r.Channel {
+	case ReminderChannelInbox, ReminderChannelEmail:
+		r.WebhookURL = ""
+	case ReminderChannelWebhook:
+		u, err := url.Parse(r.WebhookURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return errors.New("webhook_url must be an absolute http(s) URL")
+		}
+	default:
+		return fmt.Errorf("channel must be one of %s, %s, %s", ReminderChannelInbox, ReminderChannelEmail, ReminderChannelWebhook)
+	}
+	return nil
+}
+
+// DueReminder - сработавшее напоминание вместе с данными задачи и получателя, нужными для доставки.
+// Email заполнен, только если адрес пользователя подтверждён.
+type DueReminder struct {
+	Id         int        `db:"id"`
+	ItemId     int        `db:"item_id"`
+	ListId     int        `db:"list_id"`
+	UserId     int        `db:"user_id"`
+	Channel    string     `db:"channel"`
+	WebhookURL string     `db:"webhook_url"`
+	FireAt     time.Time  `db:"fire_at"`
+	Attempts   int        `db:"attempts"`
+	ItemTitle  string     `db:"item_title"`
+	DueAt      *time.Time `db:"due_at"`
+	AllDay     bool       `db:"all_day"`
+	Email      string     `db:"email"`
+	TimeZone   string     `db:"time_zone"`
+}
+
+// Notification собирает уведомление о напоминании. Ключ включает момент срабатывания,
+// поэтому повторная доставка того же срабатывания не создаёт второе уведомление.
+func (r DueReminder) Notification() Notification {
+	body := ""
+	if r.DueAt != nil {
+		if r.AllDay {
+			body = "Due " + r.DueAt.UTC().Format("2006-01-02")
+		} else {
+			loc, err := time.LoadLocation(r.TimeZone)
+			if err != nil {
+				loc = time.UTC
+			}
+			body = fmt.Sprintf("Due %s (%s)", r.DueAt.In(loc).Format("2006-01-02 15:04"), loc)
+		}
+	}
+
+	listId, itemId := r.ListId, r.ItemId
+	return Notification{
+		UserId:   r.UserId,
+		Type:     NotificationReminder,
+		Title:    r.ItemTitle,
+		Body:     body,
+		ListId:   &listId,
+		ItemId:   &itemId,
+		DedupKey: fmt.Sprintf("reminder:%d:%d", r.Id, r.FireAt.Unix()),
+	}
+}
+
+// DeliveryResult - итог попытки доставки. Err=nil - доставлено, RetryAt - когда повторить;
+// ошибка без RetryAt означает, что попытки прекращены и это срабатывание больше не доставляется.
+type DeliveryResult struct {
+	Err     error
+	RetryAt *time.Time
+}
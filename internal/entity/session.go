@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// maxUserAgentLength - длина колонки sessions.user_agent.
+const maxUserAgentLength = 512
+
+type Session struct {
+	Id         int        `json:"id" db:"id"`
+	UserId     int        `json:"-" db:"user_id"`
+	UserAgent  string     `json:"user_agent" db:"user_agent"`
+	IP         string     `json:"ip" db:"ip"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	LastSeenAt time.Time  `json:"last_seen_at" db:"last_seen_at"`
+	ExpiresAt  time.Time  `json:"expires_at" db:"expires_at"`
+	RevokedAt  *time.Time `json:"-" db:"revoked_at"`
+	Current    bool       `json:"current" db:"-"`
+}
+
+func (s Session) Active(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
+
+// Client - сведения об устройстве, с которого выполняется вход.
+type Client struct {
+	UserAgent string
+	IP        string
+}
+
+// NewClient отбрасывает из User-Agent байты, не образующие символов UTF-8, и обрезает его до длины колонки,
+// не разрывая символы.
+func NewClient(userAgent, ip string) Client {
+	userAgent = strings.ToValidUTF8(userAgent, "")
+	if len(userAgent) > maxUserAgentLength {
+		end := maxUserAgentLength
+		for !utf8.RuneStart(userAgent[end]) {
+			end--
+		}
+		userAgent = userAgent[:end]
+	}
+	return Client{UserAgent: userAgent, IP: ip}
+}
+
+// Identity - результат проверки access-токена или персонального токена.
+type Identity struct {
+	UserId    int
+	SessionId int
+	Scopes    []string
+}
+
+func (i Identity) HasScope(scope string) bool {
+	for _, s := range i.Scopes {
+		if s == scope || s == ScopeAdmin {
+			return true
+		}
+	}
+	return false
+}
+
+type RefreshToken struct {
+	Id        int        `db:"id"`
+	SessionId int        `db:"session_id"`
+	UserId    int        `db:"user_id"`
+	TokenHash string     `db:"token_hash"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+	RevokedAt *time.Time `db:"revoked_at"`
+}
+
+type Tokens struct {
+	AccessToken  string
+	RefreshToken string
+	// ChallengeToken заполняется вместо пары токенов, если для входа нужен второй фактор.
+	ChallengeToken string
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "encoding/json"
+
+// NullableInt отличает отсутствующее в JSON поле от явного null, как NullableTime.
+type NullableInt struct {
+	Set   bool
+	Value *int
+}
+
+func (i *NullableInt) UnmarshalJSON(data []byte) error {
+	i.Set = true
+	if string(data) == "null" {
+		i.Value = nil
+		return nil
+	}
+
+	var value int
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	i.Value = &value
+	return nil
+}
+
+// BuildItemTree собирает дерево из плоского списка, упорядоченного так, что родитель идёт раньше подзадач.
+// Задачи, родитель которых в список не попал (например, отсеян фильтром), становятся корнями.
+func BuildItemTree(items []TodoItem) []TodoItem {
+	children := make(map[int][]TodoItem)
+	present := make(map[int]bool, len(items))
+	for _, item := range items {
+		present[item.Id] = true
+	}
+
+	roots := make([]TodoItem, 0)
+	for _, item := range items {
+		if item.ParentId != nil && present[*item.ParentId] {
+			children[*item.ParentId] = append(children[*item.ParentId], item)
+		} else {
+			roots = append(roots, item)
+		}
+	}
+
+	var attach func(items []TodoItem) []TodoItem
+	attach = func(items []TodoItem) []TodoItem {
+		for i := range items {
+			if sub, ok := children[items[i].Id]; ok {
+				items[i].Children = attach(sub)
+			}
+		}
+		return items
+	}
+
+	return attach(roots)
+}
@@ -0,0 +1,87 @@
+package entity
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+var placeholder = regexp.MustCompile(`\{\{\s*([A-Za-z0-9_.-]+)\s*\}\}`)
+
+// ListTemplate - сохранённая структура списка. В названиях и описаниях могут быть переменные {{name}},
+// значения которых подставляются при создании списка из шаблона.
+type ListTemplate struct {
+	Id          int            `json:"id" db:"id"`
+	UserId      int            `json:"-" db:"user_id"`
+	Title       string         `json:"title" db:"title"`
+	Description string         `json:"description" db:"description"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+	Variables   []string       `json:"variables,omitempty" db:"-"`
+	Items       []TemplateItem `json:"items,omitempty" db:"-"`
+}
+
+type TemplateItem struct {
+	Id          int      `json:"id" db:"id"`
+	ParentId    *int     `json:"parent_id,omitempty" db:"parent_id"`
+	Title       string   `json:"title" db:"title"`
+	Description string   `json:"description" db:"description"`
+	Priority    Priority `json:"priority,omitempty" db:"priority" swaggertype:"string" enums:"none,low,medium,high,urgent"`
+	Position    int64    `json:"-" db:"position"`
+}
+
+// Placeholders возвращает отсортированные имена всех переменных шаблона.
+func (t *ListTemplate) Placeholders() []string {
+	seen := make(map[string]bool)
+	collect := func(s string) {
+		for _, match := range placeholder.FindAllStringSubmatch(s, -1) {
+			seen[match[1]] = true
+		}
+	}
+
+	collect(t.Title)
+	collect(t.Description)
+	for _, item := range t.Items {
+		collect(item.Title)
+		collect(item.Description)
+	}
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render подставляет значения переменных во все названия и описания шаблона.
+func (t *ListTemplate) Render(variables map[string]string) {
+	replace := func(s string) string {
+		return placeholder.ReplaceAllStringFunc(s, func(match string) string {
+			return variables[strings.TrimSpace(strings.Trim(match, "{}"))]
+		})
+	}
+
+	t.Title = replace(t.Title)
+	t.Description = replace(t.Description)
+	for i := range t.Items {
+		t.Items[i].Title = replace(t.Items[i].Title)
+		t.Items[i].Description = replace(t.Items[i].Description)
+	}
+}
+
+// SaveTemplateInput: без названия шаблон получает название списка.
+type SaveTemplateInput struct {
+	Title string `json:"title"`
+}
+
+// DuplicateListInput: без названия копия называется как исходный список с пометкой (copy).
+type DuplicateListInput struct {
+	Title string `json:"title"`
+}
+
+// InstantiateTemplateInput: значения нужны для всех переменных шаблона. Title заменяет название из шаблона.
+type InstantiateTemplateInput struct {
+	Title     string            `json:"title"`
+	Variables map[string]string `json:"variables"`
+}
@@ -1,17 +1,90 @@
 package entity
 
-import "errors"
+import (
+	"errors"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTitleLength - длина колонок title у списков, задач и шаблонов в символах.
+const MaxTitleLength = 255
+
+// copySuffix отмечает название копии списка.
+const copySuffix = " (copy)"
+
+// CopyTitle возвращает название копии списка. Исходное название укорачивается по границе символа, чтобы с пометкой
+// оно поместилось в колонку.
+func CopyTitle(title string) string {
+	limit := MaxTitleLength - utf8.RuneCountInString(copySuffix)
+	count := 0
+	for i := range title {
+		if count == limit {
+			return title[:i] + copySuffix
+		}
+		count++
+	}
+	return title + copySuffix
+}
 
 type TodoList struct {
 	Id          int    `json:"id" db:"id"`
 	Title       string `json:"title" db:"title" binding:"required"`
 	Description string `json:"description" db:"description"`
+	// Role - роль текущего пользователя в списке
+	Role string `json:"role,omitempty" db:"role"`
 }
 
 type UserLists struct {
-	Id     int `json:"id"`
-	UserId int `json:"user_id"`
-	ListId int `json:"list_id"`
+	Id     int    `json:"id"`
+	UserId int    `json:"user_id"`
+	ListId int    `json:"list_id"`
+	Role   string `json:"role"`
+}
+
+// Роли участников списка: владелец управляет списком и участниками, редактор меняет задачи, читатель только смотрит.
+const (
+	ListRoleOwner  = "owner"
+	ListRoleEditor = "editor"
+	ListRoleViewer = "viewer"
+)
+
+func ValidListRole(role string) bool {
+	return role == ListRoleOwner || role == ListRoleEditor || role == ListRoleViewer
+}
+
+// CanEditList сообщает, может ли роль менять задачи и сам список.
+func CanEditList(role string) bool {
+	return role == ListRoleOwner || role == ListRoleEditor
+}
+
+type ListMember struct {
+	UserId   int    `json:"user_id" db:"user_id"`
+	Name     string `json:"name" db:"name"`
+	Username string `json:"username" db:"username"`
+	Role     string `json:"role" db:"role"`
+}
+
+type InviteMemberInput struct {
+	Username string `json:"username" binding:"required"`
+	Role     string `json:"role" binding:"required"`
+}
+
+func (i *InviteMemberInput) Validate() error {
+	if !ValidListRole(i.Role) {
+		return errors.New("unknown role " + i.Role)
+	}
+	return nil
+}
+
+type UpdateMemberInput struct {
+	Role string `json:"role" binding:"required"`
+}
+
+func (i *UpdateMemberInput) Validate() error {
+	if !ValidListRole(i.Role) {
+		return errors.New("unknown role " + i.Role)
+	}
+	return nil
 }
 
 type TodoItem struct {
@@ -19,6 +92,53 @@ type TodoItem struct {
 	Title       string `json:"title" db:"title" binding:"required"`
 	Description string `json:"description" db:"description" binding:"required"`
 	Done        bool   `json:"done" db:"done"`
+	// ListId заполняется только в выборках по всем спискам пользователя
+	ListId  int        `json:"list_id,omitempty" db:"list_id"`
+	StartAt *time.Time `json:"start_at,omitempty" db:"start_at"`
+	DueAt   *time.Time `json:"due_at,omitempty" db:"due_at"`
+	// AllDay - срок задан датой без времени
+	AllDay bool `json:"all_day,omitempty" db:"all_day"`
+	// RRule - правило повторения RFC 5545, серия начинается с текущего срока задачи
+	RRule    string    `json:"rrule,omitempty" db:"rrule" example:"FREQ=WEEKLY;BYDAY=FR"`
+	Priority Priority  `json:"priority,omitempty" db:"priority" swaggertype:"string" enums:"none,low,medium,high,urgent"`
+	Labels   LabelList `json:"labels,omitempty" db:"labels"`
+	ParentId *int      `json:"parent_id,omitempty" db:"parent_id"`
+	// AssigneeId - исполнитель задачи из участников списка
+	AssigneeId *int `json:"assignee_id,omitempty" db:"assignee_id"`
+	// Depth - уровень вложенности подзадачи, у задач верхнего уровня 0
+	Depth int `json:"depth,omitempty" db:"depth"`
+	// Progress - доля выполненных подзадач на всех уровнях, только у задач с подзадачами
+	Progress *float64   `json:"progress,omitempty" db:"progress"`
+	Children []TodoItem `json:"children,omitempty" db:"-"`
+}
+
+// ItemFilter отбирает задачи по меткам (любой из перечисленных) и приоритетам. Query ищет по названию и описанию.
+type ItemFilter struct {
+	Labels     []string
+	Priorities []Priority
+	Query      string
+}
+
+func (i *TodoItem) Validate() error {
+	if i.StartAt != nil && i.DueAt != nil && i.StartAt.After(*i.DueAt) {
+		return errors.New("start_at must not be after due_at")
+	}
+	return validateRRule(i.RRule, i.DueAt != nil)
+}
+
+// NormalizeDates для задач на весь день отбрасывает время, оставляя дату.
+func (i *TodoItem) NormalizeDates() {
+	if !i.AllDay {
+		return
+	}
+	if i.StartAt != nil {
+		startDate := DateOf(*i.StartAt)
+		i.StartAt = &startDate
+	}
+	if i.DueAt != nil {
+		dueDate := DateOf(*i.DueAt)
+		i.DueAt = &dueDate
+	}
 }
 
 type ListItems struct {
@@ -39,15 +159,42 @@ func (i *UpdateListInput) Validate() error {
 	return nil
 }
 
+// UpdateItemInput: для start_at и due_at отсутствие поля оставляет значение как есть, а null очищает его.
 type UpdateItemInput struct {
-	Title       *string `json:"title"`
-	Description *string `json:"description"`
-	Done        *bool   `json:"done"`
+	Title       *string      `json:"title"`
+	Description *string      `json:"description"`
+	Done        *bool        `json:"done"`
+	StartAt     NullableTime `json:"start_at" swaggertype:"string" format:"date-time" extensions:"x-nullable"`
+	DueAt       NullableTime `json:"due_at" swaggertype:"string" format:"date-time" extensions:"x-nullable"`
+	AllDay      *bool        `json:"all_day"`
+	// RRule: пустая строка отключает повторение
+	RRule    *string     `json:"rrule"`
+	Priority *Priority   `json:"priority" swaggertype:"string" enums:"none,low,medium,high,urgent"`
+	ParentId NullableInt `json:"parent_id" swaggertype:"integer" extensions:"x-nullable"`
+	// AssigneeId: null снимает назначение
+	AssigneeId NullableInt `json:"assignee_id" swaggertype:"integer" extensions:"x-nullable"`
+	// CompleteChildren при done=true отмечает выполненными все подзадачи
+	CompleteChildren bool `json:"complete_children"`
+	// CompleteParent при done=true отмечает выполненным родителя, если у него не осталось невыполненных подзадач
+	CompleteParent bool `json:"complete_parent"`
 }
 
 func (i *UpdateItemInput) Validate() error {
-	if i.Title == nil && i.Description == nil && i.Done == nil {
+	if i.Title == nil && i.Description == nil && i.Done == nil && !i.StartAt.Set && !i.DueAt.Set && i.AllDay == nil &&
+		i.RRule == nil && i.Priority == nil && !i.ParentId.Set && !i.AssigneeId.Set {
 		return errors.New("update structure has no values")
 	}
+	if i.StartAt.Time != nil && i.DueAt.Time != nil && i.StartAt.Time.After(*i.DueAt.Time) {
+		return errors.New("start_at must not be after due_at")
+	}
+	if i.RRule != nil {
+		// Срок может быть уже задан у задачи, поэтому здесь проверяется только само правило
+		return validateRRule(*i.RRule, true)
+	}
 	return nil
 }
+
+// TouchesDates сообщает, что обновление затрагивает сроки задачи или правило повторения.
+func (i *UpdateItemInput) TouchesDates() bool {
+	return i.StartAt.Set || i.DueAt.Set || i.AllDay != nil || i.RRule != nil
+}
@@ -0,0 +1,22 @@
+package entity
+
+type TwoFactor struct {
+	UserId       int    `db:"user_id"`
+	Secret       string `db:"secret"`
+	Enabled      bool   `db:"enabled"`
+	LastUsedStep int64  `db:"last_used_step"`
+}
+
+type TwoFactorEnrollment struct {
+	Secret string `json:"secret"`
+	URI    string `json:"uri"`
+}
+
+type TwoFactorCodeInput struct {
+	Code string `json:"code" binding:"required"`
+}
+
+type TwoFactorSignInInput struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Code           string `json:"code" binding:"required"`
+}
@@ -1,8 +1,87 @@
 package entity
 
+import (
+	"errors"
+	"net/mail"
+	"time"
+)
+
 type User struct {
 	Id       int    `json:"-" db:"id"`
 	Name     string `json:"name" binding:"required"`
 	Username string `json:"username" binding:"required"`
-	Password string `json:"password" binding:"required"`
+	// Email необязателен, без него не работают подтверждение адреса и сброс пароля
+	Email         string `json:"email" db:"email" binding:"omitempty,email"`
+	EmailVerified bool   `json:"-" db:"email_verified"`
+	Password      string `json:"password" db:"password_hash" binding:"required"`
+	// TimeZone - часовой пояс IANA, в котором считаются сроки задач
+	TimeZone string `json:"-" db:"time_zone"`
+}
+
+type Profile struct {
+	Id            int    `json:"id" db:"id"`
+	Name          string `json:"name" db:"name"`
+	Username      string `json:"username" db:"username"`
+	Email         string `json:"email" db:"email"`
+	EmailVerified bool   `json:"email_verified" db:"email_verified"`
+	TimeZone      string `json:"time_zone" db:"time_zone"`
+}
+
+type UpdateUserInput struct {
+	Name     *string `json:"name"`
+	Username *string `json:"username"`
+	Email    *string `json:"email"`
+	TimeZone *string `json:"time_zone"`
+}
+
+func (i *UpdateUserInput) Validate() error {
+	if i.Name == nil && i.Username == nil && i.Email == nil && i.TimeZone == nil {
+		return errors.New("update structure has no values")
+	}
+	if i.Username != nil && *i.Username == "" {
+		return errors.New("username can not be empty")
+	}
+	if i.Email != nil {
+		if _, err := mail.ParseAddress(*i.Email); err != nil {
+			return errors.New("invalid email")
+		}
+	}
+	if i.TimeZone != nil && !ValidTimeZone(*i.TimeZone) {
+		return errors.New("unknown time zone")
+	}
+	return nil
+}
+
+type ChangePasswordInput struct {
+	OldPassword string `json:"old_password" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
+}
+
+// UserToken - одноразовый токен из письма: подтверждение адреса или сброс пароля.
+type UserToken struct {
+	Id        int        `db:"id"`
+	UserId    int        `db:"user_id"`
+	Purpose   string     `db:"purpose"`
+	TokenHash string     `db:"token_hash"`
+	Email     string     `db:"email"`
+	ExpiresAt time.Time  `db:"expires_at"`
+	UsedAt    *time.Time `db:"used_at"`
+}
+
+const (
+	UserTokenVerifyEmail   = "verify_email"
+	UserTokenResetPassword = "reset_password"
+)
+
+type VerifyEmailInput struct {
+	Token string `json:"token" binding:"required"`
+}
+
+type ForgotPasswordInput struct {
+	Email string `json:"email" binding:"required,email"`
+}
+
+type ResetPasswordInput struct {
+	Token       string `json:"token" binding:"required"`
+	NewPassword string `json:"new_password" binding:"required"`
 }
@@ -0,0 +1,123 @@
+package hash
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var testParams = Argon2idParams{
+	Memory:      1024,
+	Iterations:  1,
+	Parallelism: 1,
+	SaltLength:  16,
+	KeyLength:   32,
+}
+
+func TestArgon2idHasher_HashVerify(t *testing.T) {
+	h := NewArgon2idHasher(testParams, "salt")
+
+	encoded, err := h.Hash("qwerty")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$"))
+
+	other, err := h.Hash("qwerty")
+	assert.NoError(t, err)
+	assert.NotEqual(t, encoded, other, "каждый хэш должен иметь свою соль")
+
+	ok, err := h.Verify("qwerty", encoded)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = h.Verify("wrong", encoded)
+	assert.NoError(t, err)
+	assert.False(t, ok)
+
+	assert.False(t, h.NeedsRehash(encoded))
+}
+
+func TestArgon2idHasher_Verify(t *testing.T) {
+	h := NewArgon2idHasher(testParams, "salt")
+
+	bcryptHash, _ := bcrypt.GenerateFromPassword([]byte("qwerty"), bcrypt.MinCost)
+
+	tt := []struct {
+		name        string
+		password    string
+		encoded     string
+		ok          bool
+		wantErr     bool
+		needsRehash bool
+	}{
+		{
+			name:        "Legacy SHA-1",
+			password:    "qwerty",
+			encoded:     legacySHA1("qwerty", "salt"),
+			ok:          true,
+			needsRehash: true,
+		},
+		{
+			name:        "Legacy SHA-1 wrong password",
+			password:    "wrong",
+			encoded:     legacySHA1("qwerty", "salt"),
+			needsRehash: true,
+		},
+		{
+			name:        "Bcrypt",
+			password:    "qwerty",
+			encoded:     string(bcryptHash),
+			ok:          true,
+			needsRehash: true,
+		},
+		{
+			name:        "Bcrypt wrong password",
+			password:    "wrong",
+			encoded:     string(bcryptHash),
+			needsRehash: true,
+		},
+		{
+			name:        "Outdated params",
+			password:    "qwerty",
+			encoded:     mustHash(t, NewArgon2idHasher(Argon2idParams{Memory: 512, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}, "")),
+			ok:          true,
+			needsRehash: true,
+		},
+		{
+			name:        "Malformed argon2id",
+			password:    "qwerty",
+			encoded:     "$argon2id$v=19$m=1024",
+			wantErr:     true,
+			needsRehash: true,
+		},
+		{
+			name:        "Unknown scheme",
+			password:    "qwerty",
+			encoded:     "$md5$abc",
+			wantErr:     true,
+			needsRehash: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			ok, err := h.Verify(tc.password, tc.encoded)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.needsRehash, h.NeedsRehash(tc.encoded))
+		})
+	}
+}
+
+func mustHash(t *testing.T, h *Argon2idHasher) string {
+	encoded, err := h.Hash("qwerty")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
@@ -0,0 +1,170 @@
+package keyring
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"math/big"
+	"os"
+	"sort"
+)
+
+var (
+	ErrUnknownKey     = errors.New("unknown signing key")
+	ErrNoSigningKey   = errors.New("active key has no private part")
+	ErrUnsupportedKey = errors.New("unsupported key type, expected RSA or Ed25519")
+)
+
+// Key - ключ подписи токенов. У ключа, выведенного из оборота, может быть только открытая часть:
+// им больше не подписывают, но токены, выданные до ротации, ещё проверяются.
+type Key struct {
+	Id      string
+	Method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// ParseKey читает PEM с закрытым (PKCS#8, PKCS#1) или открытым (PKIX) ключом RSA или Ed25519.
+func ParseKey(id string, data []byte) (Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, fmt.Errorf("key %s: no PEM data found", id)
+	}
+
+	var (
+		parsed interface{}
+		err    error
+	)
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return Key{}, fmt.Errorf("key %s: unexpected PEM block %q", id, block.Type)
+	}
+	if err != nil {
+		return Key{}, fmt.Errorf("key %s: %w", id, err)
+	}
+
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		return Key{Id: id, Method: jwt.SigningMethodRS256, private: k, public: &k.PublicKey}, nil
+	case *rsa.PublicKey:
+		return Key{Id: id, Method: jwt.SigningMethodRS256, public: k}, nil
+	case ed25519.PrivateKey:
+		return Key{Id: id, Method: jwt.SigningMethodEdDSA, private: k, public: k.Public()}, nil
+	case ed25519.PublicKey:
+		return Key{Id: id, Method: jwt.SigningMethodEdDSA, public: k}, nil
+	default:
+		return Key{}, fmt.Errorf("key %s: %w", id, ErrUnsupportedKey)
+	}
+}
+
+// NewHMACKey создаёт симметричный ключ HS256. Он не публикуется в JWKS и нужен
+// только для совместимости с установками, где ещё не выпущены асимметричные ключи.
+func NewHMACKey(id string, secret []byte) Key {
+	return Key{Id: id, Method: jwt.SigningMethodHS256, private: secret, public: secret}
+}
+
+// KeyRing подписывает токены активным ключом и проверяет их любым ключом кольца по заголовку kid.
+type KeyRing struct {
+	active Key
+	keys   map[string]Key
+}
+
+func NewKeyRing(activeId string, keys ...Key) (*KeyRing, error) {
+	r := &KeyRing{keys: make(map[string]Key, len(keys))}
+	for _, key := range keys {
+		if _, ok := r.keys[key.Id]; ok {
+			return nil, fmt.Errorf("duplicate key id %s", key.Id)
+		}
+		r.keys[key.Id] = key
+	}
+
+	active, ok := r.keys[activeId]
+	if !ok {
+		return nil, fmt.Errorf("active key %s: %w", activeId, ErrUnknownKey)
+	}
+	if active.private == nil {
+		return nil, fmt.Errorf("active key %s: %w", activeId, ErrNoSigningKey)
+	}
+	r.active = active
+
+	return r, nil
+}
+
+// Load читает ключи из PEM-файлов. files - соответствие kid и пути к файлу.
+func Load(activeId string, files map[string]string) (*KeyRing, error) {
+	keys := make([]Key, 0, len(files))
+	for id, path := range files {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		key, err := ParseKey(id, data)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return NewKeyRing(activeId, keys...)
+}
+
+// Sign подписывает claims активным ключом и проставляет его kid в заголовок.
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(r.active.Method, claims)
+	token.Header["kid"] = r.active.Id
+
+	return token.SignedString(r.active.private)
+}
+
+// Keyfunc выбирает ключ проверки по kid. Алгоритм токена должен совпадать с алгоритмом ключа,
+// иначе открытый ключ RSA можно было бы выдать за секрет HS256.
+func (r *KeyRing) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	key, ok := r.keys[kid]
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, errors.New("invalid signing method")
+	}
+
+	return key.public, nil
+}
+
+// JWKS возвращает открытые ключи кольца. Симметричные ключи не публикуются.
+func (r *KeyRing) JWKS() entity.JSONWebKeySet {
+	set := entity.JSONWebKeySet{Keys: make([]entity.JSONWebKey, 0, len(r.keys))}
+
+	for _, key := range r.keys {
+		jwk := entity.JSONWebKey{KeyId: key.Id, Use: "sig", Algorithm: key.Method.Alg()}
+		switch pub := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.KeyType = "OKP"
+			jwk.Curve = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set.Keys = append(set.Keys, jwk)
+	}
+	sort.Slice(set.Keys, func(i, j int) bool { return set.Keys[i].KeyId < set.Keys[j].KeyId })
+
+	return set
+}
@@ -0,0 +1,170 @@
+package keyring
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	jwt "github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func pemKey(t *testing.T, blockType string, der []byte, err error) []byte {
+	if err != nil {
+		t.Fatal(err)
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+func newEd25519Key(t *testing.T, id string) (Key, []byte) {
+	pub, priv, _ := ed25519.GenerateKey(rand.Reader)
+
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	key, err := ParseKey(id, pemKey(t, "PRIVATE KEY", der, err))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pubDer, err := x509.MarshalPKIXPublicKey(pub)
+	return key, pemKey(t, "PUBLIC KEY", pubDer, err)
+}
+
+func claims() jwt.RegisteredClaims {
+	return jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute)), Subject: "1"}
+}
+
+func TestKeyRing_SignParse(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	rsaPEM := pemKey(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaKey), nil)
+
+	edKey, _ := newEd25519Key(t, "ed")
+
+	tt := []struct {
+		name   string
+		key    func() (Key, error)
+		method string
+	}{
+		{
+			name:   "RS256",
+			key:    func() (Key, error) { return ParseKey("rsa", rsaPEM) },
+			method: "RS256",
+		},
+		{
+			name:   "EdDSA",
+			key:    func() (Key, error) { return edKey, nil },
+			method: "EdDSA",
+		},
+		{
+			name:   "HS256",
+			key:    func() (Key, error) { return NewHMACKey("hmac", []byte("secret")), nil },
+			method: "HS256",
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := tc.key()
+			assert.NoError(t, err)
+
+			ring, err := NewKeyRing(key.Id, key)
+			assert.NoError(t, err)
+
+			signed, err := ring.Sign(claims())
+			assert.NoError(t, err)
+
+			token, err := jwt.ParseWithClaims(signed, &jwt.RegisteredClaims{}, ring.Keyfunc)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.method, token.Method.Alg())
+			assert.Equal(t, key.Id, token.Header["kid"])
+		})
+	}
+}
+
+func TestKeyRing_Rotation(t *testing.T) {
+	oldKey, oldPublicPEM := newEd25519Key(t, "old")
+	newKey, _ := newEd25519Key(t, "new")
+
+	oldRing, _ := NewKeyRing("old", oldKey)
+	oldToken, err := oldRing.Sign(claims())
+	assert.NoError(t, err)
+
+	// После ротации у старого ключа остаётся только открытая часть
+	oldPublic, err := ParseKey("old", oldPublicPEM)
+	assert.NoError(t, err)
+
+	ring, err := NewKeyRing("new", newKey, oldPublic)
+	assert.NoError(t, err)
+
+	_, err = jwt.Parse(oldToken, ring.Keyfunc)
+	assert.NoError(t, err)
+
+	newToken, _ := ring.Sign(claims())
+	token, err := jwt.Parse(newToken, ring.Keyfunc)
+	assert.NoError(t, err)
+	assert.Equal(t, "new", token.Header["kid"])
+
+	_, err = NewKeyRing("old", newKey, oldPublic)
+	assert.ErrorIs(t, err, ErrNoSigningKey)
+
+	_, err = NewKeyRing("missing", newKey)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+
+	// Токен с ключом, удалённым из кольца, не принимается
+	rotated, _ := NewKeyRing("new", newKey)
+	_, err = jwt.Parse(oldToken, rotated.Keyfunc)
+	assert.ErrorIs(t, err, ErrUnknownKey)
+}
+
+func TestKeyRing_AlgorithmConfusion(t *testing.T) {
+	key, publicPEM := newEd25519Key(t, "ed")
+	ring, _ := NewKeyRing("ed", key)
+
+	// Токен HS256, подписанный открытым ключом как секретом, не должен пройти проверку
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, claims())
+	forged.Header["kid"] = "ed"
+	signed, _ := forged.SignedString(publicPEM)
+
+	_, err := jwt.Parse(signed, ring.Keyfunc)
+	assert.Error(t, err)
+}
+
+func TestKeyRing_JWKS(t *testing.T) {
+	rsaKey, _ := rsa.GenerateKey(rand.Reader, 2048)
+	der, err := x509.MarshalPKCS8PrivateKey(rsaKey)
+	rsaPEM := pemKey(t, "PRIVATE KEY", der, err)
+
+	_, edPublicPEM := newEd25519Key(t, "ed")
+
+	dir := t.TempDir()
+	_ = os.WriteFile(filepath.Join(dir, "rsa.pem"), rsaPEM, 0600)
+	_ = os.WriteFile(filepath.Join(dir, "ed.pem"), edPublicPEM, 0600)
+
+	ring, err := Load("rsa", map[string]string{
+		"rsa": filepath.Join(dir, "rsa.pem"),
+		"ed":  filepath.Join(dir, "ed.pem"),
+	})
+	assert.NoError(t, err)
+
+	set := ring.JWKS()
+	assert.Len(t, set.Keys, 2)
+
+	assert.Equal(t, "ed", set.Keys[0].KeyId)
+	assert.Equal(t, "OKP", set.Keys[0].KeyType)
+	assert.Equal(t, "Ed25519", set.Keys[0].Curve)
+	assert.Equal(t, "EdDSA", set.Keys[0].Algorithm)
+	assert.NotEmpty(t, set.Keys[0].X)
+
+	assert.Equal(t, "rsa", set.Keys[1].KeyId)
+	assert.Equal(t, "RSA", set.Keys[1].KeyType)
+	assert.Equal(t, "RS256", set.Keys[1].Algorithm)
+	assert.Equal(t, "AQAB", set.Keys[1].E)
+
+	hmacRing, _ := NewKeyRing("hmac", NewHMACKey("hmac", []byte("secret")))
+	assert.Empty(t, hmacRing.JWKS().Keys)
+}
@@ -0,0 +1,77 @@
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/sirupsen/logrus"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer доставляет письма пользователям.
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Bytes формирует письмо в формате RFC 5322 с текстовым телом в UTF-8.
+func (m Message) Bytes(from string, date time.Time) []byte {
+	var b bytes.Buffer
+
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", m.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", m.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n")
+	b.WriteString("\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(m.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return b.Bytes()
+}
+
+// LogMailer не отправляет письма, а пишет их в лог. Подходит для локальной разработки.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(msg Message) error {
+	logrus.WithFields(logrus.Fields{
+		"to":      msg.To,
+		"subject": msg.Subject,
+	}).Info(msg.Body)
+	return nil
+}
+
+// FileMailer сохраняет каждое письмо в отдельный .eml файл в каталоге dir.
+type FileMailer struct {
+	dir  string
+	from string
+	seq  uint64
+}
+
+func NewFileMailer(dir, from string) (*FileMailer, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileMailer{dir: dir, from: from}, nil
+}
+
+func (m *FileMailer) Send(msg Message) error {
+	now := time.Now()
+	name := fmt.Sprintf("%s-%d.eml", now.Format("20060102T150405.000000000"), atomic.AddUint64(&m.seq, 1))
+
+	return os.WriteFile(filepath.Join(m.dir, name), msg.Bytes(m.from, now), 0o600)
+}
@@ -0,0 +1,43 @@
+package mail
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessage_Bytes(t *testing.T) {
+	msg := Message{To: "user@example.com", Subject: "Сброс пароля", Body: "line 1\nline 2"}
+	date := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	got := string(msg.Bytes("Todo App <no-reply@example.com>", date))
+
+	assert.True(t, strings.HasPrefix(got, "From: Todo App <no-reply@example.com>\r\nTo: user@example.com\r\n"))
+	assert.Contains(t, got, "Subject: =?utf-8?q?")
+	assert.Contains(t, got, "Date: Mon, 01 May 2023 12:00:00 +0000\r\n")
+	assert.True(t, strings.HasSuffix(got, "\r\n\r\nline 1\r\nline 2"))
+}
+
+func TestFileMailer_Send(t *testing.T) {
+	dir := t.TempDir()
+	m, err := NewFileMailer(dir, "no-reply@example.com")
+	assert.NoError(t, err)
+
+	assert.NoError(t, m.Send(Message{To: "a@example.com", Subject: "first", Body: "1"}))
+	assert.NoError(t, m.Send(Message{To: "b@example.com", Subject: "second", Body: "2"}))
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	assert.Len(t, files, 2)
+
+	data, _ := os.ReadFile(files[0])
+	assert.Contains(t, string(data), "To: a@example.com\r\n")
+}
+
+func TestEnvelopeAddress(t *testing.T) {
+	assert.Equal(t, "no-reply@example.com", envelopeAddress("Todo App <no-reply@example.com>"))
+	assert.Equal(t, "no-reply@example.com", envelopeAddress("no-reply@example.com"))
+}
@@ -0,0 +1,44 @@
+package mail
+
+import (
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// SMTPMailer отправляет письма через SMTP-сервер. Если сервер поддерживает STARTTLS, соединение шифруется.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+func NewSMTPMailer(cfg SMTPConfig) *SMTPMailer {
+	m := &SMTPMailer{addr: net.JoinHostPort(cfg.Host, cfg.Port), from: cfg.From}
+	if cfg.Username != "" {
+		m.auth = smtp.PlainAuth("", cfg.Username, cfg.Password, cfg.Host)
+	}
+	return m
+}
+
+func (m *SMTPMailer) Send(msg Message) error {
+	return smtp.SendMail(m.addr, m.auth, envelopeAddress(m.from), []string{msg.To}, msg.Bytes(m.from, time.Now()))
+}
+
+// envelopeAddress извлекает адрес из строки вида "Todo App <no-reply@example.com>".
+func envelopeAddress(from string) string {
+	addr, err := mail.ParseAddress(from)
+	if err != nil {
+		return from
+	}
+	return addr.Address
+}
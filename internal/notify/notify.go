@@ -0,0 +1,204 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/mail"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrUndeliverable означает, что повторная попытка доставки бессмысленна: нет адреса, адрес отвергнут и т.п.
+var ErrUndeliverable = errors.New("notification is undeliverable")
+
+// Delivery - уведомление и адреса получателя во внешних каналах.
+type Delivery struct {
+	Notification entity.Notification
+	Email        string
+	WebhookURL   string
+}
+
+// Notifier доставляет уведомление по одному каналу. Доставка должна быть идемпотентной по Notification.DedupKey,
+// потому что после сбоя до сохранения результата то же уведомление будет доставлено ещё раз.
+type Notifier interface {
+	Notify(ctx context.Context, delivery Delivery) error
+}
+
+// InboxStore сохраняет уведомления во входящих пользователя.
+type InboxStore interface {
+	Create(notification entity.Notification) (int, error)
+}
+
+// InboxNotifier записывает уведомление во входящие в приложении.
+type InboxNotifier struct {
+	store InboxStore
+}
+
+func NewInboxNotifier(store InboxStore) *InboxNotifier {
+	return &InboxNotifier{store: store}
+}
+
+func (n *InboxNotifier) Notify(_ context.Context, delivery Delivery) error {
+	_, err := n.store.Create(delivery.Notification)
+	return err
+}
+
+// EmailNotifier отправляет уведомление письмом на подтверждённый адрес пользователя.
+type EmailNotifier struct {
+	mailer mail.Mailer
+	appURL string
+}
+
+func NewEmailNotifier(mailer mail.Mailer, appURL string) *EmailNotifier {
+	return &EmailNotifier{mailer: mailer, appURL: strings.TrimSuffix(appURL, "/")}
+}
+
+func (n *EmailNotifier) Notify(_ context.Context, delivery Delivery) error {
+	if delivery.Email == "" {
+		return fmt.Errorf("%w: user has no verified email", ErrUndeliverable)
+	}
+
+	notification := delivery.Notification
+	body := notification.Title + "\n"
+	if notification.Body != "" {
+		body += notification.Body + "\n"
+	}
+	if notification.ListId != nil {
+		body += fmt.Sprintf("\n%s/lists/%d\n", n.appURL, *notification.ListId)
+	}
+
+	return n.mailer.Send(mail.Message{
+		To:      delivery.Email,
+		Subject: "Reminder: " + notification.Title,
+		Body:    body,
+	})
+}
+
+type webhookPayload struct {
+	Type      string    `json:"type"`
+	Title     string    `json:"title"`
+	Body      string    `json:"body"`
+	ListId    *int      `json:"list_id,omitempty"`
+	ItemId    *int      `json:"item_id,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// WebhookNotifier отправляет уведомление POST-запросом с JSON на адрес, указанный пользователем.
+// Заголовок Idempotency-Key позволяет получателю отбросить повторную доставку.
+type WebhookNotifier struct {
+	client *http.Client
+}
+
+func NewWebhookNotifier(client *http.Client) *WebhookNotifier {
+	return &WebhookNotifier{client: client}
+}
+
+// nonPublicNetworks - адреса, которые не встречаются в интернете и помимо IsPrivate, IsLoopback и т.п.
+var nonPublicNetworks = func() []*net.IPNet {
+	var networks []*net.IPNet
+	for _, cidr := range []string{"0.0.0.0/8", "100.64.0.0/10", "192.0.0.0/24", "198.18.0.0/15", "240.0.0.0/4"} {
+		_, network, _ := net.ParseCIDR(cidr)
+		networks = append(networks, network)
+	}
+	return networks
+}()
+
+// NewWebhookClient возвращает клиент для вебхуков, который соединяется только с публичными адресами.
+// Адрес проверяется после разрешения имени, поэтому запрос не попадёт во внутреннюю сеть и через DNS.
+// Перенаправления не выполняются: ответ 3xx считается отказом получателя.
+func NewWebhookClient(timeout time.Duration) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !publicIP(ip) {
+				return fmt.Errorf("%w: webhook address %s is not public", ErrUndeliverable, host)
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+func publicIP(ip net.IP) bool {
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() || ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() || ip.IsInterfaceLocalMulticast() || ip.IsMulticast() {
+		return false
+	}
+	for _, network := range nonPublicNetworks {
+		if network.Contains(ip) {
+			return false
+		}
+	}
+	return true
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, delivery Delivery) error {
+	if delivery.WebhookURL == "" {
+		return fmt.Errorf("%w: webhook url is empty", ErrUndeliverable)
+	}
+
+	notification := delivery.Notification
+	payload, err := json.Marshal(webhookPayload{
+		Type:      notification.Type,
+		Title:     notification.Title,
+		Body:      notification.Body,
+		ListId:    notification.ListId,
+		ItemId:    notification.ItemId,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.WebhookURL, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrUndeliverable, err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if notification.DedupKey != "" {
+		req.Header.Set("Idempotency-Key", notification.DedupKey)
+	}
+
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return nil
+	case resp.StatusCode >= 300 && resp.StatusCode < 400:
+		return fmt.Errorf("%w: webhook redirects are not followed, status %d", ErrUndeliverable, resp.StatusCode)
+	case resp.StatusCode >= 400 && resp.StatusCode < 500 &&
+		resp.StatusCode != http.StatusRequestTimeout && resp.StatusCode != http.StatusTooManyRequests:
+		return fmt.Errorf("%w: webhook responded with status %d", ErrUndeliverable, resp.StatusCode)
+	default:
+		return fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+}
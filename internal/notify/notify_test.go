@@ -0,0 +1,178 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/mail"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeMailer struct {
+	sent []mail.Message
+}
+
+func (m *fakeMailer) Send(msg mail.Message) error {
+	m.sent = append(m.sent, msg)
+	return nil
+}
+
+type fakeStore struct {
+	created []entity.Notification
+}
+
+func (s *fakeStore) Create(notification entity.Notification) (int, error) {
+	s.created = append(s.created, notification)
+	return len(s.created), nil
+}
+
+func testDelivery() Delivery {
+	listId, itemId := 3, 7
+	return Delivery{
+		Notification: entity.Notification{
+			UserId:   1,
+			Type:     entity.NotificationReminder,
+			Title:    "Pay rent",
+			Body:     "Due 2024-05-03",
+			ListId:   &listId,
+			ItemId:   &itemId,
+			DedupKey: "reminder:1:1714694400",
+		},
+		Email: "user@example.com",
+	}
+}
+
+func TestInboxNotifier_Notify(t *testing.T) {
+	store := &fakeStore{}
+	n := NewInboxNotifier(store)
+
+	assert.NoError(t, n.Notify(context.Background(), testDelivery()))
+	assert.Equal(t, []entity.Notification{testDelivery().Notification}, store.created)
+}
+
+func TestEmailNotifier_Notify(t *testing.T) {
+	mailer := &fakeMailer{}
+	n := NewEmailNotifier(mailer, "http://localhost:3000/")
+
+	assert.NoError(t, n.Notify(context.Background(), testDelivery()))
+	assert.Equal(t, []mail.Message{{
+		To:      "user@example.com",
+		Subject: "Reminder: Pay rent",
+		Body:    "Pay rent\nDue 2024-05-03\n\nhttp://localhost:3000/lists/3\n",
+	}}, mailer.sent)
+
+	delivery := testDelivery()
+	delivery.Email = ""
+	assert.ErrorIs(t, n.Notify(context.Background(), delivery), ErrUndeliverable)
+	assert.Len(t, mailer.sent, 1)
+}
+
+func TestWebhookNotifier_Notify(t *testing.T) {
+	tt := []struct {
+		name          string
+		status        int
+		wantErr       bool
+		undeliverable bool
+	}{
+		{name: "OK", status: http.StatusNoContent},
+		{name: "Server error", status: http.StatusBadGateway, wantErr: true},
+		{name: "Too many requests", status: http.StatusTooManyRequests, wantErr: true},
+		{name: "Gone", status: http.StatusGone, wantErr: true, undeliverable: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var (
+				key     string
+				payload webhookPayload
+			)
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				key = r.Header.Get("Idempotency-Key")
+				_ = json.NewDecoder(r.Body).Decode(&payload)
+				w.WriteHeader(tc.status)
+			}))
+			defer srv.Close()
+
+			delivery := testDelivery()
+			delivery.WebhookURL = srv.URL
+
+			err := NewWebhookNotifier(srv.Client()).Notify(context.Background(), delivery)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.undeliverable, errors.Is(err, ErrUndeliverable))
+			assert.Equal(t, "reminder:1:1714694400", key)
+			assert.Equal(t, "Pay rent", payload.Title)
+			assert.Equal(t, 7, *payload.ItemId)
+		})
+	}
+}
+
+func TestWebhookClient_RejectsNonPublicAddresses(t *testing.T) {
+	called := false
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer srv.Close()
+
+	notifier := NewWebhookNotifier(NewWebhookClient(time.Second))
+	for _, url := range []string{srv.URL, "http://127.0.0.1/hook", "http://localhost:" + strconv.Itoa(srv.Listener.Addr().(*net.TCPAddr).Port)} {
+		delivery := testDelivery()
+		delivery.WebhookURL = url
+
+		err := notifier.Notify(context.Background(), delivery)
+		assert.ErrorIs(t, err, ErrUndeliverable, url)
+	}
+	assert.False(t, called)
+}
+
+func TestWebhookClient_DoesNotFollowRedirects(t *testing.T) {
+	called := false
+	target := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer target.Close()
+	srv := httptest.NewServer(http.RedirectHandler(target.URL, http.StatusTemporaryRedirect))
+	defer srv.Close()
+
+	// Тестовые серверы слушают loopback, поэтому проверяется только политика перенаправлений
+	client := NewWebhookClient(time.Second)
+	client.Transport = srv.Client().Transport
+
+	delivery := testDelivery()
+	delivery.WebhookURL = srv.URL
+
+	err := NewWebhookNotifier(client).Notify(context.Background(), delivery)
+	assert.ErrorIs(t, err, ErrUndeliverable)
+	assert.False(t, called)
+}
+
+func TestPublicIP(t *testing.T) {
+	for ip, want := range map[string]bool{
+		"93.184.216.34":    true,
+		"2606:4700::1111":  true,
+		"127.0.0.1":        false,
+		"10.1.2.3":         false,
+		"172.16.0.1":       false,
+		"192.168.1.1":      false,
+		"169.254.169.254":  false,
+		"100.64.0.1":       false,
+		"0.0.0.0":          false,
+		"::1":              false,
+		"fe80::1":          false,
+		"fd00::1":          false,
+		"::ffff:127.0.0.1": false,
+	} {
+		assert.Equal(t, want, publicIP(net.ParseIP(ip)), ip)
+	}
+}
@@ -0,0 +1,109 @@
+package oidc_test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/oidc"
+	"github.com/IncubusX/go-todo-app/internal/oidc/oidctest"
+	"github.com/stretchr/testify/assert"
+)
+
+const redirectURL = "http://localhost:8000/auth/oidc/stub/callback"
+
+var testUser = oidctest.User{
+	Subject:           "248289761001",
+	Email:             "jane@example.com",
+	Name:              "Jane Doe",
+	PreferredUsername: "jane",
+}
+
+func newTestProvider(t *testing.T) (*oidc.Provider, *oidctest.Issuer) {
+	issuer, err := oidctest.NewIssuer("todo-app", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(issuer.Close)
+
+	return oidc.NewProvider(oidc.Config{
+		Name:         "stub",
+		Issuer:       issuer.URL,
+		ClientId:     "todo-app",
+		ClientSecret: "secret",
+		RedirectURL:  redirectURL,
+	}, issuer.Client()), issuer
+}
+
+func TestProvider_AuthCodeURL(t *testing.T) {
+	provider, issuer := newTestProvider(t)
+
+	authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+	assert.NoError(t, err)
+
+	u, _ := url.Parse(authURL)
+	assert.Equal(t, issuer.URL+"/authorize", u.Scheme+"://"+u.Host+u.Path)
+	assert.Equal(t, "code", u.Query().Get("response_type"))
+	assert.Equal(t, "todo-app", u.Query().Get("client_id"))
+	assert.Equal(t, redirectURL, u.Query().Get("redirect_uri"))
+	assert.Equal(t, "openid profile email", u.Query().Get("scope"))
+	assert.Equal(t, oidc.Challenge("verifier"), u.Query().Get("code_challenge"))
+	assert.Equal(t, "S256", u.Query().Get("code_challenge_method"))
+}
+
+func TestProvider_Exchange(t *testing.T) {
+	tt := []struct {
+		name     string
+		verifier string
+		nonce    string
+		wantErr  error
+	}{
+		{
+			name:     "Ok",
+			verifier: "verifier",
+			nonce:    "nonce",
+		},
+		{
+			name:     "Wrong verifier",
+			verifier: "other",
+			nonce:    "nonce",
+			wantErr:  oidc.ErrExchangeFailed,
+		},
+		{
+			name:     "Wrong nonce",
+			verifier: "verifier",
+			nonce:    "other",
+			wantErr:  oidc.ErrInvalidIDToken,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			provider, issuer := newTestProvider(t)
+
+			authURL, err := provider.AuthCodeURL(context.Background(), "state", "nonce", "verifier")
+			assert.NoError(t, err)
+
+			code, state, err := issuer.Login(authURL, testUser)
+			assert.NoError(t, err)
+			assert.Equal(t, "state", state)
+
+			claims, err := provider.Exchange(context.Background(), code, tc.verifier, tc.nonce)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, oidc.Claims{
+				Subject:           testUser.Subject,
+				Email:             testUser.Email,
+				Name:              testUser.Name,
+				PreferredUsername: testUser.PreferredUsername,
+			}, claims)
+
+			// Код авторизации одноразовый
+			_, err = provider.Exchange(context.Background(), code, tc.verifier, tc.nonce)
+			assert.ErrorIs(t, err, oidc.ErrExchangeFailed)
+		})
+	}
+}
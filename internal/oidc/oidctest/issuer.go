@@ -0,0 +1,169 @@
+// Package oidctest содержит заглушку OpenID Connect провайдера для тестов.
+package oidctest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type User struct {
+	Subject           string
+	Email             string
+	Name              string
+	PreferredUsername string
+}
+
+type grant struct {
+	challenge   string
+	nonce       string
+	redirectURI string
+	user        User
+}
+
+// Issuer - провайдер на httptest.Server: discovery, JWKS и token endpoint с проверкой PKCE.
+// Страница входа не нужна, вход пользователя эмулирует Login.
+type Issuer struct {
+	*httptest.Server
+	ClientId     string
+	ClientSecret string
+
+	keys   *keyring.KeyRing
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+func NewIssuer(clientId, clientSecret string) (*Issuer, error) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	key, err := keyring.ParseKey("stub", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		return nil, err
+	}
+	keys, err := keyring.NewKeyRing("stub", key)
+	if err != nil {
+		return nil, err
+	}
+
+	i := &Issuer{ClientId: clientId, ClientSecret: clientSecret, keys: keys, grants: make(map[string]grant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", i.discovery)
+	mux.HandleFunc("/jwks", i.jwks)
+	mux.HandleFunc("/token", i.token)
+	i.Server = httptest.NewServer(mux)
+
+	return i, nil
+}
+
+// Login эмулирует вход пользователя на странице провайдера: проверяет параметры запроса авторизации
+// и возвращает code и state, с которыми браузер был бы перенаправлен на redirect_uri.
+func (i *Issuer) Login(authURL string, user User) (string, string, error) {
+	u, err := url.Parse(authURL)
+	if err != nil {
+		return "", "", err
+	}
+	q := u.Query()
+
+	if q.Get("response_type") != "code" || q.Get("client_id") != i.ClientId {
+		return "", "", errors.New("invalid authorization request")
+	}
+	if q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		return "", "", errors.New("pkce is required")
+	}
+
+	code, err := oidc.NewVerifier()
+	if err != nil {
+		return "", "", err
+	}
+
+	i.mu.Lock()
+	i.grants[code] = grant{
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		redirectURI: q.Get("redirect_uri"),
+		user:        user,
+	}
+	i.mu.Unlock()
+
+	return code, q.Get("state"), nil
+}
+
+func (i *Issuer) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 i.URL,
+		"authorization_endpoint": i.URL + "/authorize",
+		"token_endpoint":         i.URL + "/token",
+		"jwks_uri":               i.URL + "/jwks",
+	})
+}
+
+func (i *Issuer) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, i.keys.JWKS())
+}
+
+func (i *Issuer) token(w http.ResponseWriter, r *http.Request) {
+	clientId, clientSecret, _ := r.BasicAuth()
+	if clientId != i.ClientId || clientSecret != i.ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostFormValue("code")
+	i.mu.Lock()
+	g, ok := i.grants[code]
+	delete(i.grants, code)
+	i.mu.Unlock()
+
+	if r.PostFormValue("grant_type") != "authorization_code" || !ok ||
+		r.PostFormValue("redirect_uri") != g.redirectURI ||
+		oidc.Challenge(r.PostFormValue("code_verifier")) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	idToken, err := i.keys.Sign(jwt.MapClaims{
+		"iss":                i.URL,
+		"aud":                i.ClientId,
+		"sub":                g.user.Subject,
+		"exp":                time.Now().Add(time.Minute).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              g.nonce,
+		"email":              g.user.Email,
+		"name":               g.user.Name,
+		"preferred_username": g.user.PreferredUsername,
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "stub-access-token",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
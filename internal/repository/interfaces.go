@@ -1,13 +1,73 @@
 package repository
 
-import "github.com/IncubusX/go-todo-app/internal/entity"
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"time"
+)
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
 
 type (
 	Authorization interface {
 		CreateUser(user entity.User) (int, error)
-		GetUser(username, password string) (entity.User, error)
+		GetUser(username string) (entity.User, error)
+		GetUserById(userId int) (entity.User, error)
+		GetUserByEmail(email string) (entity.User, error)
+		SetEmailVerified(userId int, email string) error
+		UpdateUser(userId int, input entity.UpdateUserInput) error
+		UpdatePasswordHash(userId int, passwordHash string) error
+		DeleteUser(userId int) error
+	}
+
+	Session interface {
+		Create(session entity.Session, refreshToken entity.RefreshToken) (int, error)
+		GetById(sessionId int) (entity.Session, error)
+		GetAll(userId int) ([]entity.Session, error)
+		Touch(sessionId int) error
+		GetRefreshToken(tokenHash string) (entity.RefreshToken, error)
+		RotateRefreshToken(oldTokenId int, newToken entity.RefreshToken) error
+		Revoke(sessionId int) error
+		RevokeAll(userId int) error
+	}
+
+	PersonalAccessToken interface {
+		Create(token entity.PersonalAccessToken) (int, error)
+		GetAll(userId int) ([]entity.PersonalAccessToken, error)
+		GetByHash(tokenHash string) (entity.PersonalAccessToken, error)
+		Touch(tokenId int) error
+		Revoke(userId, tokenId int) error
+		RevokeAll(userId int) error
+	}
+
+	TwoFactor interface {
+		Get(userId int) (entity.TwoFactor, error)
+		SetPending(userId int, secret string) error
+		Enable(userId int, recoveryCodeHashes []string) error
+		Disable(userId int) error
+		UseStep(userId int, step int64) error
+		UseRecoveryCode(userId int, codeHash string) error
+	}
+
+	UserToken interface {
+		Create(token entity.UserToken) error
+		Consume(purpose, tokenHash string) (entity.UserToken, error)
+		RevokeAll(userId int, purpose string) error
+	}
+
+	ExternalIdentity interface {
+		GetUserId(provider, subject string) (int, error)
+		CreateWithUser(user entity.User, identity entity.ExternalIdentity) (int, error)
+	}
+
+	LoginAttempt interface {
+		Get(key string) (entity.LoginAttempt, error)
+		RegisterFailure(key string, now, resetBefore time.Time) (entity.LoginAttempt, error)
+		Lock(key string, until time.Time) error
+		Reset(key string) error
+	}
+
+	Audit interface {
+		Create(entry entity.AuditEntry) error
 	}
 
 	TodoList interface {
@@ -16,13 +76,106 @@ type (
 		GetById(userId, listId int) (entity.TodoList, error)
 		Update(userId, listId int, list entity.UpdateListInput) error
 		Delete(userId, listId int) error
+		GetRole(userId, listId int) (string, error)
+		Move(userId, listId int, move entity.MoveInput) error
+		Duplicate(userId, listId int, title string) (int, error)
+	}
+
+	ListTemplate interface {
+		CreateFromList(userId, listId int, title string) (int, error)
+		GetAll(userId int) ([]entity.ListTemplate, error)
+		GetById(userId, templateId int) (entity.ListTemplate, error)
+		Delete(userId, templateId int) error
+		Instantiate(userId int, template entity.ListTemplate) (int, error)
+	}
+
+	ListMember interface {
+		GetAll(listId int) ([]entity.ListMember, error)
+		UpdateRole(listId, userId int, role string) error
+		Remove(listId, userId int) error
+		CountOwners(listId int) (int, error)
+	}
+
+	ListInvitation interface {
+		Create(invitation entity.ListInvitation) (int, error)
+		GetAll(userId int) ([]entity.ListInvitation, error)
+		Accept(userId, invitationId int) (entity.ListInvitation, error)
+		Decline(userId, invitationId int) error
+	}
+
+	InviteLink interface {
+		Create(link entity.InviteLink) (int, error)
+		Delete(listId, linkId int) error
+		Redeem(linkId, userId int) (entity.InviteLink, error)
+	}
+
+	PublicLink interface {
+		Save(link entity.PublicLink) (entity.PublicLink, error)
+		GetByHash(tokenHash string) (entity.PublicLink, error)
+		Delete(listId int) error
 	}
 
 	TodoItem interface {
 		Create(listId int, input entity.TodoItem) (int, error)
-		GetAll(userId, listId int) ([]entity.TodoItem, error)
+		GetAll(userId, listId int, filter entity.ItemFilter) ([]entity.TodoItem, error)
+		Search(userId int, filter entity.ItemFilter) ([]entity.TodoItem, error)
 		GetById(userId, itemId int) (entity.TodoItem, error)
 		Update(userId, itemId int, input entity.UpdateItemInput) error
-		Delete(userId, itemId int) error
+		Delete(userId, itemId int, keepChildren bool) error
+		GetRole(userId, itemId int) (string, error)
+		GetDue(userId int, due entity.DueRange) ([]entity.TodoItem, error)
+		GetAssigned(userId int) ([]entity.TodoItem, error)
+		GetSubtreeIds(itemId int) ([]int, error)
+		CompleteSubtree(itemId int) error
+		CompleteParents(itemId int) error
+		Reschedule(itemId int, occurrence entity.Occurrence) error
+		Move(itemId int, move entity.MoveInput) error
+		MoveToList(itemId, listId int) error
+		Copy(itemId, listId int, parentId *int) (int, error)
+	}
+
+	Label interface {
+		Create(userId int, label entity.Label) (int, error)
+		GetAll(userId int) ([]entity.Label, error)
+		GetById(userId, labelId int) (entity.Label, error)
+		Update(userId, labelId int, input entity.UpdateLabelInput) error
+		Delete(userId, labelId int) error
+		Attach(itemId, labelId int) error
+		Detach(itemId, labelId int) error
+	}
+
+	Reminder interface {
+		Create(reminder entity.Reminder) (int, error)
+		GetAll(userId, itemId int) ([]entity.Reminder, error)
+		Delete(userId, itemId, reminderId int) error
+		ClaimDue(now time.Time, deliver func(entity.DueReminder) entity.DeliveryResult) (bool, error)
+	}
+
+	Notification interface {
+		Create(notification entity.Notification) (int, error)
+		CreateForMembers(listId int, notification entity.Notification) error
+		GetAll(userId int, filter entity.NotificationFilter) ([]entity.Notification, error)
+		CountUnread(userId int) (map[string]int, error)
+		MarkRead(userId, notificationId int) error
+		MarkAllRead(userId int) error
+		GetMuted(userId int) ([]string, error)
+		SetMuted(userId int, types []string) error
+	}
+
+	Comment interface {
+		Create(comment entity.Comment) (int, error)
+		GetAll(itemId int) ([]entity.Comment, error)
+		GetById(itemId, commentId int) (entity.Comment, error)
+		Update(userId, commentId int, body string) error
+		Delete(userId, commentId int) error
+	}
+
+	Attachment interface {
+		Create(attachment entity.Attachment) (int, error)
+		GetAll(itemId int) ([]entity.Attachment, error)
+		GetById(itemId, attachmentId int) (entity.Attachment, error)
+		Delete(itemId, attachmentId int) error
+		GetOrphanBlobs(limit int) ([]string, error)
+		DeleteOrphanBlob(key string) error
 	}
 )
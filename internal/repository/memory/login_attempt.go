@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"sync"
+	"time"
+)
+
+// LoginAttempt хранит счётчики попыток входа в памяти процесса. Подходит для тестов
+// и для единственного экземпляра приложения: при перезапуске счётчики теряются.
+type LoginAttempt struct {
+	mu       sync.Mutex
+	attempts map[string]entity.LoginAttempt
+}
+
+func NewLoginAttempt() *LoginAttempt {
+	return &LoginAttempt{attempts: make(map[string]entity.LoginAttempt)}
+}
+
+func (r *LoginAttempt) Get(key string) (entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return entity.LoginAttempt{Key: key}, nil
+	}
+	return attempt, nil
+}
+
+func (r *LoginAttempt) RegisterFailure(key string, now, resetBefore time.Time) (entity.LoginAttempt, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok || attempt.LastFailureAt == nil || attempt.LastFailureAt.Before(resetBefore) {
+		attempt.Key = key
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = &now
+
+	r.attempts[key] = attempt
+	return attempt, nil
+}
+
+func (r *LoginAttempt) Lock(key string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.LockedUntil = &until
+		r.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (r *LoginAttempt) Reset(key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
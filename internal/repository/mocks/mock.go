@@ -6,6 +6,7 @@ package mock_repository
 
 import (
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/IncubusX/go-todo-app/internal/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -49,209 +50,2039 @@ func (mr *MockAuthorizationMockRecorder) CreateUser(user interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
 }
 
+// DeleteUser mocks base method.
+func (m *MockAuthorization) DeleteUser(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAuthorizationMockRecorder) DeleteUser(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthorization)(nil).DeleteUser), userId)
+}
+
 // GetUser mocks base method.
-func (m *MockAuthorization) GetUser(username, password string) (entity.User, error) {
+func (m *MockAuthorization) GetUser(username string) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUser", username)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUser indicates an expected call of GetUser.
+func (mr *MockAuthorizationMockRecorder) GetUser(username interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAuthorization)(nil).GetUser), username)
+}
+
+// GetUserByEmail mocks base method.
+func (m *MockAuthorization) GetUserByEmail(email string) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserByEmail", email)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserByEmail indicates an expected call of GetUserByEmail.
+func (mr *MockAuthorizationMockRecorder) GetUserByEmail(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserByEmail", reflect.TypeOf((*MockAuthorization)(nil).GetUserByEmail), email)
+}
+
+// GetUserById mocks base method.
+func (m *MockAuthorization) GetUserById(userId int) (entity.User, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserById", userId)
+	ret0, _ := ret[0].(entity.User)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserById indicates an expected call of GetUserById.
+func (mr *MockAuthorizationMockRecorder) GetUserById(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserById", reflect.TypeOf((*MockAuthorization)(nil).GetUserById), userId)
+}
+
+// SetEmailVerified mocks base method.
+func (m *MockAuthorization) SetEmailVerified(userId int, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEmailVerified", userId, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEmailVerified indicates an expected call of SetEmailVerified.
+func (mr *MockAuthorizationMockRecorder) SetEmailVerified(userId, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEmailVerified", reflect.TypeOf((*MockAuthorization)(nil).SetEmailVerified), userId, email)
+}
+
+// UpdatePasswordHash mocks base method.
+func (m *MockAuthorization) UpdatePasswordHash(userId int, passwordHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePasswordHash", userId, passwordHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePasswordHash indicates an expected call of UpdatePasswordHash.
+func (mr *MockAuthorizationMockRecorder) UpdatePasswordHash(userId, passwordHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePasswordHash", reflect.TypeOf((*MockAuthorization)(nil).UpdatePasswordHash), userId, passwordHash)
+}
+
+// UpdateUser mocks base method.
+func (m *MockAuthorization) UpdateUser(userId int, input entity.UpdateUserInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateUser", userId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateUser indicates an expected call of UpdateUser.
+func (mr *MockAuthorizationMockRecorder) UpdateUser(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateUser", reflect.TypeOf((*MockAuthorization)(nil).UpdateUser), userId, input)
+}
+
+// MockSession is a mock of Session interface.
+type MockSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionMockRecorder
+}
+
+// MockSessionMockRecorder is the mock recorder for MockSession.
+type MockSessionMockRecorder struct {
+	mock *MockSession
+}
+
+// NewMockSession creates a new mock instance.
+func NewMockSession(ctrl *gomock.Controller) *MockSession {
+	mock := &MockSession{ctrl: ctrl}
+	mock.recorder = &MockSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSession) EXPECT() *MockSessionMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockSession) Create(session entity.Session, refreshToken entity.RefreshToken) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", session, refreshToken)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockSessionMockRecorder) Create(session, refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockSession)(nil).Create), session, refreshToken)
+}
+
+// GetAll mocks base method.
+func (m *MockSession) GetAll(userId int) ([]entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSessionMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSession)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockSession) GetById(sessionId int) (entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", sessionId)
+	ret0, _ := ret[0].(entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockSessionMockRecorder) GetById(sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockSession)(nil).GetById), sessionId)
+}
+
+// GetRefreshToken mocks base method.
+func (m *MockSession) GetRefreshToken(tokenHash string) (entity.RefreshToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRefreshToken", tokenHash)
+	ret0, _ := ret[0].(entity.RefreshToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRefreshToken indicates an expected call of GetRefreshToken.
+func (mr *MockSessionMockRecorder) GetRefreshToken(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRefreshToken", reflect.TypeOf((*MockSession)(nil).GetRefreshToken), tokenHash)
+}
+
+// Revoke mocks base method.
+func (m *MockSession) Revoke(sessionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionMockRecorder) Revoke(sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSession)(nil).Revoke), sessionId)
+}
+
+// RevokeAll mocks base method.
+func (m *MockSession) RevokeAll(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockSessionMockRecorder) RevokeAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockSession)(nil).RevokeAll), userId)
+}
+
+// RotateRefreshToken mocks base method.
+func (m *MockSession) RotateRefreshToken(oldTokenId int, newToken entity.RefreshToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RotateRefreshToken", oldTokenId, newToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RotateRefreshToken indicates an expected call of RotateRefreshToken.
+func (mr *MockSessionMockRecorder) RotateRefreshToken(oldTokenId, newToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RotateRefreshToken", reflect.TypeOf((*MockSession)(nil).RotateRefreshToken), oldTokenId, newToken)
+}
+
+// Touch mocks base method.
+func (m *MockSession) Touch(sessionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockSessionMockRecorder) Touch(sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockSession)(nil).Touch), sessionId)
+}
+
+// MockPersonalAccessToken is a mock of PersonalAccessToken interface.
+type MockPersonalAccessToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenMockRecorder
+}
+
+// MockPersonalAccessTokenMockRecorder is the mock recorder for MockPersonalAccessToken.
+type MockPersonalAccessTokenMockRecorder struct {
+	mock *MockPersonalAccessToken
+}
+
+// NewMockPersonalAccessToken creates a new mock instance.
+func NewMockPersonalAccessToken(ctrl *gomock.Controller) *MockPersonalAccessToken {
+	mock := &MockPersonalAccessToken{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessToken) EXPECT() *MockPersonalAccessTokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPersonalAccessToken) Create(token entity.PersonalAccessToken) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPersonalAccessTokenMockRecorder) Create(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonalAccessToken)(nil).Create), token)
+}
+
+// GetAll mocks base method.
+func (m *MockPersonalAccessToken) GetAll(userId int) ([]entity.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPersonalAccessTokenMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPersonalAccessToken)(nil).GetAll), userId)
+}
+
+// GetByHash mocks base method.
+func (m *MockPersonalAccessToken) GetByHash(tokenHash string) (entity.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", tokenHash)
+	ret0, _ := ret[0].(entity.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockPersonalAccessTokenMockRecorder) GetByHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockPersonalAccessToken)(nil).GetByHash), tokenHash)
+}
+
+// Revoke mocks base method.
+func (m *MockPersonalAccessToken) Revoke(userId, tokenId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userId, tokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockPersonalAccessTokenMockRecorder) Revoke(userId, tokenId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockPersonalAccessToken)(nil).Revoke), userId, tokenId)
+}
+
+// RevokeAll mocks base method.
+func (m *MockPersonalAccessToken) RevokeAll(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockPersonalAccessTokenMockRecorder) RevokeAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockPersonalAccessToken)(nil).RevokeAll), userId)
+}
+
+// Touch mocks base method.
+func (m *MockPersonalAccessToken) Touch(tokenId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Touch", tokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Touch indicates an expected call of Touch.
+func (mr *MockPersonalAccessTokenMockRecorder) Touch(tokenId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Touch", reflect.TypeOf((*MockPersonalAccessToken)(nil).Touch), tokenId)
+}
+
+// MockTwoFactor is a mock of TwoFactor interface.
+type MockTwoFactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorMockRecorder
+}
+
+// MockTwoFactorMockRecorder is the mock recorder for MockTwoFactor.
+type MockTwoFactorMockRecorder struct {
+	mock *MockTwoFactor
+}
+
+// NewMockTwoFactor creates a new mock instance.
+func NewMockTwoFactor(ctrl *gomock.Controller) *MockTwoFactor {
+	mock := &MockTwoFactor{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactor) EXPECT() *MockTwoFactorMockRecorder {
+	return m.recorder
+}
+
+// Disable mocks base method.
+func (m *MockTwoFactor) Disable(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTwoFactorMockRecorder) Disable(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTwoFactor)(nil).Disable), userId)
+}
+
+// Enable mocks base method.
+func (m *MockTwoFactor) Enable(userId int, recoveryCodeHashes []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enable", userId, recoveryCodeHashes)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Enable indicates an expected call of Enable.
+func (mr *MockTwoFactorMockRecorder) Enable(userId, recoveryCodeHashes interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enable", reflect.TypeOf((*MockTwoFactor)(nil).Enable), userId, recoveryCodeHashes)
+}
+
+// Get mocks base method.
+func (m *MockTwoFactor) Get(userId int) (entity.TwoFactor, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", userId)
+	ret0, _ := ret[0].(entity.TwoFactor)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTwoFactorMockRecorder) Get(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTwoFactor)(nil).Get), userId)
+}
+
+// SetPending mocks base method.
+func (m *MockTwoFactor) SetPending(userId int, secret string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPending", userId, secret)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPending indicates an expected call of SetPending.
+func (mr *MockTwoFactorMockRecorder) SetPending(userId, secret interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPending", reflect.TypeOf((*MockTwoFactor)(nil).SetPending), userId, secret)
+}
+
+// UseRecoveryCode mocks base method.
+func (m *MockTwoFactor) UseRecoveryCode(userId int, codeHash string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseRecoveryCode", userId, codeHash)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseRecoveryCode indicates an expected call of UseRecoveryCode.
+func (mr *MockTwoFactorMockRecorder) UseRecoveryCode(userId, codeHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseRecoveryCode", reflect.TypeOf((*MockTwoFactor)(nil).UseRecoveryCode), userId, codeHash)
+}
+
+// UseStep mocks base method.
+func (m *MockTwoFactor) UseStep(userId int, step int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UseStep", userId, step)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UseStep indicates an expected call of UseStep.
+func (mr *MockTwoFactorMockRecorder) UseStep(userId, step interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UseStep", reflect.TypeOf((*MockTwoFactor)(nil).UseStep), userId, step)
+}
+
+// MockUserToken is a mock of UserToken interface.
+type MockUserToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserTokenMockRecorder
+}
+
+// MockUserTokenMockRecorder is the mock recorder for MockUserToken.
+type MockUserTokenMockRecorder struct {
+	mock *MockUserToken
+}
+
+// NewMockUserToken creates a new mock instance.
+func NewMockUserToken(ctrl *gomock.Controller) *MockUserToken {
+	mock := &MockUserToken{ctrl: ctrl}
+	mock.recorder = &MockUserTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserToken) EXPECT() *MockUserTokenMockRecorder {
+	return m.recorder
+}
+
+// Consume mocks base method.
+func (m *MockUserToken) Consume(purpose, tokenHash string) (entity.UserToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Consume", purpose, tokenHash)
+	ret0, _ := ret[0].(entity.UserToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Consume indicates an expected call of Consume.
+func (mr *MockUserTokenMockRecorder) Consume(purpose, tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Consume", reflect.TypeOf((*MockUserToken)(nil).Consume), purpose, tokenHash)
+}
+
+// Create mocks base method.
+func (m *MockUserToken) Create(token entity.UserToken) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockUserTokenMockRecorder) Create(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockUserToken)(nil).Create), token)
+}
+
+// RevokeAll mocks base method.
+func (m *MockUserToken) RevokeAll(userId int, purpose string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeAll", userId, purpose)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeAll indicates an expected call of RevokeAll.
+func (mr *MockUserTokenMockRecorder) RevokeAll(userId, purpose interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeAll", reflect.TypeOf((*MockUserToken)(nil).RevokeAll), userId, purpose)
+}
+
+// MockExternalIdentity is a mock of ExternalIdentity interface.
+type MockExternalIdentity struct {
+	ctrl     *gomock.Controller
+	recorder *MockExternalIdentityMockRecorder
+}
+
+// MockExternalIdentityMockRecorder is the mock recorder for MockExternalIdentity.
+type MockExternalIdentityMockRecorder struct {
+	mock *MockExternalIdentity
+}
+
+// NewMockExternalIdentity creates a new mock instance.
+func NewMockExternalIdentity(ctrl *gomock.Controller) *MockExternalIdentity {
+	mock := &MockExternalIdentity{ctrl: ctrl}
+	mock.recorder = &MockExternalIdentityMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExternalIdentity) EXPECT() *MockExternalIdentityMockRecorder {
+	return m.recorder
+}
+
+// CreateWithUser mocks base method.
+func (m *MockExternalIdentity) CreateWithUser(user entity.User, identity entity.ExternalIdentity) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateWithUser", user, identity)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateWithUser indicates an expected call of CreateWithUser.
+func (mr *MockExternalIdentityMockRecorder) CreateWithUser(user, identity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateWithUser", reflect.TypeOf((*MockExternalIdentity)(nil).CreateWithUser), user, identity)
+}
+
+// GetUserId mocks base method.
+func (m *MockExternalIdentity) GetUserId(provider, subject string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserId", provider, subject)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserId indicates an expected call of GetUserId.
+func (mr *MockExternalIdentityMockRecorder) GetUserId(provider, subject interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserId", reflect.TypeOf((*MockExternalIdentity)(nil).GetUserId), provider, subject)
+}
+
+// MockLoginAttempt is a mock of LoginAttempt interface.
+type MockLoginAttempt struct {
+	ctrl     *gomock.Controller
+	recorder *MockLoginAttemptMockRecorder
+}
+
+// MockLoginAttemptMockRecorder is the mock recorder for MockLoginAttempt.
+type MockLoginAttemptMockRecorder struct {
+	mock *MockLoginAttempt
+}
+
+// NewMockLoginAttempt creates a new mock instance.
+func NewMockLoginAttempt(ctrl *gomock.Controller) *MockLoginAttempt {
+	mock := &MockLoginAttempt{ctrl: ctrl}
+	mock.recorder = &MockLoginAttemptMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLoginAttempt) EXPECT() *MockLoginAttemptMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockLoginAttempt) Get(key string) (entity.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", key)
+	ret0, _ := ret[0].(entity.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockLoginAttemptMockRecorder) Get(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockLoginAttempt)(nil).Get), key)
+}
+
+// Lock mocks base method.
+func (m *MockLoginAttempt) Lock(key string, until time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Lock", key, until)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Lock indicates an expected call of Lock.
+func (mr *MockLoginAttemptMockRecorder) Lock(key, until interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lock", reflect.TypeOf((*MockLoginAttempt)(nil).Lock), key, until)
+}
+
+// RegisterFailure mocks base method.
+func (m *MockLoginAttempt) RegisterFailure(key string, now, resetBefore time.Time) (entity.LoginAttempt, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RegisterFailure", key, now, resetBefore)
+	ret0, _ := ret[0].(entity.LoginAttempt)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RegisterFailure indicates an expected call of RegisterFailure.
+func (mr *MockLoginAttemptMockRecorder) RegisterFailure(key, now, resetBefore interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RegisterFailure", reflect.TypeOf((*MockLoginAttempt)(nil).RegisterFailure), key, now, resetBefore)
+}
+
+// Reset mocks base method.
+func (m *MockLoginAttempt) Reset(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reset", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reset indicates an expected call of Reset.
+func (mr *MockLoginAttemptMockRecorder) Reset(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockLoginAttempt)(nil).Reset), key)
+}
+
+// MockAudit is a mock of Audit interface.
+type MockAudit struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditMockRecorder
+}
+
+// MockAuditMockRecorder is the mock recorder for MockAudit.
+type MockAuditMockRecorder struct {
+	mock *MockAudit
+}
+
+// NewMockAudit creates a new mock instance.
+func NewMockAudit(ctrl *gomock.Controller) *MockAudit {
+	mock := &MockAudit{ctrl: ctrl}
+	mock.recorder = &MockAuditMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAudit) EXPECT() *MockAuditMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAudit) Create(entry entity.AuditEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", entry)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAuditMockRecorder) Create(entry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAudit)(nil).Create), entry)
+}
+
+// MockTodoList is a mock of TodoList interface.
+type MockTodoList struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoListMockRecorder
+}
+
+// MockTodoListMockRecorder is the mock recorder for MockTodoList.
+type MockTodoListMockRecorder struct {
+	mock *MockTodoList
+}
+
+// NewMockTodoList creates a new mock instance.
+func NewMockTodoList(ctrl *gomock.Controller) *MockTodoList {
+	mock := &MockTodoList{ctrl: ctrl}
+	mock.recorder = &MockTodoListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTodoList) Create(userId int, input entity.TodoList) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTodoListMockRecorder) Create(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoList)(nil).Create), userId, input)
+}
+
+// Delete mocks base method.
+func (m *MockTodoList) Delete(userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTodoListMockRecorder) Delete(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, listId)
+}
+
+// Duplicate mocks base method.
+func (m *MockTodoList) Duplicate(userId, listId int, title string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", userId, listId, title)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockTodoListMockRecorder) Duplicate(userId, listId, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockTodoList)(nil).Duplicate), userId, listId, title)
+}
+
+// GetAll mocks base method.
+func (m *MockTodoList) GetAll(userId int) ([]entity.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockTodoList) GetById(userId, listId int) (entity.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, listId)
+	ret0, _ := ret[0].(entity.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTodoListMockRecorder) GetById(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), userId, listId)
+}
+
+// GetRole mocks base method.
+func (m *MockTodoList) GetRole(userId, listId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", userId, listId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockTodoListMockRecorder) GetRole(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockTodoList)(nil).GetRole), userId, listId)
+}
+
+// Move mocks base method.
+func (m *MockTodoList) Move(userId, listId int, move entity.MoveInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, listId, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoListMockRecorder) Move(userId, listId, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoList)(nil).Move), userId, listId, move)
+}
+
+// Update mocks base method.
+func (m *MockTodoList) Update(userId, listId int, list entity.UpdateListInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, listId, list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTodoListMockRecorder) Update(userId, listId, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, listId, list)
+}
+
+// MockListTemplate is a mock of ListTemplate interface.
+type MockListTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockListTemplateMockRecorder
+}
+
+// MockListTemplateMockRecorder is the mock recorder for MockListTemplate.
+type MockListTemplateMockRecorder struct {
+	mock *MockListTemplate
+}
+
+// NewMockListTemplate creates a new mock instance.
+func NewMockListTemplate(ctrl *gomock.Controller) *MockListTemplate {
+	mock := &MockListTemplate{ctrl: ctrl}
+	mock.recorder = &MockListTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListTemplate) EXPECT() *MockListTemplateMockRecorder {
+	return m.recorder
+}
+
+// CreateFromList mocks base method.
+func (m *MockListTemplate) CreateFromList(userId, listId int, title string) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFromList", userId, listId, title)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFromList indicates an expected call of CreateFromList.
+func (mr *MockListTemplateMockRecorder) CreateFromList(userId, listId, title interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFromList", reflect.TypeOf((*MockListTemplate)(nil).CreateFromList), userId, listId, title)
+}
+
+// Delete mocks base method.
+func (m *MockListTemplate) Delete(userId, templateId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, templateId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListTemplateMockRecorder) Delete(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListTemplate)(nil).Delete), userId, templateId)
+}
+
+// GetAll mocks base method.
+func (m *MockListTemplate) GetAll(userId int) ([]entity.ListTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.ListTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListTemplateMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListTemplate)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockListTemplate) GetById(userId, templateId int) (entity.ListTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, templateId)
+	ret0, _ := ret[0].(entity.ListTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockListTemplateMockRecorder) GetById(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockListTemplate)(nil).GetById), userId, templateId)
+}
+
+// Instantiate mocks base method.
+func (m *MockListTemplate) Instantiate(userId int, template entity.ListTemplate) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", userId, template)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockListTemplateMockRecorder) Instantiate(userId, template interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockListTemplate)(nil).Instantiate), userId, template)
+}
+
+// MockListMember is a mock of ListMember interface.
+type MockListMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMemberMockRecorder
+}
+
+// MockListMemberMockRecorder is the mock recorder for MockListMember.
+type MockListMemberMockRecorder struct {
+	mock *MockListMember
+}
+
+// NewMockListMember creates a new mock instance.
+func NewMockListMember(ctrl *gomock.Controller) *MockListMember {
+	mock := &MockListMember{ctrl: ctrl}
+	mock.recorder = &MockListMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListMember) EXPECT() *MockListMemberMockRecorder {
+	return m.recorder
+}
+
+// CountOwners mocks base method.
+func (m *MockListMember) CountOwners(listId int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountOwners", listId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountOwners indicates an expected call of CountOwners.
+func (mr *MockListMemberMockRecorder) CountOwners(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountOwners", reflect.TypeOf((*MockListMember)(nil).CountOwners), listId)
+}
+
+// GetAll mocks base method.
+func (m *MockListMember) GetAll(listId int) ([]entity.ListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", listId)
+	ret0, _ := ret[0].([]entity.ListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListMemberMockRecorder) GetAll(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListMember)(nil).GetAll), listId)
+}
+
+// Remove mocks base method.
+func (m *MockListMember) Remove(listId, userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", listId, userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockListMemberMockRecorder) Remove(listId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockListMember)(nil).Remove), listId, userId)
+}
+
+// UpdateRole mocks base method.
+func (m *MockListMember) UpdateRole(listId, userId int, role string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", listId, userId, role)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockListMemberMockRecorder) UpdateRole(listId, userId, role interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockListMember)(nil).UpdateRole), listId, userId, role)
+}
+
+// MockListInvitation is a mock of ListInvitation interface.
+type MockListInvitation struct {
+	ctrl     *gomock.Controller
+	recorder *MockListInvitationMockRecorder
+}
+
+// MockListInvitationMockRecorder is the mock recorder for MockListInvitation.
+type MockListInvitationMockRecorder struct {
+	mock *MockListInvitation
+}
+
+// NewMockListInvitation creates a new mock instance.
+func NewMockListInvitation(ctrl *gomock.Controller) *MockListInvitation {
+	mock := &MockListInvitation{ctrl: ctrl}
+	mock.recorder = &MockListInvitationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListInvitation) EXPECT() *MockListInvitationMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockListInvitation) Accept(userId, invitationId int) (entity.ListInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", userId, invitationId)
+	ret0, _ := ret[0].(entity.ListInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockListInvitationMockRecorder) Accept(userId, invitationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockListInvitation)(nil).Accept), userId, invitationId)
+}
+
+// Create mocks base method.
+func (m *MockListInvitation) Create(invitation entity.ListInvitation) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", invitation)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockListInvitationMockRecorder) Create(invitation interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockListInvitation)(nil).Create), invitation)
+}
+
+// Decline mocks base method.
+func (m *MockListInvitation) Decline(userId, invitationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", userId, invitationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockListInvitationMockRecorder) Decline(userId, invitationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockListInvitation)(nil).Decline), userId, invitationId)
+}
+
+// GetAll mocks base method.
+func (m *MockListInvitation) GetAll(userId int) ([]entity.ListInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.ListInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListInvitationMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListInvitation)(nil).GetAll), userId)
+}
+
+// MockInviteLink is a mock of InviteLink interface.
+type MockInviteLink struct {
+	ctrl     *gomock.Controller
+	recorder *MockInviteLinkMockRecorder
+}
+
+// MockInviteLinkMockRecorder is the mock recorder for MockInviteLink.
+type MockInviteLinkMockRecorder struct {
+	mock *MockInviteLink
+}
+
+// NewMockInviteLink creates a new mock instance.
+func NewMockInviteLink(ctrl *gomock.Controller) *MockInviteLink {
+	mock := &MockInviteLink{ctrl: ctrl}
+	mock.recorder = &MockInviteLinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInviteLink) EXPECT() *MockInviteLinkMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockInviteLink) Create(link entity.InviteLink) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", link)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockInviteLinkMockRecorder) Create(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockInviteLink)(nil).Create), link)
+}
+
+// Delete mocks base method.
+func (m *MockInviteLink) Delete(listId, linkId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", listId, linkId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockInviteLinkMockRecorder) Delete(listId, linkId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockInviteLink)(nil).Delete), listId, linkId)
+}
+
+// Redeem mocks base method.
+func (m *MockInviteLink) Redeem(linkId, userId int) (entity.InviteLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Redeem", linkId, userId)
+	ret0, _ := ret[0].(entity.InviteLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Redeem indicates an expected call of Redeem.
+func (mr *MockInviteLinkMockRecorder) Redeem(linkId, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Redeem", reflect.TypeOf((*MockInviteLink)(nil).Redeem), linkId, userId)
+}
+
+// MockPublicLink is a mock of PublicLink interface.
+type MockPublicLink struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicLinkMockRecorder
+}
+
+// MockPublicLinkMockRecorder is the mock recorder for MockPublicLink.
+type MockPublicLinkMockRecorder struct {
+	mock *MockPublicLink
+}
+
+// NewMockPublicLink creates a new mock instance.
+func NewMockPublicLink(ctrl *gomock.Controller) *MockPublicLink {
+	mock := &MockPublicLink{ctrl: ctrl}
+	mock.recorder = &MockPublicLinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicLink) EXPECT() *MockPublicLinkMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPublicLink) Delete(listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPublicLinkMockRecorder) Delete(listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPublicLink)(nil).Delete), listId)
+}
+
+// GetByHash mocks base method.
+func (m *MockPublicLink) GetByHash(tokenHash string) (entity.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByHash", tokenHash)
+	ret0, _ := ret[0].(entity.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByHash indicates an expected call of GetByHash.
+func (mr *MockPublicLinkMockRecorder) GetByHash(tokenHash interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByHash", reflect.TypeOf((*MockPublicLink)(nil).GetByHash), tokenHash)
+}
+
+// Save mocks base method.
+func (m *MockPublicLink) Save(link entity.PublicLink) (entity.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", link)
+	ret0, _ := ret[0].(entity.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockPublicLinkMockRecorder) Save(link interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPublicLink)(nil).Save), link)
+}
+
+// MockTodoItem is a mock of TodoItem interface.
+type MockTodoItem struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoItemMockRecorder
+}
+
+// MockTodoItemMockRecorder is the mock recorder for MockTodoItem.
+type MockTodoItemMockRecorder struct {
+	mock *MockTodoItem
+}
+
+// NewMockTodoItem creates a new mock instance.
+func NewMockTodoItem(ctrl *gomock.Controller) *MockTodoItem {
+	mock := &MockTodoItem{ctrl: ctrl}
+	mock.recorder = &MockTodoItemMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
+	return m.recorder
+}
+
+// CompleteParents mocks base method.
+func (m *MockTodoItem) CompleteParents(itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteParents", itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteParents indicates an expected call of CompleteParents.
+func (mr *MockTodoItemMockRecorder) CompleteParents(itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteParents", reflect.TypeOf((*MockTodoItem)(nil).CompleteParents), itemId)
+}
+
+// CompleteSubtree mocks base method.
+func (m *MockTodoItem) CompleteSubtree(itemId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompleteSubtree", itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompleteSubtree indicates an expected call of CompleteSubtree.
+func (mr *MockTodoItemMockRecorder) CompleteSubtree(itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompleteSubtree", reflect.TypeOf((*MockTodoItem)(nil).CompleteSubtree), itemId)
+}
+
+// Copy mocks base method.
+func (m *MockTodoItem) Copy(itemId, listId int, parentId *int) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", itemId, listId, parentId)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockTodoItemMockRecorder) Copy(itemId, listId, parentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockTodoItem)(nil).Copy), itemId, listId, parentId)
+}
+
+// Create mocks base method.
+func (m *MockTodoItem) Create(listId int, input entity.TodoItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTodoItemMockRecorder) Create(listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), listId, input)
+}
+
+// Delete mocks base method.
+func (m *MockTodoItem) Delete(userId, itemId int, keepChildren bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, keepChildren)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTodoItemMockRecorder) Delete(userId, itemId, keepChildren interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId, keepChildren)
+}
+
+// GetAll mocks base method.
+func (m *MockTodoItem) GetAll(userId, listId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, listId, filter)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoItemMockRecorder) GetAll(userId, listId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, listId, filter)
+}
+
+// GetAssigned mocks base method.
+func (m *MockTodoItem) GetAssigned(userId int) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssigned", userId)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssigned indicates an expected call of GetAssigned.
+func (mr *MockTodoItemMockRecorder) GetAssigned(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssigned", reflect.TypeOf((*MockTodoItem)(nil).GetAssigned), userId)
+}
+
+// GetById mocks base method.
+func (m *MockTodoItem) GetById(userId, itemId int) (entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, itemId)
+	ret0, _ := ret[0].(entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTodoItemMockRecorder) GetById(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), userId, itemId)
+}
+
+// GetDue mocks base method.
+func (m *MockTodoItem) GetDue(userId int, due entity.DueRange) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", userId, due)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockTodoItemMockRecorder) GetDue(userId, due interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockTodoItem)(nil).GetDue), userId, due)
+}
+
+// GetRole mocks base method.
+func (m *MockTodoItem) GetRole(userId, itemId int) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRole", userId, itemId)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRole indicates an expected call of GetRole.
+func (mr *MockTodoItemMockRecorder) GetRole(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRole", reflect.TypeOf((*MockTodoItem)(nil).GetRole), userId, itemId)
+}
+
+// GetSubtreeIds mocks base method.
+func (m *MockTodoItem) GetSubtreeIds(itemId int) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtreeIds", itemId)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtreeIds indicates an expected call of GetSubtreeIds.
+func (mr *MockTodoItemMockRecorder) GetSubtreeIds(itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtreeIds", reflect.TypeOf((*MockTodoItem)(nil).GetSubtreeIds), itemId)
+}
+
+// Move mocks base method.
+func (m *MockTodoItem) Move(itemId int, move entity.MoveInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", itemId, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoItemMockRecorder) Move(itemId, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), itemId, move)
+}
+
+// MoveToList mocks base method.
+func (m *MockTodoItem) MoveToList(itemId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveToList", itemId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MoveToList indicates an expected call of MoveToList.
+func (mr *MockTodoItemMockRecorder) MoveToList(itemId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveToList", reflect.TypeOf((*MockTodoItem)(nil).MoveToList), itemId, listId)
+}
+
+// Reschedule mocks base method.
+func (m *MockTodoItem) Reschedule(itemId int, occurrence entity.Occurrence) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Reschedule", itemId, occurrence)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Reschedule indicates an expected call of Reschedule.
+func (mr *MockTodoItemMockRecorder) Reschedule(itemId, occurrence interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reschedule", reflect.TypeOf((*MockTodoItem)(nil).Reschedule), itemId, occurrence)
+}
+
+// Search mocks base method.
+func (m *MockTodoItem) Search(userId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", userId, filter)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoItemMockRecorder) Search(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoItem)(nil).Search), userId, filter)
+}
+
+// Update mocks base method.
+func (m *MockTodoItem) Update(userId, itemId int, input entity.UpdateItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input)
+}
+
+// MockLabel is a mock of Label interface.
+type MockLabel struct {
+	ctrl     *gomock.Controller
+	recorder *MockLabelMockRecorder
+}
+
+// MockLabelMockRecorder is the mock recorder for MockLabel.
+type MockLabelMockRecorder struct {
+	mock *MockLabel
+}
+
+// NewMockLabel creates a new mock instance.
+func NewMockLabel(ctrl *gomock.Controller) *MockLabel {
+	mock := &MockLabel{ctrl: ctrl}
+	mock.recorder = &MockLabelMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockLabel) EXPECT() *MockLabelMockRecorder {
+	return m.recorder
+}
+
+// Attach mocks base method.
+func (m *MockLabel) Attach(itemId, labelId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", itemId, labelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockLabelMockRecorder) Attach(itemId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockLabel)(nil).Attach), itemId, labelId)
+}
+
+// Create mocks base method.
+func (m *MockLabel) Create(userId int, label entity.Label) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, label)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockLabelMockRecorder) Create(userId, label interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabel)(nil).Create), userId, label)
+}
+
+// Delete mocks base method.
+func (m *MockLabel) Delete(userId, labelId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUser", username, password)
-	ret0, _ := ret[0].(entity.User)
+	ret := m.ctrl.Call(m, "Delete", userId, labelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockLabelMockRecorder) Delete(userId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLabel)(nil).Delete), userId, labelId)
+}
+
+// Detach mocks base method.
+func (m *MockLabel) Detach(itemId, labelId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", itemId, labelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockLabelMockRecorder) Detach(itemId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockLabel)(nil).Detach), itemId, labelId)
+}
+
+// GetAll mocks base method.
+func (m *MockLabel) GetAll(userId int) ([]entity.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.Label)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetUser indicates an expected call of GetUser.
-func (mr *MockAuthorizationMockRecorder) GetUser(username, password interface{}) *gomock.Call {
+// GetAll indicates an expected call of GetAll.
+func (mr *MockLabelMockRecorder) GetAll(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUser", reflect.TypeOf((*MockAuthorization)(nil).GetUser), username, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLabel)(nil).GetAll), userId)
 }
 
-// MockTodoList is a mock of TodoList interface.
-type MockTodoList struct {
+// GetById mocks base method.
+func (m *MockLabel) GetById(userId, labelId int) (entity.Label, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, labelId)
+	ret0, _ := ret[0].(entity.Label)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockLabelMockRecorder) GetById(userId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockLabel)(nil).GetById), userId, labelId)
+}
+
+// Update mocks base method.
+func (m *MockLabel) Update(userId, labelId int, input entity.UpdateLabelInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, labelId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockLabelMockRecorder) Update(userId, labelId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabel)(nil).Update), userId, labelId, input)
+}
+
+// MockReminder is a mock of Reminder interface.
+type MockReminder struct {
 	ctrl     *gomock.Controller
-	recorder *MockTodoListMockRecorder
+	recorder *MockReminderMockRecorder
 }
 
-// MockTodoListMockRecorder is the mock recorder for MockTodoList.
-type MockTodoListMockRecorder struct {
-	mock *MockTodoList
+// MockReminderMockRecorder is the mock recorder for MockReminder.
+type MockReminderMockRecorder struct {
+	mock *MockReminder
 }
 
-// NewMockTodoList creates a new mock instance.
-func NewMockTodoList(ctrl *gomock.Controller) *MockTodoList {
-	mock := &MockTodoList{ctrl: ctrl}
-	mock.recorder = &MockTodoListMockRecorder{mock}
+// NewMockReminder creates a new mock instance.
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
 	return m.recorder
 }
 
+// ClaimDue mocks base method.
+func (m *MockReminder) ClaimDue(now time.Time, deliver func(entity.DueReminder) entity.DeliveryResult) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimDue", now, deliver)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimDue indicates an expected call of ClaimDue.
+func (mr *MockReminderMockRecorder) ClaimDue(now, deliver interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimDue", reflect.TypeOf((*MockReminder)(nil).ClaimDue), now, deliver)
+}
+
 // Create mocks base method.
-func (m *MockTodoList) Create(userId int, input entity.TodoList) (int, error) {
+func (m *MockReminder) Create(reminder entity.Reminder) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret := m.ctrl.Call(m, "Create", reminder)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoListMockRecorder) Create(userId, input interface{}) *gomock.Call {
+func (mr *MockReminderMockRecorder) Create(reminder interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoList)(nil).Create), userId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReminder)(nil).Create), reminder)
 }
 
 // Delete mocks base method.
-func (m *MockTodoList) Delete(userId, listId int) error {
+func (m *MockReminder) Delete(userId, itemId, reminderId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, listId)
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, reminderId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoListMockRecorder) Delete(userId, listId interface{}) *gomock.Call {
+func (mr *MockReminderMockRecorder) Delete(userId, itemId, reminderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReminder)(nil).Delete), userId, itemId, reminderId)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoList) GetAll(userId int) ([]entity.TodoList, error) {
+func (m *MockReminder) GetAll(userId, itemId int) ([]entity.Reminder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId)
-	ret0, _ := ret[0].([]entity.TodoList)
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]entity.Reminder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+func (mr *MockReminderMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReminder)(nil).GetAll), userId, itemId)
 }
 
-// GetById mocks base method.
-func (m *MockTodoList) GetById(userId, listId int) (entity.TodoList, error) {
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
+	ctrl     *gomock.Controller
+	recorder *MockNotificationMockRecorder
+}
+
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
+}
+
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotification) CountUnread(userId int) (map[string]int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", userId, listId)
-	ret0, _ := ret[0].(entity.TodoList)
+	ret := m.ctrl.Call(m, "CountUnread", userId)
+	ret0, _ := ret[0].(map[string]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetById indicates an expected call of GetById.
-func (mr *MockTodoListMockRecorder) GetById(userId, listId interface{}) *gomock.Call {
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationMockRecorder) CountUnread(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotification)(nil).CountUnread), userId)
 }
 
-// Update mocks base method.
-func (m *MockTodoList) Update(userId, listId int, list entity.UpdateListInput) error {
+// Create mocks base method.
+func (m *MockNotification) Create(notification entity.Notification) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, listId, list)
+	ret := m.ctrl.Call(m, "Create", notification)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockNotificationMockRecorder) Create(notification interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockNotification)(nil).Create), notification)
+}
+
+// CreateForMembers mocks base method.
+func (m *MockNotification) CreateForMembers(listId int, notification entity.Notification) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateForMembers", listId, notification)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// Update indicates an expected call of Update.
-func (mr *MockTodoListMockRecorder) Update(userId, listId, list interface{}) *gomock.Call {
+// CreateForMembers indicates an expected call of CreateForMembers.
+func (mr *MockNotificationMockRecorder) CreateForMembers(listId, notification interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, listId, list)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateForMembers", reflect.TypeOf((*MockNotification)(nil).CreateForMembers), listId, notification)
 }
 
-// MockTodoItem is a mock of TodoItem interface.
-type MockTodoItem struct {
+// GetAll mocks base method.
+func (m *MockNotification) GetAll(userId int, filter entity.NotificationFilter) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, filter)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockNotificationMockRecorder) GetAll(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockNotification)(nil).GetAll), userId, filter)
+}
+
+// GetMuted mocks base method.
+func (m *MockNotification) GetMuted(userId int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMuted", userId)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetMuted indicates an expected call of GetMuted.
+func (mr *MockNotificationMockRecorder) GetMuted(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMuted", reflect.TypeOf((*MockNotification)(nil).GetMuted), userId)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(userId, notificationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", userId, notificationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(userId, notificationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), userId, notificationId)
+}
+
+// SetMuted mocks base method.
+func (m *MockNotification) SetMuted(userId int, types []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetMuted", userId, types)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetMuted indicates an expected call of SetMuted.
+func (mr *MockNotificationMockRecorder) SetMuted(userId, types interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetMuted", reflect.TypeOf((*MockNotification)(nil).SetMuted), userId, types)
+}
+
+// MockComment is a mock of Comment interface.
+type MockComment struct {
 	ctrl     *gomock.Controller
-	recorder *MockTodoItemMockRecorder
+	recorder *MockCommentMockRecorder
 }
 
-// MockTodoItemMockRecorder is the mock recorder for MockTodoItem.
-type MockTodoItemMockRecorder struct {
-	mock *MockTodoItem
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
 }
 
-// NewMockTodoItem creates a new mock instance.
-func NewMockTodoItem(ctrl *gomock.Controller) *MockTodoItem {
-	mock := &MockTodoItem{ctrl: ctrl}
-	mock.recorder = &MockTodoItemMockRecorder{mock}
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockTodoItem) Create(listId int, input entity.TodoItem) (int, error) {
+func (m *MockComment) Create(comment entity.Comment) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", listId, input)
+	ret := m.ctrl.Call(m, "Create", comment)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoItemMockRecorder) Create(listId, input interface{}) *gomock.Call {
+func (mr *MockCommentMockRecorder) Create(comment interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), listId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockComment)(nil).Create), comment)
 }
 
 // Delete mocks base method.
-func (m *MockTodoItem) Delete(userId, itemId int) error {
+func (m *MockComment) Delete(userId, commentId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, itemId)
+	ret := m.ctrl.Call(m, "Delete", userId, commentId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoItemMockRecorder) Delete(userId, itemId interface{}) *gomock.Call {
+func (mr *MockCommentMockRecorder) Delete(userId, commentId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockComment)(nil).Delete), userId, commentId)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoItem) GetAll(userId, listId int) ([]entity.TodoItem, error) {
+func (m *MockComment) GetAll(itemId int) ([]entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId, listId)
-	ret0, _ := ret[0].([]entity.TodoItem)
+	ret := m.ctrl.Call(m, "GetAll", itemId)
+	ret0, _ := ret[0].([]entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoItemMockRecorder) GetAll(userId, listId interface{}) *gomock.Call {
+func (mr *MockCommentMockRecorder) GetAll(itemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockComment)(nil).GetAll), itemId)
 }
 
 // GetById mocks base method.
-func (m *MockTodoItem) GetById(userId, itemId int) (entity.TodoItem, error) {
+func (m *MockComment) GetById(itemId, commentId int) (entity.Comment, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", userId, itemId)
-	ret0, _ := ret[0].(entity.TodoItem)
+	ret := m.ctrl.Call(m, "GetById", itemId, commentId)
+	ret0, _ := ret[0].(entity.Comment)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockTodoItemMockRecorder) GetById(userId, itemId interface{}) *gomock.Call {
+func (mr *MockCommentMockRecorder) GetById(itemId, commentId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), userId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockComment)(nil).GetById), itemId, commentId)
 }
 
 // Update mocks base method.
-func (m *MockTodoItem) Update(userId, itemId int, input entity.UpdateItemInput) error {
+func (m *MockComment) Update(userId, commentId int, body string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, itemId, input)
+	ret := m.ctrl.Call(m, "Update", userId, commentId, body)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input interface{}) *gomock.Call {
+func (mr *MockCommentMockRecorder) Update(userId, commentId, body interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockComment)(nil).Update), userId, commentId, body)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachment) Create(attachment entity.Attachment) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", attachment)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentMockRecorder) Create(attachment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachment)(nil).Create), attachment)
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(itemId, attachmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", itemId, attachmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(itemId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), itemId, attachmentId)
+}
+
+// DeleteOrphanBlob mocks base method.
+func (m *MockAttachment) DeleteOrphanBlob(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOrphanBlob", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOrphanBlob indicates an expected call of DeleteOrphanBlob.
+func (mr *MockAttachmentMockRecorder) DeleteOrphanBlob(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOrphanBlob", reflect.TypeOf((*MockAttachment)(nil).DeleteOrphanBlob), key)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachment) GetAll(itemId int) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", itemId)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentMockRecorder) GetAll(itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachment)(nil).GetAll), itemId)
+}
+
+// GetById mocks base method.
+func (m *MockAttachment) GetById(itemId, attachmentId int) (entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", itemId, attachmentId)
+	ret0, _ := ret[0].(entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockAttachmentMockRecorder) GetById(itemId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockAttachment)(nil).GetById), itemId, attachmentId)
+}
+
+// GetOrphanBlobs mocks base method.
+func (m *MockAttachment) GetOrphanBlobs(limit int) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrphanBlobs", limit)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrphanBlobs indicates an expected call of GetOrphanBlobs.
+func (mr *MockAttachmentMockRecorder) GetOrphanBlobs(limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrphanBlobs", reflect.TypeOf((*MockAttachment)(nil).GetOrphanBlobs), limit)
 }
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/jmoiron/sqlx"
+)
+
+// positionGap - шаг между соседними позициями. Пока между соседями есть место, перемещение меняет одну строку,
+// когда место кончается, группа перенумеровывается заново.
+const positionGap = 1 << 16
+
+// nextListPosition - позиция в конце списков пользователя $1 для новой строки user_lists.
+var nextListPosition = fmt.Sprintf("(SELECT COALESCE(MAX(position), 0) + %d FROM %s WHERE user_id = $1)", positionGap, usersListsTable)
+
+// lockListQuery блокирует строку списка $1. FOR NO KEY UPDATE не мешает вставлять строки, которые ссылаются на список.
+var lockListQuery = fmt.Sprintf("SELECT id FROM %s WHERE id = $1 FOR NO KEY UPDATE;", todoListsTable)
+
+// lockList блокирует список до конца транзакции. Её первой берёт каждая транзакция, которая меняет позиции задач списка,
+// иначе одновременные вставки получают одинаковую позицию в конце, а перестановка не видит только что добавленных задач.
+// Если списка нет, возвращает sql.ErrNoRows.
+func lockList(tx *sqlx.Tx, listId int) error {
+	var id int
+	return tx.Get(&id, lockListQuery, listId)
+}
+
+type rankedRow struct {
+	Id       int   `db:"id"`
+	Position int64 `db:"position"`
+}
+
+// placeRanked вычисляет строки, которым нужно сменить позицию, чтобы элемент id встал перед якорем или после него.
+// siblings должны быть упорядочены по позиции. Возвращает false, если якоря среди них нет.
+func placeRanked(siblings []rankedRow, id, anchorId int, after bool) ([]rankedRow, bool) {
+	others := make([]rankedRow, 0, len(siblings))
+	index := -1
+	for _, row := range siblings {
+		if row.Id == id {
+			continue
+		}
+		if row.Id == anchorId {
+			index = len(others)
+		}
+		others = append(others, row)
+	}
+	if index < 0 {
+		return nil, false
+	}
+	if after {
+		index++
+	}
+
+	switch {
+	case index == 0:
+		return []rankedRow{{Id: id, Position: others[0].Position - positionGap}}, true
+	case index == len(others):
+		return []rankedRow{{Id: id, Position: others[index-1].Position + positionGap}}, true
+	}
+
+	prev, next := others[index-1].Position, others[index].Position
+	if next-prev > 1 {
+		return []rankedRow{{Id: id, Position: prev + (next-prev)/2}}, true
+	}
+
+	// Места между соседями нет: перенумеровываем группу с исходным шагом
+	ordered := make([]rankedRow, 0, len(others)+1)
+	ordered = append(ordered, others[:index]...)
+	ordered = append(ordered, rankedRow{Id: id})
+	ordered = append(ordered, others[index:]...)
+
+	changed := make([]rankedRow, 0, len(ordered))
+	for i, row := range ordered {
+		position := int64(i+1) * positionGap
+		if row.Id == id || row.Position != position {
+			changed = append(changed, rankedRow{Id: row.Id, Position: position})
+		}
+	}
+	return changed, true
+}
+
+func containsRow(rows []rankedRow, id int) bool {
+	for _, row := range rows {
+		if row.Id == id {
+			return true
+		}
+	}
+	return false
+}
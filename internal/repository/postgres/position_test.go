@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestPlaceRanked(t *testing.T) {
+	siblings := []rankedRow{{Id: 1, Position: 65536}, {Id: 2, Position: 131072}, {Id: 3, Position: 196608}}
+
+	tt := []struct {
+		name     string
+		siblings []rankedRow
+		id       int
+		anchorId int
+		after    bool
+		want     []rankedRow
+		ok       bool
+	}{
+		{
+			name:     "Before first",
+			siblings: siblings,
+			id:       3,
+			anchorId: 1,
+			want:     []rankedRow{{Id: 3, Position: 0}},
+			ok:       true,
+		},
+		{
+			name:     "After last",
+			siblings: siblings,
+			id:       1,
+			anchorId: 3,
+			after:    true,
+			want:     []rankedRow{{Id: 1, Position: 262144}},
+			ok:       true,
+		},
+		{
+			name:     "Between neighbours",
+			siblings: siblings,
+			id:       3,
+			anchorId: 1,
+			after:    true,
+			want:     []rankedRow{{Id: 3, Position: 98304}},
+			ok:       true,
+		},
+		{
+			name:     "From another parent",
+			siblings: siblings,
+			id:       9,
+			anchorId: 2,
+			want:     []rankedRow{{Id: 9, Position: 98304}},
+			ok:       true,
+		},
+		{
+			name:     "No gap left",
+			siblings: []rankedRow{{Id: 1, Position: 10}, {Id: 2, Position: 11}, {Id: 3, Position: 196608}},
+			id:       3,
+			anchorId: 2,
+			want:     []rankedRow{{Id: 1, Position: 65536}, {Id: 3, Position: 131072}, {Id: 2, Position: 196608}},
+			ok:       true,
+		},
+		{
+			name:     "Unknown anchor",
+			siblings: siblings,
+			id:       1,
+			anchorId: 7,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := placeRanked(tc.siblings, tc.id, tc.anchorId, tc.after)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestTodoList_Move(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoList(sqlxDB)
+
+	tt := []struct {
+		name         string
+		listId       int
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name:   "Ok",
+			listId: 3,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "position"}).AddRow(1, 65536).AddRow(2, 131072).AddRow(3, 196608)
+				mock.ExpectQuery(`SELECT list_id AS id, position FROM user_lists WHERE user_id = \$1 ORDER BY position, list_id FOR UPDATE`).
+					WithArgs(1).WillReturnRows(rows)
+				mock.ExpectExec(`UPDATE user_lists SET position = \$1 WHERE user_id = \$2 AND list_id = \$3`).
+					WithArgs(int64(0), 1, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:   "Foreign list",
+			listId: 5,
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				rows := sqlmock.NewRows([]string{"id", "position"}).AddRow(1, 65536).AddRow(2, 131072)
+				mock.ExpectQuery(`SELECT list_id AS id, position FROM user_lists (.+) FOR UPDATE`).
+					WithArgs(1).WillReturnRows(rows)
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			anchorId := 1
+			err := r.Move(1, tc.listId, entity.MoveInput{BeforeId: &anchorId})
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -2,11 +2,15 @@ package repository
 
 import (
 	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 	"time"
 )
 
+// canEditCondition ограничивает изменения участниками списка с ролью владельца или редактора (псевдоним ul - user_lists).
+var canEditCondition = fmt.Sprintf("ul.role IN ('%s', '%s')", entity.ListRoleOwner, entity.ListRoleEditor)
+
 type Config struct {
 	Host     string
 	Port     string
@@ -17,11 +21,33 @@ type Config struct {
 }
 
 const (
-	usersTable      = "users"
-	todoListsTable  = "todo_lists"
-	usersListsTable = "user_lists"
-	todoItemsTable  = "todo_items"
-	listsItemsTable = "list_items"
+	usersTable                = "users"
+	todoListsTable            = "todo_lists"
+	usersListsTable           = "user_lists"
+	todoItemsTable            = "todo_items"
+	listsItemsTable           = "list_items"
+	sessionsTable             = "sessions"
+	refreshTokensTable        = "refresh_tokens"
+	personalAccessTokensTable = "personal_access_tokens"
+	twoFactorTable            = "two_factor"
+	recoveryCodesTable        = "recovery_codes"
+	userIdentitiesTable       = "user_identities"
+	userTokensTable           = "user_tokens"
+	loginAttemptsTable        = "login_attempts"
+	auditLogTable             = "audit_log"
+	listInvitationsTable      = "list_invitations"
+	listInviteLinksTable      = "list_invite_links"
+	listPublicLinksTable      = "list_public_links"
+	labelsTable               = "labels"
+	itemLabelsTable           = "item_labels"
+	listTemplatesTable        = "list_templates"
+	templateItemsTable        = "template_items"
+	remindersTable            = "reminders"
+	notificationsTable        = "notifications"
+	notificationMutesTable    = "notification_mutes"
+	commentsTable             = "comments"
+	attachmentsTable          = "attachments"
+	orphanBlobsTable          = "orphan_blobs"
 
 	ReconnectCount    = 5
 	ReconnectCooldown = 5 * time.Second
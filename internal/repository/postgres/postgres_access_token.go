@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type PersonalAccessToken struct {
+	db *sqlx.DB
+}
+
+func NewPersonalAccessToken(db *sqlx.DB) *PersonalAccessToken {
+	return &PersonalAccessToken{db: db}
+}
+
+func (r *PersonalAccessToken) Create(token entity.PersonalAccessToken) (int, error) {
+	var id int
+	query := fmt.Sprintf("INSERT INTO %s (user_id, name, token_hash, scopes, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id;",
+		personalAccessTokensTable)
+	row := r.db.QueryRow(query, token.UserId, token.Name, token.TokenHash, token.Scopes, token.ExpiresAt)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+func (r *PersonalAccessToken) GetAll(userId int) ([]entity.PersonalAccessToken, error) {
+	var tokens []entity.PersonalAccessToken
+
+	query := fmt.Sprintf(`SELECT id, user_id, name, scopes, created_at, last_used_at, expires_at FROM %s
+								   WHERE user_id = $1 AND revoked_at IS NULL ORDER BY created_at DESC;`, personalAccessTokensTable)
+	err := r.db.Select(&tokens, query, userId)
+
+	return tokens, err
+}
+
+func (r *PersonalAccessToken) GetByHash(tokenHash string) (entity.PersonalAccessToken, error) {
+	var token entity.PersonalAccessToken
+
+	query := fmt.Sprintf(`SELECT id, user_id, name, scopes, created_at, last_used_at, expires_at, revoked_at FROM %s
+								   WHERE token_hash = $1;`, personalAccessTokensTable)
+	err := r.db.Get(&token, query, tokenHash)
+
+	return token, err
+}
+
+func (r *PersonalAccessToken) Touch(tokenId int) error {
+	query := fmt.Sprintf("UPDATE %s SET last_used_at = now() WHERE id = $1;", personalAccessTokensTable)
+	_, err := r.db.Exec(query, tokenId)
+
+	return err
+}
+
+// Revoke возвращает sql.ErrNoRows, если у пользователя нет такого действующего токена.
+func (r *PersonalAccessToken) Revoke(userId, tokenId int) error {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at = now() WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;",
+		personalAccessTokensTable)
+	res, err := r.db.Exec(query, tokenId, userId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+func (r *PersonalAccessToken) RevokeAll(userId int) error {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL;", personalAccessTokensTable)
+	_, err := r.db.Exec(query, userId)
+
+	return err
+}
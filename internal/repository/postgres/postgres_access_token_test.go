@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPersonalAccessToken_Create(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewPersonalAccessToken(sqlxDB)
+
+	token := entity.PersonalAccessToken{
+		UserId:    1,
+		Name:      "ci",
+		TokenHash: "hash",
+		Scopes:    pq.StringArray{entity.ScopeListsRead},
+	}
+
+	mock.ExpectQuery("INSERT INTO personal_access_tokens").
+		WithArgs(token.UserId, token.Name, token.TokenHash, token.Scopes, token.ExpiresAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	id, err := r.Create(token)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonalAccessToken_GetByHash(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewPersonalAccessToken(sqlxDB)
+
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "scopes", "created_at", "last_used_at", "expires_at", "revoked_at"}).
+		AddRow(1, 2, "ci", "{lists:read,items:write}", ts, nil, nil, nil)
+	mock.ExpectQuery("SELECT (.+) FROM personal_access_tokens WHERE token_hash = (.+)").
+		WithArgs("hash").WillReturnRows(rows)
+
+	got, err := r.GetByHash("hash")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PersonalAccessToken{
+		Id:        1,
+		UserId:    2,
+		Name:      "ci",
+		Scopes:    pq.StringArray{entity.ScopeListsRead, entity.ScopeItemsWrite},
+		CreatedAt: ts,
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPersonalAccessToken_Revoke(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewPersonalAccessToken(sqlxDB)
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectExec("UPDATE personal_access_tokens SET revoked_at = now\\(\\) WHERE id = (.+) AND user_id = (.+)").
+					WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Not Found",
+			mockBehavior: func() {
+				mock.ExpectExec("UPDATE personal_access_tokens SET revoked_at = now\\(\\) WHERE id = (.+) AND user_id = (.+)").
+					WithArgs(2, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.Revoke(1, 2)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+const attachmentColumns = "id, item_id, user_id, file_name, content_type, size, blob_key, etag, created_at"
+
+type Attachment struct {
+	db *sqlx.DB
+}
+
+func NewAttachment(db *sqlx.DB) *Attachment {
+	return &Attachment{db: db}
+}
+
+func (r *Attachment) Create(attachment entity.Attachment) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, file_name, content_type, size, blob_key, etag)
+									VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id;`, attachmentsTable)
+	row := r.db.QueryRow(query, attachment.ItemId, attachment.UserId, attachment.FileName, attachment.ContentType,
+		attachment.Size, attachment.BlobKey, attachment.ETag)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Attachment) GetAll(itemId int) ([]entity.Attachment, error) {
+	attachments := make([]entity.Attachment, 0)
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE item_id = $1 ORDER BY id;", attachmentColumns, attachmentsTable)
+	if err := r.db.Select(&attachments, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return attachments, nil
+}
+
+func (r *Attachment) GetById(itemId, attachmentId int) (entity.Attachment, error) {
+	var attachment entity.Attachment
+
+	query := fmt.Sprintf("SELECT %s FROM %s WHERE item_id = $1 AND id = $2;", attachmentColumns, attachmentsTable)
+	err := r.db.Get(&attachment, query, itemId, attachmentId)
+
+	return attachment, err
+}
+
+// Delete удаляет метаданные вложения, ключ файла попадает в orphan_blobs через триггер.
+func (r *Attachment) Delete(itemId, attachmentId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE item_id = $1 AND id = $2;", attachmentsTable)
+	res, err := r.db.Exec(query, itemId, attachmentId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// GetOrphanBlobs возвращает ключи файлов, оставшихся в хранилище после удаления вложений, начиная с самых старых.
+func (r *Attachment) GetOrphanBlobs(limit int) ([]string, error) {
+	keys := make([]string, 0)
+
+	query := fmt.Sprintf("SELECT blob_key FROM %s ORDER BY created_at, blob_key LIMIT $1;", orphanBlobsTable)
+	if err := r.db.Select(&keys, query, limit); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *Attachment) DeleteOrphanBlob(key string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE blob_key = $1;", orphanBlobsTable)
+	_, err := r.db.Exec(query, key)
+
+	return err
+}
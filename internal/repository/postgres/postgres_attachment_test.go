@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAttachment_Create(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewAttachment(sqlxDB)
+
+	userId := 1
+	mock.ExpectQuery(`INSERT INTO attachments \(item_id, user_id, file_name, content_type, size, blob_key, etag\) VALUES (.+) RETURNING id`).
+		WithArgs(3, &userId, "trip.png", "image/png", int64(2048), "items/3/abc", "e3b0").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+
+	id, err := r.Create(entity.Attachment{ItemId: 3, UserId: &userId, FileName: "trip.png", ContentType: "image/png",
+		Size: 2048, BlobKey: "items/3/abc", ETag: "e3b0"})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachment_Delete(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewAttachment(sqlxDB)
+
+	query := `DELETE FROM attachments WHERE item_id = \$1 AND id = \$2`
+	mock.ExpectExec(query).WithArgs(3, 7).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(query).WithArgs(3, 8).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, r.Delete(3, 7))
+	assert.ErrorIs(t, r.Delete(3, 8), sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAttachment_GetOrphanBlobs(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewAttachment(sqlxDB)
+
+	mock.ExpectQuery(`SELECT blob_key FROM orphan_blobs ORDER BY created_at, blob_key LIMIT \$1`).WithArgs(100).
+		WillReturnRows(sqlmock.NewRows([]string{"blob_key"}).AddRow("items/3/abc").AddRow("items/4/def"))
+	mock.ExpectExec(`DELETE FROM orphan_blobs WHERE blob_key = \$1`).WithArgs("items/3/abc").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	keys, err := r.GetOrphanBlobs(100)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"items/3/abc", "items/4/def"}, keys)
+	assert.NoError(t, r.DeleteOrphanBlob("items/3/abc"))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type Audit struct {
+	db *sqlx.DB
+}
+
+func NewAudit(db *sqlx.DB) *Audit {
+	return &Audit{db: db}
+}
+
+func (r *Audit) Create(entry entity.AuditEntry) error {
+	query := fmt.Sprintf("INSERT INTO %s (event, subject, ip, details) VALUES ($1, $2, $3, $4);", auditLogTable)
+	_, err := r.db.Exec(query, entry.Event, entry.Subject, entry.IP, entry.Details)
+
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/jmoiron/sqlx"
+	"strings"
 )
 
 type Auth struct {
@@ -16,18 +17,138 @@ func NewAuth(db *sqlx.DB) *Auth {
 
 func (r *Auth) CreateUser(user entity.User) (int, error) {
 	var id int
-	query := fmt.Sprintf("INSERT INTO %s (name, username, password_hash) VALUES ($1,$2,$3) RETURNING id", usersTable)
-	row := r.db.QueryRow(query, user.Name, user.Username, user.Password)
+	query := fmt.Sprintf("INSERT INTO %s (name, username, email, password_hash) VALUES ($1,$2,$3,$4) RETURNING id", usersTable)
+	row := r.db.QueryRow(query, user.Name, user.Username, user.Email, user.Password)
 	if err := row.Scan(&id); err != nil {
 		return 0, err
 	}
 	return id, nil
 }
 
-func (r *Auth) GetUser(username, password string) (entity.User, error) {
+func (r *Auth) GetUser(username string) (entity.User, error) {
 	var user entity.User
-	query := fmt.Sprintf("SELECT id FROM %s WHERE username = $1 and password_hash = $2", usersTable)
-	err := r.db.Get(&user, query, username, password)
+	query := fmt.Sprintf("SELECT id, password_hash FROM %s WHERE username = $1", usersTable)
+	err := r.db.Get(&user, query, username)
 
 	return user, err
 }
+
+func (r *Auth) GetUserById(userId int) (entity.User, error) {
+	var user entity.User
+	query := fmt.Sprintf("SELECT id, name, username, email, email_verified, password_hash, time_zone FROM %s WHERE id = $1", usersTable)
+	err := r.db.Get(&user, query, userId)
+
+	return user, err
+}
+
+func (r *Auth) GetUserByEmail(email string) (entity.User, error) {
+	var user entity.User
+	query := fmt.Sprintf("SELECT id, name, username, email, email_verified, password_hash FROM %s WHERE lower(email) = lower($1) AND email <> ''", usersTable)
+	err := r.db.Get(&user, query, email)
+
+	return user, err
+}
+
+// SetEmailVerified подтверждает адрес, только если он не менялся с момента отправки письма.
+func (r *Auth) SetEmailVerified(userId int, email string) error {
+	query := fmt.Sprintf("UPDATE %s SET email_verified = true WHERE id = $1 AND email = $2", usersTable)
+	res, err := r.db.Exec(query, userId, email)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+func (r *Auth) UpdateUser(userId int, input entity.UpdateUserInput) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Username != nil {
+		setValues = append(setValues, fmt.Sprintf("username=$%d", argId))
+		args = append(args, *input.Username)
+		argId++
+	}
+
+	// Подтверждение сохраняется, только если адрес не изменился (справа - значения до обновления)
+	if input.Email != nil {
+		setValues = append(setValues, fmt.Sprintf("email=$%[1]d, email_verified=(email = $%[1]d AND email_verified)", argId))
+		args = append(args, *input.Email)
+		argId++
+	}
+
+	if input.TimeZone != nil {
+		setValues = append(setValues, fmt.Sprintf("time_zone=$%d", argId))
+		args = append(args, *input.TimeZone)
+		argId++
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE id = $%d", usersTable, setQuery, argId)
+
+	args = append(args, userId)
+	_, err := r.db.Exec(query, args...)
+
+	return err
+}
+
+func (r *Auth) UpdatePasswordHash(userId int, passwordHash string) error {
+	query := fmt.Sprintf("UPDATE %s SET password_hash = $1 WHERE id = $2", usersTable)
+	_, err := r.db.Exec(query, passwordHash, userId)
+
+	return err
+}
+
+// DeleteUser удаляет пользователя вместе со списками, в которых он был единственным участником.
+// Задачи таких списков удаляются явно: каскад по list_items удаляет только связи, но не сами задачи.
+// В общих списках, где пользователь был единственным владельцем, владельцем становится самый давний участник.
+func (r *Auth) DeleteUser(userId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	orphanListsQuery := fmt.Sprintf(`SELECT ul.list_id FROM %[1]s AS ul WHERE ul.user_id = $1 AND NOT EXISTS
+										(SELECT 1 FROM %[1]s AS other WHERE other.list_id = ul.list_id AND other.user_id <> $1)`,
+		usersListsTable)
+
+	deleteItemsQuery := fmt.Sprintf("DELETE FROM %s WHERE id IN (SELECT li.item_id FROM %s AS li WHERE li.list_id IN (%s));",
+		todoItemsTable, listsItemsTable, orphanListsQuery)
+	if _, err = tx.Exec(deleteItemsQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	deleteListsQuery := fmt.Sprintf("DELETE FROM %s WHERE id IN (%s);", todoListsTable, orphanListsQuery)
+	if _, err = tx.Exec(deleteListsQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	promoteQuery := fmt.Sprintf(`UPDATE %[1]s SET role = $2 WHERE id IN
+									(SELECT DISTINCT ON (m.list_id) m.id FROM %[1]s AS m
+									WHERE m.user_id <> $1
+									  AND m.list_id IN (SELECT list_id FROM %[1]s WHERE user_id = $1 AND role = $2)
+									  AND NOT EXISTS (SELECT 1 FROM %[1]s AS o WHERE o.list_id = m.list_id AND o.user_id <> $1 AND o.role = $2)
+									ORDER BY m.list_id, m.id);`, usersListsTable)
+	if _, err = tx.Exec(promoteQuery, userId, entity.ListRoleOwner); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	deleteUserQuery := fmt.Sprintf("DELETE FROM %s WHERE id = $1;", usersTable)
+	if _, err = tx.Exec(deleteUserQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
@@ -34,11 +34,12 @@ func TestAuth_CreateUser(t *testing.T) {
 			args: entity.User{
 				Name:     "name",
 				Username: "username",
+				Email:    "user@example.com",
 				Password: "password",
 			},
 			id: 1,
 			mockBehavior: func(args entity.User) {
-				mock.ExpectQuery("INSERT INTO users (.+)").WithArgs(args.Name, args.Username, args.Password).
+				mock.ExpectQuery("INSERT INTO users (.+)").WithArgs(args.Name, args.Username, args.Email, args.Password).
 					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 			},
 		},
@@ -94,18 +95,22 @@ func TestAuth_GetUser(t *testing.T) {
 		{
 			name: "Ok",
 			mockBehavior: func(args entity.User) {
-				rows := sqlmock.NewRows([]string{"id"}).
-					AddRow(1)
-				mock.ExpectQuery(`SELECT id FROM users WHERE (.+)`).WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "password_hash"}).
+					AddRow(1, "hash")
+				mock.ExpectQuery(`SELECT id, password_hash FROM users WHERE (.+)`).WithArgs(args.Username).WillReturnRows(rows)
+			},
+			args: entity.User{
+				Username: "username",
 			},
 			expectedResponse: entity.User{
-				Id: 1,
+				Id:       1,
+				Password: "hash",
 			},
 		},
 		{
 			name: "Empty Items",
 			mockBehavior: func(args entity.User) {
-				mock.ExpectQuery(`SELECT id FROM users WHERE (.+)`).WillReturnError(sql.ErrNoRows)
+				mock.ExpectQuery(`SELECT id, password_hash FROM users WHERE (.+)`).WillReturnError(sql.ErrNoRows)
 			},
 			expectedResponse: entity.User{},
 			wantErr:          true,
@@ -116,7 +121,7 @@ func TestAuth_GetUser(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(tc.args)
 
-			got, err := r.GetUser(tc.args.Username, tc.args.Password)
+			got, err := r.GetUser(tc.args.Username)
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -127,3 +132,182 @@ func TestAuth_GetUser(t *testing.T) {
 		})
 	}
 }
+
+func TestAuth_UpdatePasswordHash(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewAuth(sqlxDB)
+
+	type args struct {
+		userId       int
+		passwordHash string
+	}
+	type mockBehavior func(args args)
+
+	tt := []struct {
+		name         string
+		mockBehavior mockBehavior
+		args         args
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				userId:       1,
+				passwordHash: "$argon2id$v=19$m=65536,t=3,p=2$c2FsdA$aGFzaA",
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectExec("UPDATE users SET password_hash").WithArgs(args.passwordHash, args.userId).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Failure",
+			args: args{
+				userId:       1,
+				passwordHash: "hash",
+			},
+			mockBehavior: func(args args) {
+				mock.ExpectExec("UPDATE users SET password_hash").WithArgs(args.passwordHash, args.userId).
+					WillReturnError(errors.New("some error"))
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.args)
+
+			err := r.UpdatePasswordHash(tc.args.userId, tc.args.passwordHash)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestAuth_UpdateUser(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewAuth(sqlxDB)
+
+	name := "name"
+	username := "username"
+
+	mock.ExpectExec(`UPDATE users SET name=\$1, username=\$2 WHERE id = \$3`).WithArgs(name, username, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := r.UpdateUser(1, entity.UpdateUserInput{Name: &name, Username: &username})
+	assert.NoError(t, err)
+
+	email := "user@example.com"
+
+	mock.ExpectExec(`UPDATE users SET email=\$1, email_verified=\(email = \$1 AND email_verified\) WHERE id = \$2`).
+		WithArgs(email, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = r.UpdateUser(1, entity.UpdateUserInput{Email: &email})
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuth_DeleteUser(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewAuth(sqlxDB)
+
+	// Владельцем становится участник с самой ранней записью в списке, если других владельцев нет
+	promoteQuery := `UPDATE user_lists SET role = \$2 WHERE id IN \(SELECT DISTINCT ON \(m.list_id\) m.id FROM user_lists AS m ` +
+		`WHERE m.user_id <> \$1 AND m.list_id IN \(SELECT list_id FROM user_lists WHERE user_id = \$1 AND role = \$2\) ` +
+		`AND NOT EXISTS \(SELECT 1 FROM user_lists AS o WHERE o.list_id = m.list_id AND o.user_id <> \$1 AND o.role = \$2\) ` +
+		`ORDER BY m.list_id, m.id\)`
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM todo_items WHERE id IN \\(SELECT li.item_id FROM list_items (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM todo_lists WHERE id IN \\(SELECT ul.list_id FROM user_lists (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(promoteQuery).WithArgs(1, entity.ListRoleOwner).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM users WHERE id = (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Sole owner of shared list",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM todo_items WHERE id IN \\(SELECT li.item_id FROM list_items (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM todo_lists WHERE id IN \\(SELECT ul.list_id FROM user_lists (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(promoteQuery).WithArgs(1, entity.ListRoleOwner).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM users WHERE id = (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Promotion fails",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM todo_items WHERE id IN \\(SELECT li.item_id FROM list_items (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("DELETE FROM todo_lists WHERE id IN \\(SELECT ul.list_id FROM user_lists (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(promoteQuery).WithArgs(1, entity.ListRoleOwner).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rollback",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM todo_items WHERE id IN \\(SELECT li.item_id FROM list_items (.+)").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectExec("DELETE FROM todo_lists WHERE id IN \\(SELECT ul.list_id FROM user_lists (.+)").
+					WithArgs(1).WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.DeleteUser(1)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
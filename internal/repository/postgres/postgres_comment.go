@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+// commentColumns выбирает поля комментария вместе с именем автора (псевдонимы c - comments, u - users).
+// Комментарии удалённых пользователей остаются без автора, поэтому users присоединяется через LEFT JOIN.
+var commentColumns = fmt.Sprintf(`c.id, c.item_id, c.parent_id, c.user_id, COALESCE(u.name, '%s') AS author, c.body,
+	c.deleted_at IS NOT NULL AS deleted, c.created_at, c.updated_at`, entity.DeletedAuthor)
+
+type Comment struct {
+	db *sqlx.DB
+}
+
+func NewComment(db *sqlx.DB) *Comment {
+	return &Comment{db: db}
+}
+
+func (r *Comment) Create(comment entity.Comment) (int, error) {
+	var id int
+
+	query := fmt.Sprintf("INSERT INTO %s (item_id, user_id, parent_id, body) VALUES ($1, $2, $3, $4) RETURNING id;", commentsTable)
+	row := r.db.QueryRow(query, comment.ItemId, comment.AuthorId, comment.ParentId, comment.Body)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Comment) GetAll(itemId int) ([]entity.Comment, error) {
+	comments := make([]entity.Comment, 0)
+
+	query := fmt.Sprintf(`SELECT %s FROM %s AS c LEFT JOIN %s AS u ON u.id = c.user_id
+									WHERE c.item_id = $1 ORDER BY c.id;`, commentColumns, commentsTable, usersTable)
+	if err := r.db.Select(&comments, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *Comment) GetById(itemId, commentId int) (entity.Comment, error) {
+	var comment entity.Comment
+
+	query := fmt.Sprintf(`SELECT %s FROM %s AS c LEFT JOIN %s AS u ON u.id = c.user_id
+									WHERE c.item_id = $1 AND c.id = $2;`, commentColumns, commentsTable, usersTable)
+	err := r.db.Get(&comment, query, itemId, commentId)
+
+	return comment, err
+}
+
+func (r *Comment) Update(userId, commentId int, body string) error {
+	query := fmt.Sprintf("UPDATE %s SET body = $1, updated_at = now() WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL;",
+		commentsTable)
+	res, err := r.db.Exec(query, body, commentId, userId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// Delete удаляет комментарий автора. Если на комментарий уже ответили, он остаётся в ветке без текста.
+func (r *Comment) Delete(userId, commentId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	deleteQuery := fmt.Sprintf(`DELETE FROM %s AS c WHERE c.id = $1 AND c.user_id = $2
+									AND NOT EXISTS (SELECT 1 FROM %s AS r WHERE r.parent_id = c.id);`, commentsTable, commentsTable)
+	res, err := tx.Exec(deleteQuery, commentId, userId)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if affected > 0 {
+		return tx.Commit()
+	}
+
+	hideQuery := fmt.Sprintf("UPDATE %s SET body = '', deleted_at = now() WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL;",
+		commentsTable)
+	res, err = tx.Exec(hideQuery, commentId, userId)
+	if err == nil {
+		err = expectAffected(res)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
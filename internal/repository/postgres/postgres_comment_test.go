@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+const getAllCommentsQuery = `SELECT c.id, (.+) COALESCE\(u.name, 'deleted user'\) AS author, (.+) FROM comments AS c ` +
+	`LEFT JOIN users AS u ON u.id = c.user_id WHERE c.item_id = \$1 ORDER BY c.id`
+
+func TestComment_GetAll(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewComment(sqlxDB)
+
+	createdAt := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "item_id", "parent_id", "user_id", "author", "body", "deleted", "created_at", "updated_at"}).
+		AddRow(1, 3, nil, 1, "Alice", "", true, createdAt, nil).
+		AddRow(2, 3, 1, 2, "Bob", "@alice done", false, createdAt, nil)
+	mock.ExpectQuery(getAllCommentsQuery).WithArgs(3).WillReturnRows(rows)
+
+	parentId, aliceId, bobId := 1, 1, 2
+	got, err := r.GetAll(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Comment{
+		{Id: 1, ItemId: 3, AuthorId: &aliceId, Author: "Alice", Deleted: true, CreatedAt: createdAt},
+		{Id: 2, ItemId: 3, ParentId: &parentId, AuthorId: &bobId, Author: "Bob", Body: "@alice done", CreatedAt: createdAt},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestComment_RepliesSurviveAuthorDeletion(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	// Комментарии не удаляются вместе с пользователем: user_id обнуляется внешним ключом on delete set null
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM todo_items WHERE id IN (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM todo_lists WHERE id IN (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("UPDATE user_lists SET role = (.+)").WithArgs(1, entity.ListRoleOwner).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("DELETE FROM users WHERE id = (.+)").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	createdAt := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	rows := sqlmock.NewRows([]string{"id", "item_id", "parent_id", "user_id", "author", "body", "deleted", "created_at", "updated_at"}).
+		AddRow(1, 3, nil, nil, "deleted user", "Who books the hotel?", false, createdAt, nil).
+		AddRow(2, 3, 1, 2, "Bob", "Me", false, createdAt, nil)
+	mock.ExpectQuery(getAllCommentsQuery).WithArgs(3).WillReturnRows(rows)
+
+	assert.NoError(t, NewAuth(sqlxDB).DeleteUser(1))
+
+	parentId, bobId := 1, 2
+	got, err := NewComment(sqlxDB).GetAll(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Comment{
+		{Id: 1, ItemId: 3, Author: entity.DeletedAuthor, Body: "Who books the hotel?", CreatedAt: createdAt, Replies: []entity.Comment{
+			{Id: 2, ItemId: 3, ParentId: &parentId, AuthorId: &bobId, Author: "Bob", Body: "Me", CreatedAt: createdAt},
+		}},
+	}, entity.BuildCommentTree(got))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestComment_Delete(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewComment(sqlxDB)
+
+	deleteQuery := `DELETE FROM comments AS c WHERE c.id = \$1 AND c.user_id = \$2 AND NOT EXISTS \(SELECT 1 FROM comments AS r WHERE r.parent_id = c.id\)`
+	hideQuery := `UPDATE comments SET body = '', deleted_at = now\(\) WHERE id = \$1 AND user_id = \$2 AND deleted_at IS NULL`
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Without replies",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "With replies",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(hideQuery).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not found",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(deleteQuery).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec(hideQuery).WithArgs(5, 1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.Delete(1, 5)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type ExternalIdentity struct {
+	db *sqlx.DB
+}
+
+func NewExternalIdentity(db *sqlx.DB) *ExternalIdentity {
+	return &ExternalIdentity{db: db}
+}
+
+func (r *ExternalIdentity) GetUserId(provider, subject string) (int, error) {
+	var userId int
+
+	query := fmt.Sprintf("SELECT user_id FROM %s WHERE provider = $1 AND subject = $2;", userIdentitiesTable)
+	err := r.db.Get(&userId, query, provider, subject)
+
+	return userId, err
+}
+
+// CreateWithUser создаёт пользователя вместе с привязкой к внешнему провайдеру.
+func (r *ExternalIdentity) CreateWithUser(user entity.User, identity entity.ExternalIdentity) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var userId int
+	createUserQuery := fmt.Sprintf("INSERT INTO %s (name, username, password_hash) VALUES ($1, $2, $3) RETURNING id;", usersTable)
+	row := tx.QueryRow(createUserQuery, user.Name, user.Username, user.Password)
+	if err = row.Scan(&userId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	createIdentityQuery := fmt.Sprintf("INSERT INTO %s (user_id, provider, subject, email) VALUES ($1, $2, $3, $4);", userIdentitiesTable)
+	if _, err = tx.Exec(createIdentityQuery, userId, identity.Provider, identity.Subject, identity.Email); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return userId, tx.Commit()
+}
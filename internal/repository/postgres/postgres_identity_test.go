@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExternalIdentity_CreateWithUser(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewExternalIdentity(sqlxDB)
+
+	user := entity.User{Name: "Jane Doe", Username: "jane"}
+	identity := entity.ExternalIdentity{Provider: "corp", Subject: "sub-1", Email: "jane@example.com"}
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		want         int
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO users").WithArgs("Jane Doe", "jane", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec("INSERT INTO user_identities").WithArgs(7, "corp", "sub-1", "jane@example.com").
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: 7,
+		},
+		{
+			name: "Identity insert failed",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("INSERT INTO users").WithArgs("Jane Doe", "jane", "").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				mock.ExpectExec("INSERT INTO user_identities").WithArgs(7, "corp", "sub-1", "jane@example.com").
+					WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			got, err := r.CreateWithUser(user, identity)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type ListInvitation struct {
+	db *sqlx.DB
+}
+
+func NewListInvitation(db *sqlx.DB) *ListInvitation {
+	return &ListInvitation{db: db}
+}
+
+func (r *ListInvitation) Create(invitation entity.ListInvitation) (int, error) {
+	var id int
+
+	query := fmt.Sprintf("INSERT INTO %s (list_id, user_id, inviter_id, role) VALUES ($1, $2, $3, $4) RETURNING id;", listInvitationsTable)
+	row := r.db.QueryRow(query, invitation.ListId, invitation.UserId, invitation.InviterId, invitation.Role)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *ListInvitation) GetAll(userId int) ([]entity.ListInvitation, error) {
+	var invitations []entity.ListInvitation
+
+	query := fmt.Sprintf(`SELECT i.id, i.list_id, tl.title AS list_title, i.user_id, i.inviter_id, u.username AS invited_by, i.role, i.created_at
+									FROM %s AS i INNER JOIN %s AS tl ON tl.id = i.list_id INNER JOIN %s AS u ON u.id = i.inviter_id
+									WHERE i.user_id = $1 ORDER BY i.id;`, listInvitationsTable, todoListsTable, usersTable)
+	err := r.db.Select(&invitations, query, userId)
+
+	return invitations, err
+}
+
+// Accept превращает приглашение в участие в списке. Если приглашения нет, возвращается sql.ErrNoRows.
+func (r *ListInvitation) Accept(userId, invitationId int) (entity.ListInvitation, error) {
+	invitation := entity.ListInvitation{Id: invitationId, UserId: userId}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return invitation, err
+	}
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2 RETURNING list_id, role;", listInvitationsTable)
+	row := tx.QueryRow(deleteQuery, invitationId, userId)
+	if err = row.Scan(&invitation.ListId, &invitation.Role); err != nil {
+		_ = tx.Rollback()
+		return invitation, err
+	}
+
+	addMemberQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id, role, position) VALUES ($1, $2, $3, %s);", usersListsTable, nextListPosition)
+	if _, err = tx.Exec(addMemberQuery, userId, invitation.ListId, invitation.Role); err != nil {
+		_ = tx.Rollback()
+		return invitation, err
+	}
+
+	return invitation, tx.Commit()
+}
+
+func (r *ListInvitation) Decline(userId, invitationId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2;", listInvitationsTable)
+	res, err := r.db.Exec(query, invitationId, userId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListInvitation_Accept(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewListInvitation(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM list_invitations WHERE id = (.+) AND user_id = (.+) RETURNING list_id, role").
+		WithArgs(5, 3).WillReturnRows(sqlmock.NewRows([]string{"list_id", "role"}).AddRow(2, entity.ListRoleEditor))
+	mock.ExpectExec("INSERT INTO user_lists").
+		WithArgs(3, 2, entity.ListRoleEditor).WillReturnResult(sqlmock.NewResult(1, 1))
+	mock.ExpectCommit()
+
+	got, err := r.Accept(3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, entity.ListInvitation{Id: 5, ListId: 2, UserId: 3, Role: entity.ListRoleEditor}, got)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM list_invitations").
+		WithArgs(6, 3).WillReturnRows(sqlmock.NewRows([]string{"list_id", "role"}))
+	mock.ExpectRollback()
+
+	_, err = r.Accept(3, 6)
+	assert.ErrorIs(t, err, sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type InviteLink struct {
+	db *sqlx.DB
+}
+
+func NewInviteLink(db *sqlx.DB) *InviteLink {
+	return &InviteLink{db: db}
+}
+
+func (r *InviteLink) Create(link entity.InviteLink) (int, error) {
+	var id int
+
+	query := fmt.Sprintf("INSERT INTO %s (list_id, created_by, role, single_use, expires_at) VALUES ($1, $2, $3, $4, $5) RETURNING id;", listInviteLinksTable)
+	row := r.db.QueryRow(query, link.ListId, link.CreatedBy, link.Role, link.SingleUse, link.ExpiresAt)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *InviteLink) Delete(listId, linkId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND list_id = $2;", listInviteLinksTable)
+	res, err := r.db.Exec(query, linkId, listId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// Redeem добавляет пользователя в список по ссылке и гасит одноразовую ссылку.
+// Для отозванной, истёкшей или уже использованной ссылки возвращается sql.ErrNoRows.
+func (r *InviteLink) Redeem(linkId, userId int) (entity.InviteLink, error) {
+	var link entity.InviteLink
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return link, err
+	}
+
+	selectQuery := fmt.Sprintf(`SELECT id, list_id, created_by, role, single_use, expires_at, used_at FROM %s
+									WHERE id = $1 AND expires_at > now() AND (NOT single_use OR used_at IS NULL) FOR UPDATE;`, listInviteLinksTable)
+	if err = tx.Get(&link, selectQuery, linkId); err != nil {
+		_ = tx.Rollback()
+		return link, err
+	}
+
+	addMemberQuery := fmt.Sprintf("INSERT INTO %s (user_id, list_id, role, position) VALUES ($1, $2, $3, %s);", usersListsTable, nextListPosition)
+	if _, err = tx.Exec(addMemberQuery, userId, link.ListId, link.Role); err != nil {
+		_ = tx.Rollback()
+		return link, err
+	}
+
+	if link.SingleUse {
+		useQuery := fmt.Sprintf("UPDATE %s SET used_at = now() WHERE id = $1;", listInviteLinksTable)
+		if _, err = tx.Exec(useQuery, linkId); err != nil {
+			_ = tx.Rollback()
+			return link, err
+		}
+	}
+
+	return link, tx.Commit()
+}
@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestInviteLink_Redeem(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewInviteLink(sqlxDB)
+
+	expiresAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "list_id", "created_by", "role", "single_use", "expires_at", "used_at"}
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		want         entity.InviteLink
+		wantErr      error
+	}{
+		{
+			name: "Single use",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM list_invite_links WHERE id = (.+) AND expires_at > now\\(\\) AND \\(NOT single_use OR used_at IS NULL\\) FOR UPDATE").
+					WithArgs(7).WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 2, 1, entity.ListRoleViewer, true, expiresAt, nil))
+				mock.ExpectExec("INSERT INTO user_lists").
+					WithArgs(3, 2, entity.ListRoleViewer).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("UPDATE list_invite_links SET used_at = now\\(\\)").
+					WithArgs(7).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			want: entity.InviteLink{Id: 7, ListId: 2, CreatedBy: 1, Role: entity.ListRoleViewer, SingleUse: true, ExpiresAt: expiresAt},
+		},
+		{
+			name: "Reusable",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM list_invite_links").
+					WithArgs(7).WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 2, 1, entity.ListRoleEditor, false, expiresAt, nil))
+				mock.ExpectExec("INSERT INTO user_lists").
+					WithArgs(3, 2, entity.ListRoleEditor).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+			want: entity.InviteLink{Id: 7, ListId: 2, CreatedBy: 1, Role: entity.ListRoleEditor, ExpiresAt: expiresAt},
+		},
+		{
+			name: "Used or expired",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM list_invite_links").
+					WithArgs(7).WillReturnRows(sqlmock.NewRows(columns))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "Already member",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery("SELECT (.+) FROM list_invite_links").
+					WithArgs(7).WillReturnRows(sqlmock.NewRows(columns).AddRow(7, 2, 1, entity.ListRoleViewer, true, expiresAt, nil))
+				mock.ExpectExec("INSERT INTO user_lists").
+					WithArgs(3, 2, entity.ListRoleViewer).WillReturnError(errors.New("unique violation"))
+				mock.ExpectRollback()
+			},
+			wantErr: errors.New("unique violation"),
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			got, err := r.Redeem(7, 3)
+			if tc.wantErr != nil {
+				assert.EqualError(t, err, tc.wantErr.Error())
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"strings"
+)
+
+type Label struct {
+	db *sqlx.DB
+}
+
+func NewLabel(db *sqlx.DB) *Label {
+	return &Label{db: db}
+}
+
+func (r *Label) Create(userId int, label entity.Label) (int, error) {
+	var id int
+
+	query := fmt.Sprintf("INSERT INTO %s (user_id, name, color) VALUES ($1, $2, $3) RETURNING id;", labelsTable)
+	row := r.db.QueryRow(query, userId, label.Name, label.Color)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Label) GetAll(userId int) ([]entity.Label, error) {
+	labels := make([]entity.Label, 0)
+
+	query := fmt.Sprintf("SELECT id, user_id, name, color FROM %s WHERE user_id = $1 ORDER BY name;", labelsTable)
+	if err := r.db.Select(&labels, query, userId); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (r *Label) GetById(userId, labelId int) (entity.Label, error) {
+	var label entity.Label
+
+	query := fmt.Sprintf("SELECT id, user_id, name, color FROM %s WHERE user_id = $1 AND id = $2;", labelsTable)
+	err := r.db.Get(&label, query, userId, labelId)
+
+	return label, err
+}
+
+func (r *Label) Update(userId, labelId int, input entity.UpdateLabelInput) error {
+	setValues := make([]string, 0)
+	args := make([]interface{}, 0)
+	argId := 1
+
+	if input.Name != nil {
+		setValues = append(setValues, fmt.Sprintf("name=$%d", argId))
+		args = append(args, *input.Name)
+		argId++
+	}
+
+	if input.Color != nil {
+		setValues = append(setValues, fmt.Sprintf("color=$%d", argId))
+		args = append(args, *input.Color)
+		argId++
+	}
+
+	setQuery := strings.Join(setValues, ", ")
+
+	query := fmt.Sprintf("UPDATE %s SET %s WHERE user_id = $%d AND id = $%d;", labelsTable, setQuery, argId, argId+1)
+	args = append(args, userId, labelId)
+
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+func (r *Label) Delete(userId, labelId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND id = $2;", labelsTable)
+	res, err := r.db.Exec(query, userId, labelId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// Attach повторно метку не добавляет и ошибки в этом случае не возвращает.
+func (r *Label) Attach(itemId, labelId int) error {
+	query := fmt.Sprintf("INSERT INTO %s (item_id, label_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;", itemLabelsTable)
+	_, err := r.db.Exec(query, itemId, labelId)
+
+	return err
+}
+
+func (r *Label) Detach(itemId, labelId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE item_id = $1 AND label_id = $2;", itemLabelsTable)
+	res, err := r.db.Exec(query, itemId, labelId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
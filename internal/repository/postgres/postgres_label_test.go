@@ -0,0 +1,78 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestLabel_Update(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewLabel(sqlxDB)
+
+	name := "home"
+	color := "#00ff00"
+
+	tt := []struct {
+		name         string
+		input        entity.UpdateLabelInput
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name:  "Ok",
+			input: entity.UpdateLabelInput{Name: &name, Color: &color},
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE labels SET name=\$1, color=\$2 WHERE user_id = \$3 AND id = \$4`).
+					WithArgs(name, color, 1, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Foreign label",
+			input: entity.UpdateLabelInput{Color: &color},
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE labels SET color=\$1 WHERE user_id = \$2 AND id = \$3`).
+					WithArgs(color, 1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.Update(1, 2, tc.input)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLabel_AttachDetach(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewLabel(sqlxDB)
+
+	mock.ExpectExec(`INSERT INTO item_labels \(item_id, label_id\) VALUES \(\$1, \$2\) ON CONFLICT DO NOTHING`).
+		WithArgs(3, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.NoError(t, r.Attach(3, 2))
+
+	mock.ExpectExec(`DELETE FROM item_labels WHERE item_id = \$1 AND label_id = \$2`).
+		WithArgs(3, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	assert.ErrorIs(t, r.Detach(3, 2), sql.ErrNoRows)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
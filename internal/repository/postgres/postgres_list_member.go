@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type ListMember struct {
+	db *sqlx.DB
+}
+
+func NewListMember(db *sqlx.DB) *ListMember {
+	return &ListMember{db: db}
+}
+
+func (r *ListMember) GetAll(listId int) ([]entity.ListMember, error) {
+	var members []entity.ListMember
+
+	query := fmt.Sprintf(`SELECT ul.user_id, u.name, u.username, ul.role FROM %s AS ul INNER JOIN %s AS u ON u.id = ul.user_id
+									WHERE ul.list_id = $1 ORDER BY ul.id;`, usersListsTable, usersTable)
+	err := r.db.Select(&members, query, listId)
+
+	return members, err
+}
+
+func (r *ListMember) UpdateRole(listId, userId int, role string) error {
+	query := fmt.Sprintf("UPDATE %s SET role = $1 WHERE list_id = $2 AND user_id = $3;", usersListsTable)
+	res, err := r.db.Exec(query, role, listId, userId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// Remove исключает участника из списка и снимает его с задач этого списка.
+func (r *ListMember) Remove(listId, userId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE list_id = $1 AND user_id = $2;", usersListsTable)
+	res, err := tx.Exec(query, listId, userId)
+	if err == nil {
+		err = expectAffected(res)
+	}
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	unassignQuery := fmt.Sprintf(`UPDATE %s AS ti SET assignee_id = NULL FROM %s AS li
+									WHERE li.item_id = ti.id AND li.list_id = $1 AND ti.assignee_id = $2;`,
+		todoItemsTable, listsItemsTable)
+	if _, err = tx.Exec(unassignQuery, listId, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *ListMember) CountOwners(listId int) (int, error) {
+	var count int
+
+	query := fmt.Sprintf("SELECT count(*) FROM %s WHERE list_id = $1 AND role = $2;", usersListsTable)
+	err := r.db.Get(&count, query, listId, entity.ListRoleOwner)
+
+	return count, err
+}
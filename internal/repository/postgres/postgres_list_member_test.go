@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListMember_GetAll(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewListMember(sqlxDB)
+
+	rows := sqlmock.NewRows([]string{"user_id", "name", "username", "role"}).
+		AddRow(1, "Alice", "alice", entity.ListRoleOwner).
+		AddRow(2, "Bob", "bob", entity.ListRoleViewer)
+	mock.ExpectQuery("SELECT ul.user_id, u.name, u.username, ul.role FROM user_lists AS ul INNER JOIN users AS u (.+) WHERE ul.list_id = (.+)").
+		WithArgs(7).WillReturnRows(rows)
+
+	got, err := r.GetAll(7)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.ListMember{
+		{UserId: 1, Name: "Alice", Username: "alice", Role: entity.ListRoleOwner},
+		{UserId: 2, Name: "Bob", Username: "bob", Role: entity.ListRoleViewer},
+	}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListMember_UpdateRole(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewListMember(sqlxDB)
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectExec("UPDATE user_lists SET role = (.+) WHERE list_id = (.+) AND user_id = (.+)").
+					WithArgs(entity.ListRoleEditor, 7, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Not a member",
+			mockBehavior: func() {
+				mock.ExpectExec("UPDATE user_lists SET role = (.+) WHERE list_id = (.+) AND user_id = (.+)").
+					WithArgs(entity.ListRoleEditor, 7, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.UpdateRole(7, 2, entity.ListRoleEditor)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestListMember_Remove(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewListMember(sqlxDB)
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM user_lists WHERE list_id = (.+) AND user_id = (.+)").
+					WithArgs(7, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(`UPDATE todo_items AS ti SET assignee_id = NULL FROM list_items AS li (.+) AND li.list_id = \$1 AND ti.assignee_id = \$2`).
+					WithArgs(7, 2).WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Not a member",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("DELETE FROM user_lists WHERE list_id = (.+) AND user_id = (.+)").
+					WithArgs(7, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.Remove(7, 2)
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
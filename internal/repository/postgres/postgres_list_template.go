@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type ListTemplate struct {
+	db *sqlx.DB
+}
+
+func NewListTemplate(db *sqlx.DB) *ListTemplate {
+	return &ListTemplate{db: db}
+}
+
+// CreateFromList сохраняет структуру списка как шаблон пользователя. Пустой title заменяется названием списка.
+func (r *ListTemplate) CreateFromList(userId, listId int, title string) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	createQuery := fmt.Sprintf(`INSERT INTO %s (user_id, title, description)
+									SELECT $1, COALESCE(NULLIF($3, ''), title), COALESCE(description, '') FROM %s WHERE id = $2 RETURNING id;`,
+		listTemplatesTable, todoListsTable)
+	if err = tx.Get(&id, createQuery, userId, listId, title); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	var items []entity.TemplateItem
+	itemsQuery := fmt.Sprintf("%s SELECT ti.id, ti.parent_id, ti.title, ti.description, ti.priority, ti.position FROM tree INNER JOIN %s AS ti ON ti.id = tree.id ORDER BY tree.level, ti.id;",
+		listTreeCTE, todoItemsTable)
+	if err = tx.Select(&items, itemsQuery, listId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	createItemQuery := fmt.Sprintf("INSERT INTO %s (template_id, parent_id, title, description, priority, position) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;",
+		templateItemsTable)
+	copies := make(map[int]int, len(items))
+	for _, item := range items {
+		var parentId *int
+		if item.ParentId != nil {
+			copyParentId := copies[*item.ParentId]
+			parentId = &copyParentId
+		}
+
+		var copyId int
+		if err = tx.Get(&copyId, createItemQuery, id, parentId, item.Title, item.Description, item.Priority, item.Position); err != nil {
+			_ = tx.Rollback()
+			return 0, err
+		}
+		copies[item.Id] = copyId
+	}
+
+	return id, tx.Commit()
+}
+
+func (r *ListTemplate) GetAll(userId int) ([]entity.ListTemplate, error) {
+	templates := make([]entity.ListTemplate, 0)
+
+	query := fmt.Sprintf("SELECT id, user_id, title, description, created_at FROM %s WHERE user_id = $1 ORDER BY title, id;", listTemplatesTable)
+	if err := r.db.Select(&templates, query, userId); err != nil {
+		return nil, err
+	}
+
+	return templates, nil
+}
+
+// GetById возвращает шаблон с задачами. Задачи упорядочены так, что родитель всегда идёт раньше подзадач.
+func (r *ListTemplate) GetById(userId, templateId int) (entity.ListTemplate, error) {
+	var template entity.ListTemplate
+
+	query := fmt.Sprintf("SELECT id, user_id, title, description, created_at FROM %s WHERE user_id = $1 AND id = $2;", listTemplatesTable)
+	if err := r.db.Get(&template, query, userId, templateId); err != nil {
+		return template, err
+	}
+
+	itemsQuery := fmt.Sprintf(`WITH RECURSIVE tree AS (
+									SELECT id, 1 AS level FROM %s WHERE template_id = $1 AND parent_id IS NULL
+								UNION ALL
+									SELECT ti.id, tree.level + 1 FROM %s AS ti INNER JOIN tree ON ti.parent_id = tree.id
+								)
+								SELECT ti.id, ti.parent_id, ti.title, ti.description, ti.priority, ti.position
+								FROM tree INNER JOIN %s AS ti ON ti.id = tree.id ORDER BY tree.level, ti.position, ti.id;`,
+		templateItemsTable, templateItemsTable, templateItemsTable)
+	err := r.db.Select(&template.Items, itemsQuery, templateId)
+
+	return template, err
+}
+
+func (r *ListTemplate) Delete(userId, templateId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1 AND id = $2;", listTemplatesTable)
+	res, err := r.db.Exec(query, userId, templateId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// Instantiate создаёт по уже заполненному шаблону новый список пользователя со всеми задачами.
+func (r *ListTemplate) Instantiate(userId int, template entity.ListTemplate) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	createListQuery := fmt.Sprintf("INSERT INTO %s (title, description) VALUES ($1, $2) RETURNING id;", todoListsTable)
+	if err = tx.Get(&id, createListQuery, template.Title, template.Description); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	createUserLists := fmt.Sprintf("INSERT INTO %s (user_id, list_id, role, position) VALUES ($1, $2, '%s', %s);",
+		usersListsTable, entity.ListRoleOwner, nextListPosition)
+	if _, err = tx.Exec(createUserLists, userId, id); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	items := make([]itemCopy, len(template.Items))
+	for i, item := range template.Items {
+		items[i] = itemCopy{
+			TodoItem: entity.TodoItem{Id: item.Id, ParentId: item.ParentId, Title: item.Title, Description: item.Description, Priority: item.Priority},
+			Position: item.Position,
+		}
+	}
+	if _, err = insertItemCopies(tx, items, id, false); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestListTemplate_CreateFromList(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewListTemplate(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO list_templates \(user_id, title, description\) SELECT \$1, COALESCE\(NULLIF\(\$3, ''\), title\), (.+) FROM todo_lists WHERE id = \$2`).
+		WithArgs(1, 2, "Weekly").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	rows := sqlmock.NewRows([]string{"id", "parent_id", "title", "description", "priority", "position"}).
+		AddRow(7, nil, "Plan {{week}}", "", 1, 65536).
+		AddRow(8, 7, "Review", "", 0, 131072)
+	mock.ExpectQuery(`WITH RECURSIVE tree AS \((.+)\) SELECT (.+) FROM tree INNER JOIN todo_items AS ti`).
+		WithArgs(2).WillReturnRows(rows)
+	mock.ExpectQuery(`INSERT INTO template_items \(template_id, parent_id, title, description, priority, position\)`).
+		WithArgs(3, nil, "Plan {{week}}", "", entity.PriorityLow, int64(65536)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(30))
+	mock.ExpectQuery(`INSERT INTO template_items`).
+		WithArgs(3, 30, "Review", "", entity.PriorityNone, int64(131072)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(31))
+	mock.ExpectCommit()
+
+	id, err := r.CreateFromList(1, 2, "Weekly")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestListTemplate_Instantiate(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewListTemplate(sqlxDB)
+
+	parentId := 30
+	template := entity.ListTemplate{
+		Id:    3,
+		Title: "Week 12",
+		Items: []entity.TemplateItem{
+			{Id: 30, Title: "Plan week 12", Priority: entity.PriorityLow, Position: 65536},
+			{Id: 31, ParentId: &parentId, Title: "Review", Position: 131072},
+		},
+	}
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO todo_lists \(title, description\) VALUES \(\$1, \$2\)`).
+		WithArgs("Week 12", "").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectExec(`INSERT INTO user_lists`).WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO todo_items").
+		WithArgs("Plan week 12", "", false, nil, nil, false, "", entity.PriorityLow, nil, int64(65536)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+	mock.ExpectExec("INSERT INTO list_items").WithArgs(5, 20).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectQuery("INSERT INTO todo_items").
+		WithArgs("Review", "", false, nil, nil, false, "", entity.PriorityNone, 20, int64(131072)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+	mock.ExpectExec("INSERT INTO list_items").WithArgs(5, 21).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	id, err := r.Instantiate(1, template)
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
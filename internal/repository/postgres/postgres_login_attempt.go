@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+type LoginAttempt struct {
+	db *sqlx.DB
+}
+
+func NewLoginAttempt(db *sqlx.DB) *LoginAttempt {
+	return &LoginAttempt{db: db}
+}
+
+// Get возвращает счётчик попыток по ключу. Для ключа без истории возвращается пустой счётчик.
+func (r *LoginAttempt) Get(key string) (entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+
+	query := fmt.Sprintf("SELECT key, failures, last_failure_at, locked_until FROM %s WHERE key = $1;", loginAttemptsTable)
+	err := r.db.Get(&attempt, query, key)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.LoginAttempt{Key: key}, nil
+	}
+
+	return attempt, err
+}
+
+// RegisterFailure атомарно увеличивает счётчик неудач. Если последняя неудача была раньше resetBefore,
+// счёт начинается заново.
+func (r *LoginAttempt) RegisterFailure(key string, now, resetBefore time.Time) (entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+
+	query := fmt.Sprintf(`INSERT INTO %[1]s (key, failures, last_failure_at) VALUES ($1, 1, $2)
+								   ON CONFLICT (key) DO UPDATE SET
+								   failures = CASE WHEN %[1]s.last_failure_at < $3 THEN 1 ELSE %[1]s.failures + 1 END,
+								   last_failure_at = EXCLUDED.last_failure_at
+								   RETURNING key, failures, last_failure_at, locked_until;`, loginAttemptsTable)
+	err := r.db.Get(&attempt, query, key, now, resetBefore)
+
+	return attempt, err
+}
+
+func (r *LoginAttempt) Lock(key string, until time.Time) error {
+	query := fmt.Sprintf("UPDATE %s SET locked_until = $1 WHERE key = $2;", loginAttemptsTable)
+	_, err := r.db.Exec(query, until, key)
+
+	return err
+}
+
+func (r *LoginAttempt) Reset(key string) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE key = $1;", loginAttemptsTable)
+	_, err := r.db.Exec(query, key)
+
+	return err
+}
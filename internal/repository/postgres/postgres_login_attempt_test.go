@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLoginAttempt_Get(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewLoginAttempt(sqlxDB)
+
+	mock.ExpectQuery("SELECT (.+) FROM login_attempts WHERE key = (.+)").
+		WithArgs("user:test").WillReturnError(sql.ErrNoRows)
+
+	attempt, err := r.Get("user:test")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.LoginAttempt{Key: "user:test"}, attempt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginAttempt_RegisterFailure(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewLoginAttempt(sqlxDB)
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	resetBefore := now.Add(-15 * time.Minute)
+
+	rows := sqlmock.NewRows([]string{"key", "failures", "last_failure_at", "locked_until"}).
+		AddRow("user:test", 3, now, nil)
+	mock.ExpectQuery("INSERT INTO login_attempts (.+) ON CONFLICT \\(key\\) DO UPDATE (.+) RETURNING").
+		WithArgs("user:test", now, resetBefore).WillReturnRows(rows)
+
+	attempt, err := r.RegisterFailure("user:test", now, resetBefore)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempt.Failures)
+	assert.Equal(t, now, *attempt.LastFailureAt)
+	assert.Nil(t, attempt.LockedUntil)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
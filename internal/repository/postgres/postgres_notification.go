@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"strings"
+)
+
+type Notification struct {
+	db *sqlx.DB
+}
+
+func NewNotification(db *sqlx.DB) *Notification {
+	return &Notification{db: db}
+}
+
+// Create не записывает уведомление отключённого получателем типа или с уже встречавшимся DedupKey
+// и в этих случаях возвращает нулевой id без ошибки.
+func (r *Notification) Create(notification entity.Notification) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(`INSERT INTO %s (user_id, type, title, body, list_id, item_id, actor_id, dedup_key)
+									SELECT $1::int, $2::varchar, $3::varchar, $4::text, $5::int, $6::int, $7::int, NULLIF($8::varchar, '')
+									WHERE NOT EXISTS (SELECT 1 FROM %s WHERE user_id = $1 AND type = $2)
+									ON CONFLICT DO NOTHING RETURNING id;`, notificationsTable, notificationMutesTable)
+	row := r.db.QueryRow(query, notification.UserId, notification.Type, notification.Title, notification.Body,
+		notification.ListId, notification.ItemId, notification.ActorId, notification.DedupKey)
+	if err := row.Scan(&id); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+// CreateForMembers рассылает уведомление всем участникам списка, кроме совершившего действие (ActorId).
+func (r *Notification) CreateForMembers(listId int, notification entity.Notification) error {
+	query := fmt.Sprintf(`INSERT INTO %s (user_id, type, title, body, list_id, item_id, actor_id)
+									SELECT ul.user_id, $2::varchar, $3::varchar, $4::text, $5::int, $6::int, $7::int FROM %s AS ul
+									WHERE ul.list_id = $1 AND ul.user_id IS DISTINCT FROM $7::int
+										AND NOT EXISTS (SELECT 1 FROM %s AS m WHERE m.user_id = ul.user_id AND m.type = $2);`,
+		notificationsTable, usersListsTable, notificationMutesTable)
+	_, err := r.db.Exec(query, listId, notification.Type, notification.Title, notification.Body, notification.ListId,
+		notification.ItemId, notification.ActorId)
+
+	return err
+}
+
+func (r *Notification) GetAll(userId int, filter entity.NotificationFilter) ([]entity.Notification, error) {
+	notifications := make([]entity.Notification, 0)
+
+	conditions := []string{"n.user_id = $1"}
+	args := []interface{}{userId}
+	if filter.Unread {
+		conditions = append(conditions, "n.read_at IS NULL")
+	}
+	if filter.BeforeId > 0 {
+		args = append(args, filter.BeforeId)
+		conditions = append(conditions, fmt.Sprintf("n.id < $%d", len(args)))
+	}
+	args = append(args, filter.Limit)
+
+	query := fmt.Sprintf(`SELECT n.id, n.user_id, n.type, n.title, n.body, n.list_id, n.item_id, n.actor_id,
+									COALESCE(a.name, '') AS actor, n.created_at, n.read_at
+								FROM %s AS n
+									LEFT JOIN %s AS a ON a.id = n.actor_id
+								WHERE %s
+								ORDER BY n.id DESC LIMIT $%d;`,
+		notificationsTable, usersTable, strings.Join(conditions, " AND "), len(args))
+	if err := r.db.Select(&notifications, query, args...); err != nil {
+		return nil, err
+	}
+
+	return notifications, nil
+}
+
+// CountUnread возвращает число непрочитанных уведомлений по типам.
+func (r *Notification) CountUnread(userId int) (map[string]int, error) {
+	var rows []struct {
+		Type  string `db:"type"`
+		Count int    `db:"count"`
+	}
+
+	query := fmt.Sprintf("SELECT type, count(*) AS count FROM %s WHERE user_id = $1 AND read_at IS NULL GROUP BY type;", notificationsTable)
+	if err := r.db.Select(&rows, query, userId); err != nil {
+		return nil, err
+	}
+
+	counts := make(map[string]int, len(rows))
+	for _, row := range rows {
+		counts[row.Type] = row.Count
+	}
+	return counts, nil
+}
+
+// MarkRead не меняет время прочтения уже прочитанного уведомления.
+func (r *Notification) MarkRead(userId, notificationId int) error {
+	query := fmt.Sprintf("UPDATE %s SET read_at = COALESCE(read_at, now()) WHERE user_id = $1 AND id = $2;", notificationsTable)
+	res, err := r.db.Exec(query, userId, notificationId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+func (r *Notification) MarkAllRead(userId int) error {
+	query := fmt.Sprintf("UPDATE %s SET read_at = now() WHERE user_id = $1 AND read_at IS NULL;", notificationsTable)
+	_, err := r.db.Exec(query, userId)
+
+	return err
+}
+
+func (r *Notification) GetMuted(userId int) ([]string, error) {
+	muted := make([]string, 0)
+
+	query := fmt.Sprintf("SELECT type FROM %s WHERE user_id = $1 ORDER BY type;", notificationMutesTable)
+	if err := r.db.Select(&muted, query, userId); err != nil {
+		return nil, err
+	}
+
+	return muted, nil
+}
+
+// SetMuted заменяет набор отключённых типов уведомлений.
+func (r *Notification) SetMuted(userId int, types []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1;", notificationMutesTable)
+	if _, err = tx.Exec(query, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	if len(types) > 0 {
+		insertQuery := fmt.Sprintf("INSERT INTO %s (user_id, type) SELECT $1, unnest($2::varchar[]) ON CONFLICT DO NOTHING;", notificationMutesTable)
+		if _, err = tx.Exec(insertQuery, userId, pq.Array(types)); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
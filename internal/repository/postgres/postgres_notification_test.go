@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNotification_Create(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewNotification(sqlxDB)
+	listId, itemId := 3, 7
+	notification := entity.Notification{UserId: 1, Type: entity.NotificationReminder, Title: "Pay rent",
+		ListId: &listId, ItemId: &itemId, DedupKey: "reminder:5:1714726800"}
+
+	query := `INSERT INTO notifications (.+) SELECT (.+) WHERE NOT EXISTS \(SELECT 1 FROM notification_mutes WHERE user_id = \$1 AND type = \$2\) ON CONFLICT DO NOTHING RETURNING id`
+	args := []driver.Value{1, "reminder", "Pay rent", "", &listId, &itemId, nil, "reminder:5:1714726800"}
+	mock.ExpectQuery(query).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(9))
+	mock.ExpectQuery(query).WithArgs(args...).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	id, err := r.Create(notification)
+	assert.NoError(t, err)
+	assert.Equal(t, 9, id)
+
+	id, err = r.Create(notification)
+	assert.NoError(t, err, "повторное или отключённое уведомление не ошибка")
+	assert.Equal(t, 0, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotification_CreateForMembers(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewNotification(sqlxDB)
+	listId, itemId, actorId := 2, 5, 1
+
+	mock.ExpectExec(`INSERT INTO notifications (.+) SELECT ul.user_id, (.+) FROM user_lists AS ul WHERE ul.list_id = \$1 AND ul.user_id IS DISTINCT FROM \$7::int AND NOT EXISTS (.+)`).
+		WithArgs(2, "item_completed", "Trip", "The item was completed", &listId, &itemId, &actorId).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, r.CreateForMembers(2, entity.Notification{Type: entity.NotificationItemCompleted, Title: "Trip",
+		Body: "The item was completed", ListId: &listId, ItemId: &itemId, ActorId: &actorId}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotification_GetAll(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewNotification(sqlxDB)
+	createdAt := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "type", "title", "body", "list_id", "item_id", "actor_id", "actor", "created_at", "read_at"}).
+		AddRow(9, 1, "list_invitation", "Trip", "", 2, nil, 3, "Alice", createdAt, nil)
+	mock.ExpectQuery(`SELECT n.id, (.+) FROM notifications AS n LEFT JOIN users AS a ON a.id = n.actor_id WHERE n.user_id = \$1 AND n.read_at IS NULL AND n.id < \$2 ORDER BY n.id DESC LIMIT \$3`).
+		WithArgs(1, 10, 20).WillReturnRows(rows)
+
+	notifications, err := r.GetAll(1, entity.NotificationFilter{Unread: true, BeforeId: 10, Limit: 20})
+	assert.NoError(t, err)
+	assert.Len(t, notifications, 1)
+	assert.Equal(t, "Alice", notifications[0].Actor)
+	assert.Equal(t, 2, *notifications[0].ListId)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestNotification_SetMuted(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewNotification(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`DELETE FROM notification_mutes WHERE user_id = \$1`).WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO notification_mutes \(user_id, type\) SELECT \$1, unnest\(\$2::varchar\[\]\)`).
+		WithArgs(1, pq.Array([]string{"reminder", "mention"})).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.SetMuted(1, []string{"reminder", "mention"}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type PublicLink struct {
+	db *sqlx.DB
+}
+
+func NewPublicLink(db *sqlx.DB) *PublicLink {
+	return &PublicLink{db: db}
+}
+
+// Save публикует список, заменяя прежнюю ссылку, если она была: старый токен перестаёт действовать.
+func (r *PublicLink) Save(link entity.PublicLink) (entity.PublicLink, error) {
+	var saved entity.PublicLink
+
+	query := fmt.Sprintf(`INSERT INTO %s (list_id, created_by, token_hash, password_hash, expires_at) VALUES ($1, $2, $3, $4, $5)
+									ON CONFLICT (list_id) DO UPDATE SET created_by = EXCLUDED.created_by, token_hash = EXCLUDED.token_hash,
+									password_hash = EXCLUDED.password_hash, expires_at = EXCLUDED.expires_at, created_at = now()
+									RETURNING id, list_id, created_by, token_hash, password_hash, expires_at, created_at;`, listPublicLinksTable)
+	err := r.db.Get(&saved, query, link.ListId, link.CreatedBy, link.TokenHash, link.PasswordHash, link.ExpiresAt)
+
+	return saved, err
+}
+
+// GetByHash возвращает действующую ссылку. Для отозванной или истёкшей возвращается sql.ErrNoRows.
+func (r *PublicLink) GetByHash(tokenHash string) (entity.PublicLink, error) {
+	var link entity.PublicLink
+
+	query := fmt.Sprintf(`SELECT id, list_id, created_by, token_hash, password_hash, expires_at, created_at FROM %s
+									WHERE token_hash = $1 AND (expires_at IS NULL OR expires_at > now());`, listPublicLinksTable)
+	err := r.db.Get(&link, query, tokenHash)
+
+	return link, err
+}
+
+func (r *PublicLink) Delete(listId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE list_id = $1;", listPublicLinksTable)
+	res, err := r.db.Exec(query, listId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
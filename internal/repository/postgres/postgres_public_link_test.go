@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestPublicLink_GetByHash(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewPublicLink(sqlxDB)
+
+	createdAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	columns := []string{"id", "list_id", "created_by", "token_hash", "password_hash", "expires_at", "created_at"}
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		want         entity.PublicLink
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM list_public_links WHERE token_hash = (.+) AND \\(expires_at IS NULL OR expires_at > now\\(\\)\\)").
+					WithArgs("hash").WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 2, 3, "hash", "", nil, createdAt))
+			},
+			want: entity.PublicLink{Id: 1, ListId: 2, CreatedBy: 3, TokenHash: "hash", CreatedAt: createdAt},
+		},
+		{
+			name: "Revoked or expired",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM list_public_links").
+					WithArgs("hash").WillReturnRows(sqlmock.NewRows(columns))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			got, err := r.GetByHash("hash")
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
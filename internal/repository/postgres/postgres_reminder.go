@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"time"
+)
+
+// reminderFireAt вычисляет момент срабатывания напоминания (псевдонимы r - reminders, ti - todo_items, u - users).
+// Срок задачи на весь день хранится как полночь UTC, поэтому он переводится в полночь той же даты в поясе пользователя.
+// Для напоминания со смещением у задачи без срока результат NULL и напоминание не срабатывает.
+const reminderFireAt = `CASE
+		WHEN r.remind_at IS NOT NULL THEN r.remind_at
+		WHEN ti.all_day THEN ((ti.due_at AT TIME ZONE 'UTC') AT TIME ZONE u.time_zone) - make_interval(mins => r.offset_minutes)
+		ELSE ti.due_at - make_interval(mins => r.offset_minutes)
+	END`
+
+type Reminder struct {
+	db *sqlx.DB
+}
+
+func NewReminder(db *sqlx.DB) *Reminder {
+	return &Reminder{db: db}
+}
+
+func (r *Reminder) Create(reminder entity.Reminder) (int, error) {
+	var id int
+
+	query := fmt.Sprintf(`INSERT INTO %s (item_id, user_id, offset_minutes, remind_at, channel, webhook_url)
+									VALUES ($1, $2, $3, $4, $5, $6) RETURNING id;`, remindersTable)
+	row := r.db.QueryRow(query, reminder.ItemId, reminder.UserId, reminder.OffsetMinutes, reminder.RemindAt,
+		reminder.Channel, reminder.WebhookURL)
+	if err := row.Scan(&id); err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (r *Reminder) GetAll(userId, itemId int) ([]entity.Reminder, error) {
+	reminders := make([]entity.Reminder, 0)
+
+	query := fmt.Sprintf(`SELECT r.id, r.item_id, r.user_id, r.offset_minutes, r.remind_at, r.channel, r.webhook_url,
+									%s AS fire_at, r.sent_for, r.last_error
+								FROM %s AS r
+									INNER JOIN %s AS ti ON ti.id = r.item_id
+									INNER JOIN %s AS u ON u.id = r.user_id
+								WHERE r.user_id = $1 AND r.item_id = $2
+								ORDER BY fire_at NULLS LAST, r.id;`,
+		reminderFireAt, remindersTable, todoItemsTable, usersTable)
+	if err := r.db.Select(&reminders, query, userId, itemId); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+func (r *Reminder) Delete(userId, itemId, reminderId int) error {
+	query := fmt.Sprintf("DELETE FROM %s WHERE id = $1 AND user_id = $2 AND item_id = $3;", remindersTable)
+	res, err := r.db.Exec(query, reminderId, userId, itemId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// ClaimDue берёт одно сработавшее к моменту now напоминание и передаёт его в deliver, удерживая блокировку строки
+// до сохранения результата. Благодаря SKIP LOCKED несколько экземпляров приложения не получают одно напоминание дважды.
+// Напоминание срабатывает, пока задача не выполнена и пользователь остаётся участником списка.
+// Возвращает false, если сработавших напоминаний нет.
+func (r *Reminder) ClaimDue(now time.Time, deliver func(entity.DueReminder) entity.DeliveryResult) (bool, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return false, err
+	}
+
+	var reminder entity.DueReminder
+	query := fmt.Sprintf(`SELECT r.id, r.item_id, li.list_id, r.user_id, r.channel, r.webhook_url, %[1]s AS fire_at, r.attempts,
+									ti.title AS item_title, ti.due_at, ti.all_day,
+									CASE WHEN u.email_verified THEN u.email ELSE '' END AS email, u.time_zone
+								FROM %[2]s AS r
+									INNER JOIN %[3]s AS ti ON ti.id = r.item_id
+									INNER JOIN %[4]s AS li ON li.item_id = ti.id
+									INNER JOIN %[5]s AS ul ON ul.list_id = li.list_id AND ul.user_id = r.user_id
+									INNER JOIN %[6]s AS u ON u.id = r.user_id
+								WHERE NOT ti.done AND %[1]s <= $1 AND r.sent_for IS DISTINCT FROM %[1]s
+									AND (r.retry_at IS NULL OR r.retry_at <= $1)
+								ORDER BY fire_at
+								LIMIT 1 FOR UPDATE OF r SKIP LOCKED;`,
+		reminderFireAt, remindersTable, todoItemsTable, listsItemsTable, usersListsTable, usersTable)
+	if err = tx.Get(&reminder, query, now); err != nil {
+		_ = tx.Rollback()
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+
+	result := deliver(reminder)
+
+	var args []interface{}
+	switch {
+	case result.Err == nil:
+		query = fmt.Sprintf("UPDATE %s SET sent_for = $2, attempts = 0, retry_at = NULL, last_error = '' WHERE id = $1;", remindersTable)
+		args = []interface{}{reminder.Id, reminder.FireAt}
+	case result.RetryAt != nil:
+		query = fmt.Sprintf("UPDATE %s SET attempts = attempts + 1, retry_at = $2, last_error = $3 WHERE id = $1;", remindersTable)
+		args = []interface{}{reminder.Id, *result.RetryAt, result.Err.Error()}
+	default:
+		query = fmt.Sprintf("UPDATE %s SET sent_for = $2, attempts = 0, retry_at = NULL, last_error = $3 WHERE id = $1;", remindersTable)
+		args = []interface{}{reminder.Id, reminder.FireAt, result.Err.Error()}
+	}
+	if _, err = tx.Exec(query, args...); err != nil {
+		_ = tx.Rollback()
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
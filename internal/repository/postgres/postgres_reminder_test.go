@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestReminder_ClaimDue(t *testing.T) {
+	now := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	fireAt := now.Add(-time.Minute)
+	retryAt := now.Add(time.Minute)
+	errFailed := errors.New("connection refused")
+
+	claimQuery := `SELECT r.id, (.+) FROM reminders AS r (.+) WHERE NOT ti.done AND (.+) <= \$1 AND r.sent_for IS DISTINCT FROM (.+) LIMIT 1 FOR UPDATE OF r SKIP LOCKED`
+	columns := []string{"id", "item_id", "list_id", "user_id", "channel", "webhook_url", "fire_at", "attempts",
+		"item_title", "due_at", "all_day", "email", "time_zone"}
+
+	tt := []struct {
+		name         string
+		result       entity.DeliveryResult
+		mockBehavior func(mock sqlmock.Sqlmock)
+	}{
+		{
+			name: "Delivered",
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE reminders SET sent_for = \$2, attempts = 0, retry_at = NULL, last_error = '' WHERE id = \$1`).
+					WithArgs(5, fireAt).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:   "Retry",
+			result: entity.DeliveryResult{Err: errFailed, RetryAt: &retryAt},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE reminders SET attempts = attempts \+ 1, retry_at = \$2, last_error = \$3 WHERE id = \$1`).
+					WithArgs(5, retryAt, "connection refused").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:   "Gave up",
+			result: entity.DeliveryResult{Err: errFailed},
+			mockBehavior: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(`UPDATE reminders SET sent_for = \$2, attempts = 0, retry_at = NULL, last_error = \$3 WHERE id = \$1`).
+					WithArgs(5, fireAt, "connection refused").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			mockDB, mock, _ := sqlmock.New()
+			defer func(mockDB *sql.DB) {
+				_ = mockDB.Close()
+			}(mockDB)
+			sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+			r := NewReminder(sqlxDB)
+
+			mock.ExpectBegin()
+			rows := sqlmock.NewRows(columns).
+				AddRow(5, 7, 3, 1, "inbox", "", fireAt, 0, "Pay rent", now, false, "", "UTC")
+			mock.ExpectQuery(claimQuery).WithArgs(now).WillReturnRows(rows)
+			tc.mockBehavior(mock)
+			mock.ExpectCommit()
+
+			var got entity.DueReminder
+			claimed, err := r.ClaimDue(now, func(reminder entity.DueReminder) entity.DeliveryResult {
+				got = reminder
+				return tc.result
+			})
+
+			assert.NoError(t, err)
+			assert.True(t, claimed)
+			assert.Equal(t, 5, got.Id)
+			assert.Equal(t, "Pay rent", got.ItemTitle)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestReminder_ClaimDue_Empty(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewReminder(sqlxDB)
+	now := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT r.id, (.+) FOR UPDATE OF r SKIP LOCKED`).WithArgs(now).WillReturnError(sql.ErrNoRows)
+	mock.ExpectRollback()
+
+	claimed, err := r.ClaimDue(now, func(entity.DueReminder) entity.DeliveryResult {
+		t.Fatal("deliver не должен вызываться без сработавших напоминаний")
+		return entity.DeliveryResult{}
+	})
+
+	assert.NoError(t, err)
+	assert.False(t, claimed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
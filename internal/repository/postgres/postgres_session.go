@@ -0,0 +1,131 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type Session struct {
+	db *sqlx.DB
+}
+
+func NewSession(db *sqlx.DB) *Session {
+	return &Session{db: db}
+}
+
+func (r *Session) Create(session entity.Session, refreshToken entity.RefreshToken) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	createSessionQuery := fmt.Sprintf("INSERT INTO %s (user_id, user_agent, ip, expires_at) VALUES ($1, $2, $3, $4) RETURNING id;", sessionsTable)
+	row := tx.QueryRow(createSessionQuery, session.UserId, session.UserAgent, session.IP, session.ExpiresAt)
+	if err = row.Scan(&id); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	createTokenQuery := fmt.Sprintf("INSERT INTO %s (session_id, token_hash, expires_at) VALUES ($1, $2, $3);", refreshTokensTable)
+	_, err = tx.Exec(createTokenQuery, id, refreshToken.TokenHash, refreshToken.ExpiresAt)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
+
+func (r *Session) GetById(sessionId int) (entity.Session, error) {
+	var session entity.Session
+
+	query := fmt.Sprintf("SELECT id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at FROM %s WHERE id = $1;", sessionsTable)
+	err := r.db.Get(&session, query, sessionId)
+
+	return session, err
+}
+
+func (r *Session) GetAll(userId int) ([]entity.Session, error) {
+	var sessions []entity.Session
+
+	query := fmt.Sprintf(`SELECT id, user_id, user_agent, ip, created_at, last_seen_at, expires_at, revoked_at FROM %s
+								   WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+								   ORDER BY last_seen_at DESC;`, sessionsTable)
+	err := r.db.Select(&sessions, query, userId)
+
+	return sessions, err
+}
+
+func (r *Session) Touch(sessionId int) error {
+	query := fmt.Sprintf("UPDATE %s SET last_seen_at = now() WHERE id = $1;", sessionsTable)
+	_, err := r.db.Exec(query, sessionId)
+
+	return err
+}
+
+func (r *Session) GetRefreshToken(tokenHash string) (entity.RefreshToken, error) {
+	var token entity.RefreshToken
+
+	query := fmt.Sprintf(`SELECT rt.id, rt.session_id, s.user_id, rt.token_hash, rt.expires_at, rt.used_at, s.revoked_at FROM %s AS rt
+								   INNER JOIN %s AS s ON s.id = rt.session_id
+								   WHERE rt.token_hash = $1;`, refreshTokensTable, sessionsTable)
+	err := r.db.Get(&token, query, tokenHash)
+
+	return token, err
+}
+
+// RotateRefreshToken помечает старый refresh-токен использованным и выпускает новый в той же сессии.
+// Если старый токен уже был использован (в том числе параллельным запросом), возвращается sql.ErrNoRows.
+func (r *Session) RotateRefreshToken(oldTokenId int, newToken entity.RefreshToken) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	useTokenQuery := fmt.Sprintf("UPDATE %s SET used_at = now() WHERE id = $1 AND used_at IS NULL;", refreshTokensTable)
+	res, err := tx.Exec(useTokenQuery, oldTokenId)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+		_ = tx.Rollback()
+		if err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+
+	createTokenQuery := fmt.Sprintf("INSERT INTO %s (session_id, token_hash, expires_at) VALUES ($1, $2, $3);", refreshTokensTable)
+	_, err = tx.Exec(createTokenQuery, newToken.SessionId, newToken.TokenHash, newToken.ExpiresAt)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	extendSessionQuery := fmt.Sprintf("UPDATE %s SET expires_at = $1, last_seen_at = now() WHERE id = $2;", sessionsTable)
+	_, err = tx.Exec(extendSessionQuery, newToken.ExpiresAt, newToken.SessionId)
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (r *Session) Revoke(sessionId int) error {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at = now() WHERE id = $1 AND revoked_at IS NULL;", sessionsTable)
+	_, err := r.db.Exec(query, sessionId)
+
+	return err
+}
+
+func (r *Session) RevokeAll(userId int) error {
+	query := fmt.Sprintf("UPDATE %s SET revoked_at = now() WHERE user_id = $1 AND revoked_at IS NULL;", sessionsTable)
+	_, err := r.db.Exec(query, userId)
+
+	return err
+}
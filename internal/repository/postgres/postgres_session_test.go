@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestSession_Create(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewSession(sqlxDB)
+
+	expiresAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type args struct {
+		session entity.Session
+		token   entity.RefreshToken
+	}
+	type mockBehavior func(args args, id int)
+
+	tt := []struct {
+		name         string
+		mockBehavior mockBehavior
+		args         args
+		id           int
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			args: args{
+				session: entity.Session{UserId: 1, UserAgent: "curl/8.0", IP: "127.0.0.1", ExpiresAt: expiresAt},
+				token:   entity.RefreshToken{TokenHash: "hash", ExpiresAt: expiresAt},
+			},
+			id: 2,
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery("INSERT INTO sessions").WithArgs(args.session.UserId, args.session.UserAgent, args.session.IP, args.session.ExpiresAt).
+					WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO refresh_tokens").WithArgs(id, args.token.TokenHash, args.token.ExpiresAt).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "2nd Insert Rollback",
+			args: args{
+				session: entity.Session{UserId: 1, UserAgent: "curl/8.0", IP: "127.0.0.1", ExpiresAt: expiresAt},
+				token:   entity.RefreshToken{TokenHash: "hash", ExpiresAt: expiresAt},
+			},
+			id: 2,
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
+				mock.ExpectQuery("INSERT INTO sessions").WithArgs(args.session.UserId, args.session.UserAgent, args.session.IP, args.session.ExpiresAt).
+					WillReturnRows(rows)
+
+				mock.ExpectExec("INSERT INTO refresh_tokens").WithArgs(id, args.token.TokenHash, args.token.ExpiresAt).
+					WillReturnError(errors.New("some error"))
+
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior(tc.args, tc.id)
+
+			got, err := r.Create(tc.args.session, tc.args.token)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.id, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSession_GetRefreshToken(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewSession(sqlxDB)
+
+	expiresAt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name             string
+		mockBehavior     func()
+		tokenHash        string
+		expectedResponse entity.RefreshToken
+		wantErr          bool
+	}{
+		{
+			name:      "Ok",
+			tokenHash: "hash",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "session_id", "user_id", "token_hash", "expires_at", "used_at", "revoked_at"}).
+					AddRow(1, 2, 3, "hash", expiresAt, nil, nil)
+				mock.ExpectQuery("SELECT (.+) FROM refresh_tokens AS rt INNER JOIN sessions AS s (.+) WHERE rt.token_hash = (.+)").
+					WithArgs("hash").WillReturnRows(rows)
+			},
+			expectedResponse: entity.RefreshToken{
+				Id:        1,
+				SessionId: 2,
+				UserId:    3,
+				TokenHash: "hash",
+				ExpiresAt: expiresAt,
+			},
+		},
+		{
+			name:      "Not Found",
+			tokenHash: "hash",
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM refresh_tokens AS rt INNER JOIN sessions AS s (.+) WHERE rt.token_hash = (.+)").
+					WithArgs("hash").WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			got, err := r.GetRefreshToken(tc.tokenHash)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResponse, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSession_RotateRefreshToken(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewSession(sqlxDB)
+
+	newToken := entity.RefreshToken{
+		SessionId: 2,
+		TokenHash: "new hash",
+		ExpiresAt: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE refresh_tokens SET used_at = now\\(\\) WHERE id = (.+) AND used_at IS NULL").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO refresh_tokens").
+					WithArgs(newToken.SessionId, newToken.TokenHash, newToken.ExpiresAt).WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectExec("UPDATE sessions SET expires_at").
+					WithArgs(newToken.ExpiresAt, newToken.SessionId).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Already Used",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE refresh_tokens SET used_at = now\\(\\) WHERE id = (.+) AND used_at IS NULL").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			wantErr: sql.ErrNoRows,
+		},
+		{
+			name: "Bad Connection",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE refresh_tokens SET used_at = now\\(\\) WHERE id = (.+) AND used_at IS NULL").
+					WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("INSERT INTO refresh_tokens").
+					WithArgs(newToken.SessionId, newToken.TokenHash, newToken.ExpiresAt).WillReturnError(driver.ErrBadConn)
+				mock.ExpectRollback()
+			},
+			wantErr: driver.ErrBadConn,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.RotateRefreshToken(1, newToken)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSession_GetAll(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewSession(sqlxDB)
+
+	ts := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name             string
+		mockBehavior     func()
+		userId           int
+		expectedResponse []entity.Session
+		wantErr          bool
+	}{
+		{
+			name:   "Ok",
+			userId: 1,
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "user_agent", "ip", "created_at", "last_seen_at", "expires_at", "revoked_at"}).
+					AddRow(1, 1, "curl/8.0", "127.0.0.1", ts, ts, ts, nil).
+					AddRow(2, 1, "Firefox", "10.0.0.1", ts, ts, ts, nil)
+				mock.ExpectQuery("SELECT (.+) FROM sessions WHERE user_id = (.+) AND revoked_at IS NULL").
+					WithArgs(1).WillReturnRows(rows)
+			},
+			expectedResponse: []entity.Session{
+				{Id: 1, UserId: 1, UserAgent: "curl/8.0", IP: "127.0.0.1", CreatedAt: ts, LastSeenAt: ts, ExpiresAt: ts},
+				{Id: 2, UserId: 1, UserAgent: "Firefox", IP: "10.0.0.1", CreatedAt: ts, LastSeenAt: ts, ExpiresAt: ts},
+			},
+		},
+		{
+			name:   "Bad Connection",
+			userId: 1,
+			mockBehavior: func() {
+				mock.ExpectQuery("SELECT (.+) FROM sessions WHERE user_id = (.+) AND revoked_at IS NULL").
+					WithArgs(1).WillReturnError(driver.ErrBadConn)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			got, err := r.GetAll(tc.userId)
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.expectedResponse, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestSession_Revoke(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewSession(sqlxDB)
+
+	mock.ExpectExec("UPDATE sessions SET revoked_at = now\\(\\) WHERE id = (.+) AND revoked_at IS NULL").
+		WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	assert.NoError(t, r.Revoke(1))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,12 +1,21 @@
 package repository
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"strings"
 )
 
+// itemColumns выбирает поля задачи вместе с её метками, собранными в JSON-массив, чтобы не делать отдельный запрос на каждую задачу.
+var itemColumns = fmt.Sprintf(`ti.id, ti.title, ti.description, ti.done, ti.start_at, ti.due_at, ti.all_day, ti.rrule, ti.priority, ti.parent_id, ti.assignee_id,
+	COALESCE((SELECT json_agg(json_build_object('id', l.id, 'name', l.name, 'color', l.color) ORDER BY l.name)
+		FROM %s AS il INNER JOIN %s AS l ON l.id = il.label_id WHERE il.item_id = ti.id), '[]') AS labels`,
+	itemLabelsTable, labelsTable)
+
 type TodoItem struct {
 	db *sqlx.DB
 }
@@ -16,14 +25,24 @@ func NewTodoItem(db *sqlx.DB) *TodoItem {
 }
 
 func (r *TodoItem) Create(listId int, input entity.TodoItem) (int, error) {
-	tx, err := r.db.Begin()
+	tx, err := r.db.Beginx()
 	if err != nil {
 		return 0, err
 	}
 
+	if err = lockList(tx, listId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
 	var itemId int
-	createItemQuery := fmt.Sprintf("INSERT INTO %s (title, description) VALUES ($1, $2) RETURNING id;", todoItemsTable)
-	row := tx.QueryRow(createItemQuery, input.Title, input.Description)
+	// Новая задача встаёт последней среди задач того же родителя
+	createItemQuery := fmt.Sprintf(`INSERT INTO %s (title, description, start_at, due_at, all_day, rrule, priority, parent_id, position)
+									VALUES ($1, $2, $3, $4, $5, $6, $7, $8, (SELECT COALESCE(MAX(ti.position), 0) + %d FROM %s AS ti
+										INNER JOIN %s AS li ON li.item_id = ti.id WHERE li.list_id = $9 AND ti.parent_id IS NOT DISTINCT FROM $8))
+									RETURNING id;`, todoItemsTable, positionGap, todoItemsTable, listsItemsTable)
+	row := tx.QueryRow(createItemQuery, input.Title, input.Description, input.StartAt, input.DueAt, input.AllDay, input.RRule,
+		input.Priority, input.ParentId, listId)
 	if err = row.Scan(&itemId); err != nil {
 		_ = tx.Rollback()
 		return 0, err
@@ -39,29 +58,102 @@ func (r *TodoItem) Create(listId int, input entity.TodoItem) (int, error) {
 	return itemId, tx.Commit()
 }
 
-func (r *TodoItem) GetAll(userId, listId int) ([]entity.TodoItem, error) {
+// GetAll возвращает задачи списка в порядке обхода дерева: каждая подзадача идёт сразу после своего родителя,
+// задачи одного родителя упорядочены по позиции.
+// Глубина и прогресс считаются по всему дереву списка, фильтр применяется уже к результату обхода.
+func (r *TodoItem) GetAll(userId, listId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
 	var items []entity.TodoItem
 
-	query := fmt.Sprintf("SELECT ti.id, ti.title, ti.description, ti.done FROM %s AS ti "+
+	conditions, args := itemFilterConditions(filter, []interface{}{userId, listId})
+	query := fmt.Sprintf(`WITH RECURSIVE tree AS (
+									SELECT ti.id, 0 AS depth, ARRAY[ti.id] AS path, ARRAY[ti.position, ti.id] AS sort FROM %s AS ti
+									INNER JOIN %s AS li ON li.item_id = ti.id
+									WHERE li.list_id = $2 AND ti.parent_id IS NULL
+								UNION ALL
+									SELECT ti.id, tree.depth + 1, tree.path || ti.id, tree.sort || ARRAY[ti.position, ti.id] FROM %s AS ti
+									INNER JOIN tree ON ti.parent_id = tree.id
+									WHERE NOT ti.id = ANY(tree.path)
+								)
+								SELECT tree.depth, %s,
+									(SELECT avg(d.done::int)::float8 FROM tree AS sub INNER JOIN %s AS d ON d.id = sub.id
+										WHERE ti.id = ANY(sub.path) AND sub.id <> ti.id) AS progress
+								FROM tree INNER JOIN %s AS ti ON ti.id = tree.id
+								INNER JOIN %s AS li ON li.item_id = ti.id
+								INNER JOIN %s AS ul ON ul.list_id = li.list_id
+								WHERE ul.user_id = $1 AND ul.list_id = $2%s
+								ORDER BY tree.sort;`,
+		todoItemsTable, listsItemsTable, todoItemsTable, itemColumns, todoItemsTable,
+		todoItemsTable, listsItemsTable, usersListsTable, conditions)
+	if err := r.db.Select(&items, query, args...); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// Search ищет задачи во всех списках пользователя.
+func (r *TodoItem) Search(userId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	conditions, args := itemFilterConditions(filter, []interface{}{userId})
+	query := fmt.Sprintf("SELECT li.list_id, %s FROM %s AS ti "+
 		"INNER JOIN %s AS li ON li.item_id = ti.id "+
 		"INNER JOIN %s AS ul ON ul.list_id = li.list_id "+
-		"WHERE ul.user_id = $1 AND ul.list_id = $2;",
-		todoItemsTable, listsItemsTable, usersListsTable)
-	if err := r.db.Select(&items, query, userId, listId); err != nil {
+		"WHERE ul.user_id = $1%s ORDER BY ti.priority DESC, ti.id;",
+		itemColumns, todoItemsTable, listsItemsTable, usersListsTable, conditions)
+
+	items := make([]entity.TodoItem, 0)
+	if err := r.db.Select(&items, query, args...); err != nil {
 		return nil, err
 	}
 
 	return items, nil
 }
 
+// itemFilterConditions дописывает к args параметры фильтра и возвращает условия для WHERE, начинающиеся с AND.
+func itemFilterConditions(filter entity.ItemFilter, args []interface{}) (string, []interface{}) {
+	conditions := make([]string, 0)
+
+	if len(filter.Labels) > 0 {
+		names := make([]string, len(filter.Labels))
+		for i, name := range filter.Labels {
+			names[i] = strings.ToLower(entity.NormalizeLabelName(name))
+		}
+		args = append(args, pq.Array(names))
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM %s AS il INNER JOIN %s AS l ON l.id = il.label_id "+
+			"WHERE il.item_id = ti.id AND lower(l.name) = ANY($%d))", itemLabelsTable, labelsTable, len(args)))
+	}
+
+	if len(filter.Priorities) > 0 {
+		priorities := make([]int64, len(filter.Priorities))
+		for i, priority := range filter.Priorities {
+			priorities[i] = int64(priority)
+		}
+		args = append(args, pq.Array(priorities))
+		conditions = append(conditions, fmt.Sprintf("ti.priority = ANY($%d)", len(args)))
+	}
+
+	if filter.Query != "" {
+		args = append(args, "%"+escapeLike(filter.Query)+"%")
+		conditions = append(conditions, fmt.Sprintf("(ti.title ILIKE $%d OR ti.description ILIKE $%d)", len(args), len(args)))
+	}
+
+	if len(conditions) == 0 {
+		return "", args
+	}
+	return " AND " + strings.Join(conditions, " AND "), args
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
+
 func (r *TodoItem) GetById(userId, itemId int) (entity.TodoItem, error) {
 	var item entity.TodoItem
 
-	query := fmt.Sprintf("SELECT ti.id, ti.title, ti.description, ti.done FROM %s AS ti "+
+	query := fmt.Sprintf("SELECT li.list_id, %s FROM %s AS ti "+
 		"INNER JOIN %s AS li ON li.item_id = ti.id "+
 		"INNER JOIN %s AS ul ON ul.list_id = li.list_id "+
 		"WHERE ul.user_id = $1 AND ti.id = $2;",
-		todoItemsTable, listsItemsTable, usersListsTable)
+		itemColumns, todoItemsTable, listsItemsTable, usersListsTable)
 	err := r.db.Get(&item, query, userId, itemId)
 
 	return item, err
@@ -90,12 +182,54 @@ func (r *TodoItem) Update(userId, itemId int, input entity.UpdateItemInput) erro
 		argId++
 	}
 
+	if input.StartAt.Set {
+		setValues = append(setValues, fmt.Sprintf("start_at=$%d", argId))
+		args = append(args, input.StartAt.Time)
+		argId++
+	}
+
+	if input.DueAt.Set {
+		setValues = append(setValues, fmt.Sprintf("due_at=$%d", argId))
+		args = append(args, input.DueAt.Time)
+		argId++
+	}
+
+	if input.AllDay != nil {
+		setValues = append(setValues, fmt.Sprintf("all_day=$%d", argId))
+		args = append(args, *input.AllDay)
+		argId++
+	}
+
+	if input.RRule != nil {
+		setValues = append(setValues, fmt.Sprintf("rrule=$%d", argId))
+		args = append(args, *input.RRule)
+		argId++
+	}
+
+	if input.Priority != nil {
+		setValues = append(setValues, fmt.Sprintf("priority=$%d", argId))
+		args = append(args, *input.Priority)
+		argId++
+	}
+
+	if input.ParentId.Set {
+		setValues = append(setValues, fmt.Sprintf("parent_id=$%d", argId))
+		args = append(args, input.ParentId.Value)
+		argId++
+	}
+
+	if input.AssigneeId.Set {
+		setValues = append(setValues, fmt.Sprintf("assignee_id=$%d", argId))
+		args = append(args, input.AssigneeId.Value)
+		argId++
+	}
+
 	setQuery := strings.Join(setValues, ", ")
 
 	query := fmt.Sprintf(`UPDATE %s AS ti SET %s 
 									FROM %s AS ul, %s AS li 
-									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $%d AND ti.id = $%d`,
-		todoItemsTable, setQuery, usersListsTable, listsItemsTable, argId, argId+1)
+									WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $%d AND ti.id = $%d AND %s`,
+		todoItemsTable, setQuery, usersListsTable, listsItemsTable, argId, argId+1, canEditCondition)
 
 	args = append(args, userId, itemId)
 
@@ -104,10 +238,380 @@ func (r *TodoItem) Update(userId, itemId int, input entity.UpdateItemInput) erro
 	return err
 }
 
-func (r *TodoItem) Delete(userId, itemId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s AS ti USING %s as ul, %s as li WHERE  ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $1 AND ti.id = $2;`,
-		todoItemsTable, usersListsTable, listsItemsTable)
-	_, err := r.db.Exec(query, userId, itemId)
+// Delete удаляет задачу вместе с подзадачами. С keepChildren подзадачи сначала переносятся к родителю удаляемой задачи
+// и встают после его последней подзадачи в прежнем порядке.
+func (r *TodoItem) Delete(userId, itemId int, keepChildren bool) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if keepChildren {
+		var listId int
+		listQuery := fmt.Sprintf("SELECT list_id FROM %s WHERE item_id = $1;", listsItemsTable)
+		if err = tx.Get(&listId, listQuery, itemId); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err = lockList(tx, listId); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+
+		reparentQuery := fmt.Sprintf(`UPDATE %[1]s AS ti SET parent_id = p.parent_id,
+										position = (SELECT COALESCE(MAX(s.position), 0) FROM %[1]s AS s INNER JOIN %[2]s AS li ON li.item_id = s.id
+											WHERE li.list_id = $2 AND s.parent_id IS NOT DISTINCT FROM p.parent_id AND s.id <> p.id) + c.rank * %[3]d
+									FROM %[1]s AS p, (SELECT id, ROW_NUMBER() OVER (ORDER BY position, id) AS rank FROM %[1]s WHERE parent_id = $1) AS c
+									WHERE p.id = $1 AND ti.id = c.id;`, todoItemsTable, listsItemsTable, positionGap)
+		if _, err = tx.Exec(reparentQuery, itemId, listId); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	query := fmt.Sprintf(`DELETE FROM %s AS ti USING %s as ul, %s as li WHERE  ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = $1 AND ti.id = $2 AND %s;`,
+		todoItemsTable, usersListsTable, listsItemsTable, canEditCondition)
+	if _, err = tx.Exec(query, userId, itemId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Move ставит задачу перед якорем или после него, задача становится подзадачей того же родителя, что и якорь.
+// Список и задачи этого родителя блокируются на время перестановки, поэтому одновременные перемещения и вставки выполняются по очереди.
+// Если якоря нет, возвращает sql.ErrNoRows.
+func (r *TodoItem) Move(itemId int, move entity.MoveInput) error {
+	anchorId, after := move.Anchor()
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var anchor struct {
+		ListId   int  `db:"list_id"`
+		ParentId *int `db:"parent_id"`
+	}
+	anchorQuery := fmt.Sprintf("SELECT li.list_id, ti.parent_id FROM %s AS ti INNER JOIN %s AS li ON li.item_id = ti.id WHERE ti.id = $1;",
+		todoItemsTable, listsItemsTable)
+	if err = tx.Get(&anchor, anchorQuery, anchorId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err = lockList(tx, anchor.ListId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	var siblings []rankedRow
+	siblingsQuery := fmt.Sprintf(`SELECT ti.id, ti.position FROM %s AS ti INNER JOIN %s AS li ON li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.parent_id IS NOT DISTINCT FROM $2
+									ORDER BY ti.position, ti.id FOR UPDATE OF ti;`, todoItemsTable, listsItemsTable)
+	if err = tx.Select(&siblings, siblingsQuery, anchor.ListId, anchor.ParentId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	// Якорь мог сменить родителя, пока строки не были заблокированы
+	positions, ok := placeRanked(siblings, itemId, anchorId, after)
+	if !ok {
+		_ = tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE %s SET position = $1 WHERE id = $2;", todoItemsTable)
+	moveQuery := fmt.Sprintf("UPDATE %s SET position = $1, parent_id = $2 WHERE id = $3;", todoItemsTable)
+	for _, row := range positions {
+		if row.Id == itemId {
+			_, err = tx.Exec(moveQuery, row.Position, anchor.ParentId, row.Id)
+		} else {
+			_, err = tx.Exec(updateQuery, row.Position, row.Id)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetSubtreeIds возвращает идентификаторы задачи и всех её подзадач на любой глубине.
+func (r *TodoItem) GetSubtreeIds(itemId int) ([]int, error) {
+	ids := make([]int, 0)
+
+	query := fmt.Sprintf("%s SELECT id FROM subtree;", subtreeCTE)
+	if err := r.db.Select(&ids, query, itemId); err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// MoveToList переносит задачу вместе с подзадачами в другой список. Задача становится последней среди задач верхнего уровня.
+func (r *TodoItem) MoveToList(itemId, listId int) error {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	if err = lockList(tx, listId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	detachQuery := fmt.Sprintf(`UPDATE %s SET parent_id = NULL, position = (SELECT COALESCE(MAX(ti.position), 0) + %d FROM %s AS ti
+									INNER JOIN %s AS li ON li.item_id = ti.id WHERE li.list_id = $2 AND ti.parent_id IS NULL)
+									WHERE id = $1;`, todoItemsTable, positionGap, todoItemsTable, listsItemsTable)
+	if _, err = tx.Exec(detachQuery, itemId, listId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	moveQuery := fmt.Sprintf("%s UPDATE %s SET list_id = $2 WHERE item_id IN (SELECT id FROM subtree);", subtreeCTE, listsItemsTable)
+	if _, err = tx.Exec(moveQuery, itemId, listId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	// Исполнители, которые не состоят в новом списке, снимаются с задач
+	unassignQuery := fmt.Sprintf(`%s UPDATE %s SET assignee_id = NULL WHERE id IN (SELECT id FROM subtree)
+									AND assignee_id NOT IN (SELECT user_id FROM %s WHERE list_id = $2);`,
+		subtreeCTE, todoItemsTable, usersListsTable)
+	if _, err = tx.Exec(unassignQuery, itemId, listId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Copy копирует задачу вместе с подзадачами и метками в список listId под родителя parentId.
+// Копия встаёт последней среди задач этого родителя, подзадачи сохраняют свой порядок. Возвращает id копии.
+func (r *TodoItem) Copy(itemId, listId int, parentId *int) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	var items []itemCopy
+	selectQuery := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id, 1 AS level FROM %s WHERE id = $1
+								UNION ALL
+									SELECT ti.id, subtree.level + 1 FROM %s AS ti INNER JOIN subtree ON ti.parent_id = subtree.id
+								)
+								SELECT %s FROM subtree INNER JOIN %s AS ti ON ti.id = subtree.id
+								ORDER BY subtree.level, ti.id;`, todoItemsTable, todoItemsTable, itemCopyColumns, todoItemsTable)
+	if err = tx.Select(&items, selectQuery, itemId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	if len(items) == 0 {
+		_ = tx.Rollback()
+		return 0, sql.ErrNoRows
+	}
+	if err = lockList(tx, listId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	positionQuery := fmt.Sprintf(`SELECT COALESCE(MAX(ti.position), 0) + %d FROM %s AS ti INNER JOIN %s AS li ON li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.parent_id IS NOT DISTINCT FROM $2;`, positionGap, todoItemsTable, listsItemsTable)
+	if err = tx.Get(&items[0].Position, positionQuery, listId, parentId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	items[0].ParentId = parentId
+
+	copies, err := insertItemCopies(tx, items, listId, true)
+	if err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return copies[itemId], tx.Commit()
+}
+
+// itemCopy - задача вместе с позицией, которую нужно сохранить у копии.
+type itemCopy struct {
+	entity.TodoItem
+	Position int64 `db:"position"`
+}
+
+const itemCopyColumns = "ti.id, ti.title, ti.description, ti.done, ti.start_at, ti.due_at, ti.all_day, ti.rrule, ti.priority, ti.parent_id, ti.position"
+
+// listTreeCTE выбирает в tree задачи списка $1 по уровням вложенности.
+var listTreeCTE = fmt.Sprintf(`WITH RECURSIVE tree AS (
+									SELECT ti.id, 1 AS level FROM %s AS ti INNER JOIN %s AS li ON li.item_id = ti.id
+									WHERE li.list_id = $1 AND ti.parent_id IS NULL
+								UNION ALL
+									SELECT ti.id, tree.level + 1 FROM %s AS ti INNER JOIN tree ON ti.parent_id = tree.id
+								)`, todoItemsTable, listsItemsTable, todoItemsTable)
+
+// insertItemCopies создаёт в списке listId копии задач, а при copyLabels и их метки. Задачи должны идти по уровням, чтобы копия
+// родителя создавалась раньше копий подзадач. Родитель, которого среди items нет, остаётся как есть. Возвращает id копий по исходным id.
+func insertItemCopies(tx *sqlx.Tx, items []itemCopy, listId int, copyLabels bool) (map[int]int, error) {
+	createQuery := fmt.Sprintf(`INSERT INTO %s (title, description, done, start_at, due_at, all_day, rrule, priority, parent_id, position)
+									VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) RETURNING id;`, todoItemsTable)
+	createListItemsQuery := fmt.Sprintf("INSERT INTO %s (list_id, item_id) VALUES ($1, $2);", listsItemsTable)
+	copyLabelsQuery := fmt.Sprintf("INSERT INTO %s (item_id, label_id) SELECT $1, label_id FROM %s WHERE item_id = $2;", itemLabelsTable, itemLabelsTable)
+
+	copies := make(map[int]int, len(items))
+	for _, item := range items {
+		parentId := item.ParentId
+		if parentId != nil {
+			if copyParentId, ok := copies[*parentId]; ok {
+				parentId = &copyParentId
+			}
+		}
+
+		var copyId int
+		row := tx.QueryRow(createQuery, item.Title, item.Description, item.Done, item.StartAt, item.DueAt, item.AllDay,
+			item.RRule, item.Priority, parentId, item.Position)
+		if err := row.Scan(&copyId); err != nil {
+			return nil, err
+		}
+		copies[item.Id] = copyId
+
+		if _, err := tx.Exec(createListItemsQuery, listId, copyId); err != nil {
+			return nil, err
+		}
+		if !copyLabels {
+			continue
+		}
+		if _, err := tx.Exec(copyLabelsQuery, copyId, item.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return copies, nil
+}
+
+// subtreeCTE выбирает задачу $1 и все её подзадачи в subtree.
+var subtreeCTE = fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id FROM %s WHERE id = $1
+								UNION
+									SELECT ti.id FROM %s AS ti INNER JOIN subtree ON ti.parent_id = subtree.id
+								)`, todoItemsTable, todoItemsTable)
+
+// CompleteSubtree отмечает выполненными все подзадачи на любой глубине.
+func (r *TodoItem) CompleteSubtree(itemId int) error {
+	query := fmt.Sprintf(`WITH RECURSIVE subtree AS (
+									SELECT id FROM %s WHERE parent_id = $1
+								UNION
+									SELECT ti.id FROM %s AS ti INNER JOIN subtree ON ti.parent_id = subtree.id
+								)
+								UPDATE %s SET done = true WHERE id IN (SELECT id FROM subtree) AND NOT done;`,
+		todoItemsTable, todoItemsTable, todoItemsTable)
+	_, err := r.db.Exec(query, itemId)
 
 	return err
 }
+
+// Reschedule переносит повторяющуюся задачу на следующее повторение: ставит новые сроки и правило для оставшейся серии
+// и снимает отметку о выполнении с задачи и всех её подзадач.
+func (r *TodoItem) Reschedule(itemId int, occurrence entity.Occurrence) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE %s SET done = false, start_at = $2, due_at = $3, rrule = $4 WHERE id = $1;", todoItemsTable)
+	if _, err = tx.Exec(query, itemId, occurrence.StartAt, occurrence.DueAt, occurrence.RRule); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	resetQuery := fmt.Sprintf("%s UPDATE %s SET done = false WHERE id IN (SELECT id FROM subtree) AND done;", subtreeCTE, todoItemsTable)
+	if _, err = tx.Exec(resetQuery, itemId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// CompleteParents поднимается от задачи вверх по дереву и отмечает выполненным каждого родителя,
+// у которого не осталось невыполненных подзадач. Останавливается на первом родителе, у которого они есть.
+func (r *TodoItem) CompleteParents(itemId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s AS p SET done = true FROM %s AS c
+									WHERE c.id = $1 AND p.id = c.parent_id AND NOT p.done
+									AND NOT EXISTS (SELECT 1 FROM %s AS s WHERE s.parent_id = p.id AND NOT s.done)
+									RETURNING p.id;`, todoItemsTable, todoItemsTable, todoItemsTable)
+	for {
+		var parentId int
+		err = tx.QueryRow(query, itemId).Scan(&parentId)
+		if errors.Is(err, sql.ErrNoRows) {
+			break
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		itemId = parentId
+	}
+
+	return tx.Commit()
+}
+
+// GetRole возвращает роль пользователя в списке, которому принадлежит задача, или sql.ErrNoRows.
+func (r *TodoItem) GetRole(userId, itemId int) (string, error) {
+	var role string
+
+	query := fmt.Sprintf("SELECT ul.role FROM %s AS ul INNER JOIN %s AS li ON li.list_id = ul.list_id WHERE ul.user_id = $1 AND li.item_id = $2;",
+		usersListsTable, listsItemsTable)
+	err := r.db.Get(&role, query, userId, itemId)
+
+	return role, err
+}
+
+// GetDue возвращает невыполненные задачи из всех списков пользователя со сроком в заданном интервале.
+func (r *TodoItem) GetDue(userId int, due entity.DueRange) ([]entity.TodoItem, error) {
+	timedConditions := []string{"NOT ti.all_day", "ti.due_at < $2"}
+	allDayConditions := []string{"ti.all_day", "ti.due_at < $3"}
+	args := []interface{}{userId, due.To, due.ToDate}
+	argId := 4
+
+	if !due.From.IsZero() {
+		timedConditions = append(timedConditions, fmt.Sprintf("ti.due_at >= $%d", argId))
+		allDayConditions = append(allDayConditions, fmt.Sprintf("ti.due_at >= $%d", argId+1))
+		args = append(args, due.From, due.FromDate)
+	}
+
+	query := fmt.Sprintf(`SELECT li.list_id, %s
+									FROM %s AS ti INNER JOIN %s AS li ON li.item_id = ti.id INNER JOIN %s AS ul ON ul.list_id = li.list_id
+									WHERE ul.user_id = $1 AND NOT ti.done AND ((%s) OR (%s))
+									ORDER BY ti.due_at, ti.id;`,
+		itemColumns, todoItemsTable, listsItemsTable, usersListsTable,
+		strings.Join(timedConditions, " AND "), strings.Join(allDayConditions, " AND "))
+
+	items := make([]entity.TodoItem, 0)
+	if err := r.db.Select(&items, query, args...); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// GetAssigned возвращает невыполненные задачи из всех списков пользователя, назначенные на него.
+func (r *TodoItem) GetAssigned(userId int) ([]entity.TodoItem, error) {
+	query := fmt.Sprintf(`SELECT li.list_id, %s
+									FROM %s AS ti INNER JOIN %s AS li ON li.item_id = ti.id INNER JOIN %s AS ul ON ul.list_id = li.list_id
+									WHERE ul.user_id = $1 AND ti.assignee_id = $1 AND NOT ti.done
+									ORDER BY ti.due_at NULLS LAST, ti.id;`,
+		itemColumns, todoItemsTable, listsItemsTable, usersListsTable)
+
+	items := make([]entity.TodoItem, 0)
+	if err := r.db.Select(&items, query, userId); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
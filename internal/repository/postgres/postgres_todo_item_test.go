@@ -9,6 +9,7 @@ import (
 	"github.com/jmoiron/sqlx"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestTodoItem_Create(t *testing.T) {
@@ -45,9 +46,11 @@ func TestTodoItem_Create(t *testing.T) {
 			id: 2,
 			mockBehavior: func(args args, id int) {
 				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(args.listId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
-				mock.ExpectQuery("INSERT INTO todo_items").WithArgs(args.item.Title, args.item.Description).
+				mock.ExpectQuery("INSERT INTO todo_items").WithArgs(args.item.Title, args.item.Description, args.item.StartAt, args.item.DueAt, args.item.AllDay, args.item.RRule, args.item.Priority, args.item.ParentId, args.listId).
 					WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO list_items").WithArgs(args.listId, id).
@@ -81,14 +84,33 @@ func TestTodoItem_Create(t *testing.T) {
 			},
 			mockBehavior: func(args args, id int) {
 				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(args.listId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
 
-				mock.ExpectQuery("INSERT INTO todo_items").WithArgs(args.item.Title, args.item.Description).
+				mock.ExpectQuery("INSERT INTO todo_items").WithArgs(args.item.Title, args.item.Description, args.item.StartAt, args.item.DueAt, args.item.AllDay, args.item.RRule, args.item.Priority, args.item.ParentId, args.listId).
 					WillReturnError(errors.New("some error"))
 
 				mock.ExpectRollback()
 			},
 			wantErr: true,
 		},
+		{
+			name: "List not found",
+			args: args{
+				listId: 1,
+				item: entity.TodoItem{
+					Title:       "test title",
+					Description: "test desc",
+				},
+			},
+			mockBehavior: func(args args, id int) {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(args.listId).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
 		{
 			name: "2nd Insert Rollback",
 			args: args{
@@ -101,9 +123,11 @@ func TestTodoItem_Create(t *testing.T) {
 			id: 2,
 			mockBehavior: func(args args, id int) {
 				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(args.listId).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(args.listId))
 
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(id)
-				mock.ExpectQuery("INSERT INTO todo_items").WithArgs(args.item.Title, args.item.Description).
+				mock.ExpectQuery("INSERT INTO todo_items").WithArgs(args.item.Title, args.item.Description, args.item.StartAt, args.item.DueAt, args.item.AllDay, args.item.RRule, args.item.Priority, args.item.ParentId, args.listId).
 					WillReturnRows(rows)
 
 				mock.ExpectExec("INSERT INTO list_items").WithArgs(args.listId, id).
@@ -126,6 +150,7 @@ func TestTodoItem_Create(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tc.id, got)
 			}
+			assert.NoError(t, mock.ExpectationsWereMet())
 		})
 	}
 }
@@ -141,22 +166,26 @@ func TestTodoItem_GetAll(t *testing.T) {
 
 	type mockBehavior func(userId, listId int)
 
+	progress := 0.5
+	parentId := 1
+
 	tt := []struct {
 		name             string
 		mockBehavior     mockBehavior
 		userId           int
 		listId           int
+		filter           entity.ItemFilter
 		expectedResponse []entity.TodoItem
 		wantErr          bool
 	}{
 		{
 			name: "Ok",
 			mockBehavior: func(userId, listId int) {
-				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"}).
-					AddRow(1, "test title 1", "test desc 1", false).
-					AddRow(2, "test title 2", "test desc 2", false).
-					AddRow(3, "test title 3", "test desc 3", false)
-				mock.ExpectQuery(`SELECT (.+) FROM todo_items AS ti 
+				rows := sqlmock.NewRows([]string{"depth", "id", "title", "description", "done", "parent_id", "progress"}).
+					AddRow(0, 1, "test title 1", "test desc 1", false, nil, 0.5).
+					AddRow(1, 2, "test title 2", "test desc 2", true, 1, nil).
+					AddRow(1, 3, "test title 3", "test desc 3", false, 1, nil)
+				mock.ExpectQuery(`WITH RECURSIVE tree AS \((.+)\) SELECT tree.depth, (.+) FROM tree INNER JOIN todo_items AS ti ON ti.id = tree.id 
 												INNER JOIN list_items AS li ON li.item_id = ti.id 
 												INNER JOIN user_lists AS ul ON ul.list_id = li.list_id 
 												WHERE (.+)`).
@@ -165,16 +194,35 @@ func TestTodoItem_GetAll(t *testing.T) {
 			userId: 1,
 			listId: 1,
 			expectedResponse: []entity.TodoItem{
-				{Id: 1, Title: "test title 1", Description: "test desc 1", Done: false},
-				{Id: 2, Title: "test title 2", Description: "test desc 2", Done: false},
-				{Id: 3, Title: "test title 3", Description: "test desc 3", Done: false},
+				{Id: 1, Title: "test title 1", Description: "test desc 1", Done: false, Progress: &progress},
+				{Id: 2, Title: "test title 2", Description: "test desc 2", Done: true, ParentId: &parentId, Depth: 1},
+				{Id: 3, Title: "test title 3", Description: "test desc 3", Done: false, ParentId: &parentId, Depth: 1},
+			},
+		},
+		{
+			name: "Ok_Filter",
+			mockBehavior: func(userId, listId int) {
+				rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "priority", "labels"}).
+					AddRow(1, "test title 1", "test desc 1", false, 3, []byte(`[{"id":2,"name":"work","color":"#ff0000"}]`))
+				mock.ExpectQuery(`WITH RECURSIVE tree AS \((.+)\) SELECT tree.depth, (.+) FROM tree INNER JOIN todo_items AS ti ON ti.id = tree.id 
+												INNER JOIN list_items AS li ON li.item_id = ti.id 
+												INNER JOIN user_lists AS ul ON ul.list_id = li.list_id 
+												WHERE ul.user_id = \$1 AND ul.list_id = \$2 AND EXISTS \((.+)lower\(l.name\) = ANY\(\$3\)\) AND ti.priority = ANY\(\$4\)`).
+					WithArgs(1, 1, "{\"work\"}", "{3}").WillReturnRows(rows)
+			},
+			userId: 1,
+			listId: 1,
+			filter: entity.ItemFilter{Labels: []string{"#Work"}, Priorities: []entity.Priority{entity.PriorityHigh}},
+			expectedResponse: []entity.TodoItem{
+				{Id: 1, Title: "test title 1", Description: "test desc 1", Priority: entity.PriorityHigh,
+					Labels: entity.LabelList{{Id: 2, Name: "work", Color: "#ff0000"}}},
 			},
 		},
 		{
 			name: "Empty Items",
 			mockBehavior: func(userId, listId int) {
 				rows := sqlmock.NewRows([]string{"id", "title", "description", "done"})
-				mock.ExpectQuery(`SELECT (.+) FROM todo_items AS ti 
+				mock.ExpectQuery(`WITH RECURSIVE tree AS \((.+)\) SELECT tree.depth, (.+) FROM tree INNER JOIN todo_items AS ti ON ti.id = tree.id 
 												INNER JOIN list_items AS li ON li.item_id = ti.id 
 												INNER JOIN user_lists AS ul ON ul.list_id = li.list_id 
 												WHERE (.+)`).
@@ -191,7 +239,7 @@ func TestTodoItem_GetAll(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior(tc.userId, tc.listId)
 
-			got, err := r.GetAll(tc.userId, tc.listId)
+			got, err := r.GetAll(tc.userId, tc.listId, tc.filter)
 			if tc.wantErr {
 				assert.Error(t, err)
 				assert.Equal(t, tc.expectedResponse, got)
@@ -288,8 +336,9 @@ func TestTodoItem_Delete(t *testing.T) {
 	r := NewTodoItem(sqlxDB)
 
 	type args struct {
-		userId int
-		itemId int
+		userId       int
+		itemId       int
+		keepChildren bool
 	}
 	type mockBehavior func()
 
@@ -303,26 +352,55 @@ func TestTodoItem_Delete(t *testing.T) {
 		{
 			name: "Ok",
 			mockBehavior: func() {
+				mock.ExpectBegin()
 				mock.ExpectExec(`DELETE FROM todo_items AS ti USING user_lists as ul, list_items as li 
 												WHERE  ti.id = li.item_id AND 
 														li.list_id = ul.list_id AND 
 														(.+);`).
 					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
 			},
 			args: args{
 				userId: 1,
 				itemId: 1,
 			},
 		},
+		{
+			name: "Ok_KeepChildren",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(`SELECT list_id FROM list_items WHERE item_id = \$1`).WithArgs(1).
+					WillReturnRows(sqlmock.NewRows([]string{"list_id"}).AddRow(3))
+				mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(3).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+				// Подзадачи встают после последней подзадачи нового родителя с исходным шагом и в прежнем порядке
+				mock.ExpectExec(`UPDATE todo_items AS ti SET parent_id = p.parent_id, ` +
+					`position = \(SELECT COALESCE\(MAX\(s.position\), 0\) FROM todo_items AS s INNER JOIN list_items AS li ON li.item_id = s.id ` +
+					`WHERE li.list_id = \$2 AND s.parent_id IS NOT DISTINCT FROM p.parent_id AND s.id <> p.id\) \+ c.rank \* 65536 ` +
+					`FROM todo_items AS p, \(SELECT id, ROW_NUMBER\(\) OVER \(ORDER BY position, id\) AS rank FROM todo_items WHERE parent_id = \$1\) AS c ` +
+					`WHERE p.id = \$1 AND ti.id = c.id`).
+					WithArgs(1, 3).WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectExec(`DELETE FROM todo_items AS ti (.+)`).
+					WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			args: args{
+				userId:       1,
+				itemId:       1,
+				keepChildren: true,
+			},
+		},
 		{
 			name: "Bad Connection",
 			mockBehavior: func() {
+				mock.ExpectBegin()
 
 				mock.ExpectExec(`DELETE FROM todo_items AS ti USING user_lists as ul, list_items as li 
 												WHERE  ti.id = li.item_id AND 
 														li.list_id = ul.list_id AND 
 														(.+);`).
 					WithArgs(1, -1).WillReturnError(driver.ErrBadConn)
+				mock.ExpectRollback()
 			},
 			args: args{
 				userId: 1,
@@ -336,7 +414,7 @@ func TestTodoItem_Delete(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			tc.mockBehavior()
 
-			err := r.Delete(tc.args.userId, tc.args.itemId)
+			err := r.Delete(tc.args.userId, tc.args.itemId, tc.args.keepChildren)
 			if tc.wantErr {
 				assert.Error(t, err)
 			} else {
@@ -388,6 +466,20 @@ func TestTodoItem_Update(t *testing.T) {
 				input:  entity.UpdateItemInput{Title: &testTitle, Description: &testDesc, Done: &testDone},
 			},
 		},
+		{
+			name: "Ok_ClearDueAt",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items AS ti SET due_at=(.+) 
+												FROM user_lists AS ul, list_items AS li 
+												WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = (.+) AND ti.id = (.+)`).
+					WithArgs(nil, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			args: args{
+				userId: 1,
+				itemId: 1,
+				input:  entity.UpdateItemInput{DueAt: entity.NullableTime{Set: true}},
+			},
+		},
 		{
 			name: "Ok_Title",
 			mockBehavior: func() {
@@ -430,6 +522,20 @@ func TestTodoItem_Update(t *testing.T) {
 				input:  entity.UpdateItemInput{Done: &testDone},
 			},
 		},
+		{
+			name: "Ok_Unassign",
+			mockBehavior: func() {
+				mock.ExpectExec(`UPDATE todo_items AS ti SET assignee_id=(.+) 
+												FROM user_lists AS ul, list_items AS li 
+												WHERE ti.id = li.item_id AND li.list_id = ul.list_id AND ul.user_id = (.+) AND ti.id = (.+)`).
+					WithArgs(nil, 1, 1).WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			args: args{
+				userId: 1,
+				itemId: 1,
+				input:  entity.UpdateItemInput{AssigneeId: entity.NullableInt{Set: true}},
+			},
+		},
 		{
 			name: "Bad Connection",
 			mockBehavior: func() {
@@ -461,3 +567,201 @@ func TestTodoItem_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoItem_GetDue(t *testing.T) {
+	mockDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf(err.Error())
+	}
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	from := time.Date(2023, 5, 1, 21, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	fromDate := time.Date(2023, 5, 2, 0, 0, 0, 0, time.UTC)
+	toDate := fromDate.Add(24 * time.Hour)
+	dueAt := from.Add(time.Hour)
+
+	columns := []string{"id", "list_id", "title", "description", "done", "start_at", "due_at", "all_day"}
+
+	t.Run("Range", func(t *testing.T) {
+		mock.ExpectQuery(`SELECT li.list_id, ti.id, (.+) WHERE ul.user_id = \$1 AND NOT ti.done AND \(\(NOT ti.all_day AND ti.due_at < \$2 AND ti.due_at >= \$4\) OR \(ti.all_day AND ti.due_at < \$3 AND ti.due_at >= \$5\)\)`).
+			WithArgs(1, to, toDate, from, fromDate).
+			WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 2, "Item", "", false, nil, dueAt, false))
+
+		got, err := r.GetDue(1, entity.DueRange{From: from, To: to, FromDate: fromDate, ToDate: toDate})
+		assert.NoError(t, err)
+		assert.Equal(t, []entity.TodoItem{{Id: 3, ListId: 2, Title: "Item", DueAt: &dueAt}}, got)
+	})
+
+	t.Run("Open start", func(t *testing.T) {
+		mock.ExpectQuery(`\(\(NOT ti.all_day AND ti.due_at < \$2\) OR \(ti.all_day AND ti.due_at < \$3\)\)`).
+			WithArgs(1, to, toDate).
+			WillReturnRows(sqlmock.NewRows(columns))
+
+		got, err := r.GetDue(1, entity.DueRange{To: to, ToDate: toDate})
+		assert.NoError(t, err)
+		assert.Empty(t, got)
+	})
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_GetAssigned(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	rows := sqlmock.NewRows([]string{"id", "list_id", "title", "description", "done", "assignee_id"}).
+		AddRow(3, 2, "Item", "", false, 1)
+	mock.ExpectQuery(`SELECT li.list_id, ti.id, (.+) WHERE ul.user_id = \$1 AND ti.assignee_id = \$1 AND NOT ti.done ORDER BY ti.due_at NULLS LAST, ti.id`).
+		WithArgs(1).WillReturnRows(rows)
+
+	assignee := 1
+	got, err := r.GetAssigned(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.TodoItem{{Id: 3, ListId: 2, Title: "Item", AssigneeId: &assignee}}, got)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_CompleteParents(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	query := `UPDATE todo_items AS p SET done = true FROM todo_items AS c WHERE c.id = \$1 AND p.id = c.parent_id AND NOT p.done AND NOT EXISTS (.+) RETURNING p.id`
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(query).WithArgs(7).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(6))
+	mock.ExpectQuery(query).WithArgs(6).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectQuery(query).WithArgs(5).WillReturnError(sql.ErrNoRows)
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.CompleteParents(7))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_Reschedule(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	dueAt := time.Date(2023, 5, 8, 6, 0, 0, 0, time.UTC)
+	occurrence := entity.Occurrence{DueAt: &dueAt, RRule: "FREQ=WEEKLY;COUNT=2"}
+
+	mock.ExpectBegin()
+	mock.ExpectExec(`UPDATE todo_items SET done = false, start_at = \$2, due_at = \$3, rrule = \$4 WHERE id = \$1`).
+		WithArgs(5, occurrence.StartAt, occurrence.DueAt, "FREQ=WEEKLY;COUNT=2").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`WITH RECURSIVE subtree AS \((.+)\) UPDATE todo_items SET done = false WHERE id IN \(SELECT id FROM subtree\) AND done`).
+		WithArgs(5).WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.Reschedule(5, occurrence))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_MoveToList(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectExec(`UPDATE todo_items SET parent_id = NULL, position = \(SELECT (.+) WHERE li.list_id = \$2 AND ti.parent_id IS NULL\) WHERE id = \$1`).
+		WithArgs(5, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`WITH RECURSIVE subtree AS \((.+)\) UPDATE list_items SET list_id = \$2 WHERE item_id IN \(SELECT id FROM subtree\)`).
+		WithArgs(5, 3).WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectExec(`WITH RECURSIVE subtree AS \((.+)\) UPDATE todo_items SET assignee_id = NULL (.+) NOT IN \(SELECT user_id FROM user_lists WHERE list_id = \$2\)`).
+		WithArgs(5, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	assert.NoError(t, r.MoveToList(5, 3))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_Move(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	// Список блокируется раньше задач, как и при вставке, поэтому новая задача не появится посреди перестановки
+	mock.ExpectBegin()
+	mock.ExpectQuery(`SELECT li.list_id, ti.parent_id FROM todo_items AS ti (.+) WHERE ti.id = \$1`).WithArgs(6).
+		WillReturnRows(sqlmock.NewRows([]string{"list_id", "parent_id"}).AddRow(3, nil))
+	mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery(`SELECT ti.id, ti.position FROM todo_items (.+) ORDER BY ti.position, ti.id FOR UPDATE OF ti`).WithArgs(3, nil).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "position"}).AddRow(5, 65536).AddRow(6, 131072).AddRow(7, 196608))
+	mock.ExpectExec(`UPDATE todo_items SET position = \$1, parent_id = \$2 WHERE id = \$3`).WithArgs(int64(163840), nil, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	anchorId := 6
+	assert.NoError(t, r.Move(5, entity.MoveInput{AfterId: &anchorId}))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTodoItem_Copy(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoItem(sqlxDB)
+
+	mock.ExpectBegin()
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "start_at", "due_at", "all_day", "rrule", "priority", "parent_id", "position"}).
+		AddRow(5, "Trip", "", false, nil, nil, false, "FREQ=YEARLY", 2, nil, 65536).
+		AddRow(6, "Tickets", "", true, nil, nil, false, "", 0, 5, 131072)
+	mock.ExpectQuery(`WITH RECURSIVE subtree AS \((.+)\) SELECT (.+) FROM subtree (.+) ORDER BY subtree.level, ti.id`).
+		WithArgs(5).WillReturnRows(rows)
+	mock.ExpectQuery(`SELECT id FROM todo_lists WHERE id = \$1 FOR NO KEY UPDATE`).WithArgs(3).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+	mock.ExpectQuery(`SELECT COALESCE\(MAX\(ti.position\), 0\) \+ 65536 FROM todo_items (.+) ti.parent_id IS NOT DISTINCT FROM \$2`).
+		WithArgs(3, nil).WillReturnRows(sqlmock.NewRows([]string{"position"}).AddRow(196608))
+
+	mock.ExpectQuery(`INSERT INTO todo_items (.+) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10\) RETURNING id`).
+		WithArgs("Trip", "", false, nil, nil, false, "FREQ=YEARLY", entity.PriorityMedium, nil, int64(196608)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(10))
+	mock.ExpectExec("INSERT INTO list_items").WithArgs(3, 10).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(`INSERT INTO item_labels \(item_id, label_id\) SELECT \$1, label_id FROM item_labels WHERE item_id = \$2`).
+		WithArgs(10, 5).WillReturnResult(sqlmock.NewResult(0, 2))
+
+	mock.ExpectQuery(`INSERT INTO todo_items (.+) VALUES \(\$1, \$2, \$3, \$4, \$5, \$6, \$7, \$8, \$9, \$10\) RETURNING id`).
+		WithArgs("Tickets", "", true, nil, nil, false, "", entity.PriorityNone, 10, int64(131072)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(11))
+	mock.ExpectExec("INSERT INTO list_items").WithArgs(3, 11).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO item_labels").WithArgs(11, 6).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	id, err := r.Copy(5, 3, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 10, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -1,6 +1,7 @@
 package repository
 
 import (
+	"database/sql"
 	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/jmoiron/sqlx"
@@ -29,7 +30,8 @@ func (r *TodoList) Create(userId int, input entity.TodoList) (int, error) {
 		return 0, err
 	}
 
-	createUserLists := fmt.Sprintf("INSERT INTO %s (user_id, list_id) VALUES ($1, $2);", usersListsTable)
+	createUserLists := fmt.Sprintf("INSERT INTO %s (user_id, list_id, role, position) VALUES ($1, $2, '%s', %s);",
+		usersListsTable, entity.ListRoleOwner, nextListPosition)
 	_, err = tx.Exec(createUserLists, userId, id)
 	if err != nil {
 		_ = tx.Rollback()
@@ -42,7 +44,7 @@ func (r *TodoList) Create(userId int, input entity.TodoList) (int, error) {
 func (r *TodoList) GetAll(userId int) ([]entity.TodoList, error) {
 	var lists []entity.TodoList
 
-	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description FROM %s AS tl INNER JOIN %s AS ul ON tl.id = ul.list_id WHERE ul.user_id = $1;",
+	query := fmt.Sprintf("SELECT tl.id, tl.title, tl.description, ul.role FROM %s AS tl INNER JOIN %s AS ul ON tl.id = ul.list_id WHERE ul.user_id = $1 ORDER BY ul.position, tl.id;",
 		todoListsTable, usersListsTable)
 	err := r.db.Select(&lists, query, userId)
 
@@ -52,7 +54,7 @@ func (r *TodoList) GetAll(userId int) ([]entity.TodoList, error) {
 func (r *TodoList) GetById(userId, listId int) (entity.TodoList, error) {
 	var list entity.TodoList
 
-	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description FROM %s AS tl 
+	query := fmt.Sprintf(`SELECT tl.id, tl.title, tl.description, ul.role FROM %s AS tl 
 								   INNER JOIN %s AS ul ON tl.id = ul.list_id 
 								   WHERE ul.user_id = $1 AND tl.id = $2;`, todoListsTable, usersListsTable)
 	err := r.db.Get(&list, query, userId, listId)
@@ -79,8 +81,8 @@ func (r *TodoList) Update(userId, listId int, input entity.UpdateListInput) erro
 
 	setQuery := strings.Join(setValues, ", ")
 
-	query := fmt.Sprintf(`UPDATE %s AS tl SET %s FROM %s AS ul WHERE tl.id = ul.list_id AND ul.user_id = $%d AND ul.list_id = $%d`,
-		todoListsTable, setQuery, usersListsTable, argId, argId+1)
+	query := fmt.Sprintf(`UPDATE %s AS tl SET %s FROM %s AS ul WHERE tl.id = ul.list_id AND ul.user_id = $%d AND ul.list_id = $%d AND %s`,
+		todoListsTable, setQuery, usersListsTable, argId, argId+1, canEditCondition)
 
 	args = append(args, userId, listId)
 	_, err := r.db.Exec(query, args...)
@@ -89,9 +91,95 @@ func (r *TodoList) Update(userId, listId int, input entity.UpdateListInput) erro
 }
 
 func (r *TodoList) Delete(userId, listId int) error {
-	query := fmt.Sprintf(`DELETE FROM %s AS tl USING %s as ul WHERE tl.id = ul.list_id AND ul.user_id = $1 AND ul.list_id = $2;`,
-		todoListsTable, usersListsTable)
+	query := fmt.Sprintf(`DELETE FROM %s AS tl USING %s as ul WHERE tl.id = ul.list_id AND ul.user_id = $1 AND ul.list_id = $2 AND ul.role = '%s';`,
+		todoListsTable, usersListsTable, entity.ListRoleOwner)
 	_, err := r.db.Exec(query, userId, listId)
 
 	return err
 }
+
+// GetRole возвращает роль пользователя в списке или sql.ErrNoRows, если он не участник.
+func (r *TodoList) GetRole(userId, listId int) (string, error) {
+	var role string
+
+	query := fmt.Sprintf("SELECT role FROM %s WHERE user_id = $1 AND list_id = $2;", usersListsTable)
+	err := r.db.Get(&role, query, userId, listId)
+
+	return role, err
+}
+
+// Move переставляет список в боковой панели пользователя. Строки пользователя блокируются на время перестановки,
+// поэтому одновременные перемещения выполняются по очереди. Если списка или якоря нет среди списков пользователя, возвращает sql.ErrNoRows.
+func (r *TodoList) Move(userId, listId int, move entity.MoveInput) error {
+	anchorId, after := move.Anchor()
+
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return err
+	}
+
+	var siblings []rankedRow
+	siblingsQuery := fmt.Sprintf("SELECT list_id AS id, position FROM %s WHERE user_id = $1 ORDER BY position, list_id FOR UPDATE;", usersListsTable)
+	if err = tx.Select(&siblings, siblingsQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	positions, ok := placeRanked(siblings, listId, anchorId, after)
+	if !ok || !containsRow(siblings, listId) {
+		_ = tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	updateQuery := fmt.Sprintf("UPDATE %s SET position = $1 WHERE user_id = $2 AND list_id = $3;", usersListsTable)
+	for _, row := range positions {
+		if _, err = tx.Exec(updateQuery, row.Position, userId, row.Id); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Duplicate создаёт копию списка со всеми задачами, подзадачами и метками. Задачи в копии не выполнены,
+// владельцем копии становится userId, копия получает название title.
+func (r *TodoList) Duplicate(userId, listId int, title string) (int, error) {
+	tx, err := r.db.Beginx()
+	if err != nil {
+		return 0, err
+	}
+
+	var id int
+	createListQuery := fmt.Sprintf("INSERT INTO %s (title, description) SELECT $2, description FROM %s WHERE id = $1 RETURNING id;",
+		todoListsTable, todoListsTable)
+	if err = tx.Get(&id, createListQuery, listId, title); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	createUserLists := fmt.Sprintf("INSERT INTO %s (user_id, list_id, role, position) VALUES ($1, $2, '%s', %s);",
+		usersListsTable, entity.ListRoleOwner, nextListPosition)
+	if _, err = tx.Exec(createUserLists, userId, id); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	var items []itemCopy
+	itemsQuery := fmt.Sprintf("%s SELECT %s FROM tree INNER JOIN %s AS ti ON ti.id = tree.id ORDER BY tree.level, ti.id;",
+		listTreeCTE, itemCopyColumns, todoItemsTable)
+	if err = tx.Select(&items, itemsQuery, listId); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+	for i := range items {
+		items[i].Done = false
+	}
+
+	if _, err = insertItemCopies(tx, items, id, true); err != nil {
+		_ = tx.Rollback()
+		return 0, err
+	}
+
+	return id, tx.Commit()
+}
@@ -426,3 +426,41 @@ func TestTodoList_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestTodoList_Duplicate(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTodoList(sqlxDB)
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(`INSERT INTO todo_lists \(title, description\) SELECT \$2, description FROM todo_lists WHERE id = \$1`).
+		WithArgs(2, "Trip (copy)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+	mock.ExpectExec(`INSERT INTO user_lists \(user_id, list_id, role, position\) VALUES \(\$1, \$2, 'owner', (.+)\)`).
+		WithArgs(1, 5).WillReturnResult(sqlmock.NewResult(0, 1))
+	rows := sqlmock.NewRows([]string{"id", "title", "description", "done", "start_at", "due_at", "all_day", "priority", "parent_id", "position"}).
+		AddRow(7, "Trip", "", true, nil, nil, false, 0, nil, 65536).
+		AddRow(8, "Tickets", "", true, nil, nil, false, 0, 7, 65536)
+	mock.ExpectQuery(`WITH RECURSIVE tree AS \((.+)\) SELECT (.+) FROM tree INNER JOIN todo_items AS ti ON ti.id = tree.id ORDER BY tree.level, ti.id`).
+		WithArgs(2).WillReturnRows(rows)
+
+	mock.ExpectQuery("INSERT INTO todo_items").
+		WithArgs("Trip", "", false, nil, nil, false, "", entity.PriorityNone, nil, int64(65536)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(20))
+	mock.ExpectExec("INSERT INTO list_items").WithArgs(5, 20).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO item_labels").WithArgs(20, 7).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO todo_items").
+		WithArgs("Tickets", "", false, nil, nil, false, "", entity.PriorityNone, 20, int64(65536)).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(21))
+	mock.ExpectExec("INSERT INTO list_items").WithArgs(5, 21).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("INSERT INTO item_labels").WithArgs(21, 8).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	id, err := r.Duplicate(1, 2, "Trip (copy)")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
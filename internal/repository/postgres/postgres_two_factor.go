@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type TwoFactor struct {
+	db *sqlx.DB
+}
+
+func NewTwoFactor(db *sqlx.DB) *TwoFactor {
+	return &TwoFactor{db: db}
+}
+
+func (r *TwoFactor) Get(userId int) (entity.TwoFactor, error) {
+	var tf entity.TwoFactor
+
+	query := fmt.Sprintf("SELECT user_id, secret, enabled, last_used_step FROM %s WHERE user_id = $1;", twoFactorTable)
+	err := r.db.Get(&tf, query, userId)
+
+	return tf, err
+}
+
+// SetPending сохраняет секрет, ожидающий подтверждения первым кодом. Включённый второй фактор не перезаписывается.
+func (r *TwoFactor) SetPending(userId int, secret string) error {
+	query := fmt.Sprintf(`INSERT INTO %[1]s (user_id, secret) VALUES ($1, $2)
+								   ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, created_at = now()
+								   WHERE %[1]s.enabled = false;`, twoFactorTable)
+	res, err := r.db.Exec(query, userId, secret)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+func (r *TwoFactor) Enable(userId int, recoveryCodeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	enableQuery := fmt.Sprintf("UPDATE %s SET enabled = true WHERE user_id = $1;", twoFactorTable)
+	if _, err = tx.Exec(enableQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	deleteCodesQuery := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1;", recoveryCodesTable)
+	if _, err = tx.Exec(deleteCodesQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	createCodeQuery := fmt.Sprintf("INSERT INTO %s (user_id, code_hash) VALUES ($1, $2);", recoveryCodesTable)
+	for _, codeHash := range recoveryCodeHashes {
+		if _, err = tx.Exec(createCodeQuery, userId, codeHash); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (r *TwoFactor) Disable(userId int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	deleteCodesQuery := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1;", recoveryCodesTable)
+	if _, err = tx.Exec(deleteCodesQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE user_id = $1;", twoFactorTable)
+	if _, err = tx.Exec(deleteQuery, userId); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// UseStep запоминает шаг последнего принятого кода. Если код этого или более позднего шага
+// уже использовался, возвращается sql.ErrNoRows - так один код нельзя предъявить дважды.
+func (r *TwoFactor) UseStep(userId int, step int64) error {
+	query := fmt.Sprintf("UPDATE %s SET last_used_step = $1 WHERE user_id = $2 AND last_used_step < $1;", twoFactorTable)
+	res, err := r.db.Exec(query, step, userId)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+// UseRecoveryCode гасит резервный код. Если такого неиспользованного кода нет, возвращается sql.ErrNoRows.
+func (r *TwoFactor) UseRecoveryCode(userId int, codeHash string) error {
+	query := fmt.Sprintf("UPDATE %s SET used_at = now() WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;", recoveryCodesTable)
+	res, err := r.db.Exec(query, userId, codeHash)
+	if err != nil {
+		return err
+	}
+
+	return expectAffected(res)
+}
+
+func expectAffected(res sql.Result) error {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
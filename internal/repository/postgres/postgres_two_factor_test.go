@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTwoFactor_SetPending(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTwoFactor(sqlxDB)
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectExec("INSERT INTO two_factor (.+) ON CONFLICT \\(user_id\\) DO UPDATE (.+) WHERE two_factor.enabled = false").
+					WithArgs(1, "SECRET").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name: "Already enabled",
+			mockBehavior: func() {
+				mock.ExpectExec("INSERT INTO two_factor (.+) ON CONFLICT \\(user_id\\) DO UPDATE (.+) WHERE two_factor.enabled = false").
+					WithArgs(1, "SECRET").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.SetPending(1, "SECRET")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTwoFactor_Enable(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTwoFactor(sqlxDB)
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		wantErr      bool
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE two_factor SET enabled = true").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM recovery_codes").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO recovery_codes").WithArgs(1, "h1").WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec("INSERT INTO recovery_codes").WithArgs(1, "h2").WillReturnResult(sqlmock.NewResult(2, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "Rollback",
+			mockBehavior: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec("UPDATE two_factor SET enabled = true").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("DELETE FROM recovery_codes").WithArgs(1).WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectExec("INSERT INTO recovery_codes").WithArgs(1, "h1").WillReturnError(errors.New("some error"))
+				mock.ExpectRollback()
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			err := r.Enable(1, []string{"h1", "h2"})
+			if tc.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestTwoFactor_UseStep(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewTwoFactor(sqlxDB)
+
+	mock.ExpectExec("UPDATE two_factor SET last_used_step = (.+) AND last_used_step < (.+)").
+		WithArgs(int64(100), 1).WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec("UPDATE two_factor SET last_used_step = (.+) AND last_used_step < (.+)").
+		WithArgs(int64(100), 1).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	assert.NoError(t, r.UseStep(1, 100))
+	assert.ErrorIs(t, r.UseStep(1, 100), sql.ErrNoRows)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
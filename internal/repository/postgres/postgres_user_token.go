@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserToken struct {
+	db *sqlx.DB
+}
+
+func NewUserToken(db *sqlx.DB) *UserToken {
+	return &UserToken{db: db}
+}
+
+func (r *UserToken) Create(token entity.UserToken) error {
+	query := fmt.Sprintf("INSERT INTO %s (user_id, purpose, token_hash, email, expires_at) VALUES ($1, $2, $3, $4, $5);", userTokensTable)
+	_, err := r.db.Exec(query, token.UserId, token.Purpose, token.TokenHash, token.Email, token.ExpiresAt)
+
+	return err
+}
+
+// Consume гасит токен и возвращает его. Если токен не найден, уже использован или истёк, возвращается sql.ErrNoRows.
+func (r *UserToken) Consume(purpose, tokenHash string) (entity.UserToken, error) {
+	var token entity.UserToken
+
+	query := fmt.Sprintf(`UPDATE %s SET used_at = now()
+								   WHERE purpose = $1 AND token_hash = $2 AND used_at IS NULL AND expires_at > now()
+								   RETURNING id, user_id, purpose, token_hash, email, expires_at, used_at;`, userTokensTable)
+	err := r.db.Get(&token, query, purpose, tokenHash)
+
+	return token, err
+}
+
+func (r *UserToken) RevokeAll(userId int, purpose string) error {
+	query := fmt.Sprintf("UPDATE %s SET used_at = now() WHERE user_id = $1 AND purpose = $2 AND used_at IS NULL;", userTokensTable)
+	_, err := r.db.Exec(query, userId, purpose)
+
+	return err
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"database/sql"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestUserToken_Consume(t *testing.T) {
+	mockDB, mock, _ := sqlmock.New()
+	defer func(mockDB *sql.DB) {
+		_ = mockDB.Close()
+	}(mockDB)
+	sqlxDB := sqlx.NewDb(mockDB, "sqlmock")
+
+	r := NewUserToken(sqlxDB)
+
+	expiresAt := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	usedAt := expiresAt.Add(-time.Hour)
+
+	tt := []struct {
+		name         string
+		mockBehavior func()
+		want         entity.UserToken
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func() {
+				rows := sqlmock.NewRows([]string{"id", "user_id", "purpose", "token_hash", "email", "expires_at", "used_at"}).
+					AddRow(1, 2, entity.UserTokenResetPassword, "hash", "user@example.com", expiresAt, usedAt)
+				mock.ExpectQuery("UPDATE user_tokens SET used_at = now\\(\\) WHERE (.+) used_at IS NULL AND expires_at > now\\(\\)").
+					WithArgs(entity.UserTokenResetPassword, "hash").WillReturnRows(rows)
+			},
+			want: entity.UserToken{
+				Id:        1,
+				UserId:    2,
+				Purpose:   entity.UserTokenResetPassword,
+				TokenHash: "hash",
+				Email:     "user@example.com",
+				ExpiresAt: expiresAt,
+				UsedAt:    &usedAt,
+			},
+		},
+		{
+			name: "Used or expired",
+			mockBehavior: func() {
+				mock.ExpectQuery("UPDATE user_tokens SET used_at = now\\(\\)").
+					WithArgs(entity.UserTokenResetPassword, "hash").WillReturnError(sql.ErrNoRows)
+			},
+			wantErr: sql.ErrNoRows,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.mockBehavior()
+
+			got, err := r.Consume(entity.UserTokenResetPassword, "hash")
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tc.want, got)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -8,15 +8,49 @@ import (
 type (
 	Repository struct {
 		Authorization
+		Session
+		PersonalAccessToken
+		TwoFactor
+		UserToken
+		ExternalIdentity
+		LoginAttempt
+		Audit
 		TodoList
+		ListMember
+		ListInvitation
+		InviteLink
+		PublicLink
 		TodoItem
+		Label
+		ListTemplate
+		Reminder
+		Notification
+		Comment
+		Attachment
 	}
 )
 
 func NewRepository(db *sqlx.DB) *Repository {
 	return &Repository{
-		Authorization: repository.NewAuth(db),
-		TodoList:      repository.NewTodoList(db),
-		TodoItem:      repository.NewTodoItem(db),
+		Authorization:       repository.NewAuth(db),
+		Session:             repository.NewSession(db),
+		PersonalAccessToken: repository.NewPersonalAccessToken(db),
+		TwoFactor:           repository.NewTwoFactor(db),
+		UserToken:           repository.NewUserToken(db),
+		ExternalIdentity:    repository.NewExternalIdentity(db),
+		LoginAttempt:        repository.NewLoginAttempt(db),
+		Audit:               repository.NewAudit(db),
+		TodoList:            repository.NewTodoList(db),
+		ListMember:          repository.NewListMember(db),
+		ListInvitation:      repository.NewListInvitation(db),
+		InviteLink:          repository.NewInviteLink(db),
+		PublicLink:          repository.NewPublicLink(db),
+		TodoItem:            repository.NewTodoItem(db),
+		Label:               repository.NewLabel(db),
+		ListTemplate:        repository.NewListTemplate(db),
+		Reminder:            repository.NewReminder(db),
+		Notification:        repository.NewNotification(db),
+		Comment:             repository.NewComment(db),
+		Attachment:          repository.NewAttachment(db),
 	}
 }
@@ -0,0 +1,586 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Frequency - значение FREQ. Частоты меньше суток не поддерживаются: у задач срок не чаще раза в день.
+type Frequency int
+
+const (
+	Daily Frequency = iota + 1
+	Weekly
+	Monthly
+	Yearly
+)
+
+var frequencies = map[string]Frequency{"DAILY": Daily, "WEEKLY": Weekly, "MONTHLY": Monthly, "YEARLY": Yearly}
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday, "MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday,
+	"TH": time.Thursday, "FR": time.Friday, "SA": time.Saturday,
+}
+
+var ErrInvalidRule = errors.New("invalid rrule")
+
+// horizonYears ограничивает поиск следующего повторения для правил, которые больше никогда не срабатывают
+// (например, 30 февраля). За 400 лет григорианский календарь проходит полный цикл.
+const horizonYears = 400
+
+// WeekdayNum - элемент BYDAY: день недели с необязательным номером (1FR - первая пятница, -1FR - последняя).
+type WeekdayNum struct {
+	Weekday time.Weekday
+	N       int
+}
+
+// Rule - правило повторения RFC 5545 с частями FREQ, INTERVAL, COUNT, UNTIL, BYMONTH, BYMONTHDAY, BYDAY, BYSETPOS и WKST.
+type Rule struct {
+	Freq       Frequency
+	Interval   int
+	Count      int
+	ByMonth    []time.Month
+	ByMonthDay []int
+	ByDay      []WeekdayNum
+	BySetPos   []int
+	WeekStart  time.Weekday
+
+	// until хранится как в правиле: UTC-момент, локальное время или дата, последние два - в зоне начала серии
+	until      time.Time
+	untilKind  untilKind
+	untilValue string
+}
+
+type untilKind int
+
+const (
+	untilNone untilKind = iota
+	untilUTC
+	untilLocal
+	untilDate
+)
+
+// Parse разбирает правило вида FREQ=WEEKLY;BYDAY=MO,FR. Префикс RRULE: допускается.
+func Parse(s string) (*Rule, error) {
+	s = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(s)), "RRULE:")
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty rule", ErrInvalidRule)
+	}
+
+	r := &Rule{Interval: 1, WeekStart: time.Monday}
+	seen := make(map[string]bool)
+	for _, part := range strings.Split(s, ";") {
+		name, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("%w: duplicate %s", ErrInvalidRule, name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			var ok bool
+			if r.Freq, ok = frequencies[value]; !ok {
+				err = fmt.Errorf("unsupported FREQ %s", value)
+			}
+		case "INTERVAL":
+			r.Interval, err = parseInt(value, 1, 1000)
+		case "COUNT":
+			r.Count, err = parseInt(value, 1, 10000)
+		case "UNTIL":
+			err = r.parseUntil(value)
+		case "BYMONTH":
+			err = parseList(value, func(v string) error {
+				month, err := parseInt(v, 1, 12)
+				r.ByMonth = append(r.ByMonth, time.Month(month))
+				return err
+			})
+		case "BYMONTHDAY":
+			err = parseList(value, func(v string) error {
+				day, err := parseSignedInt(v, 31)
+				r.ByMonthDay = append(r.ByMonthDay, day)
+				return err
+			})
+		case "BYDAY":
+			err = parseList(value, func(v string) error {
+				day, err := parseWeekdayNum(v)
+				r.ByDay = append(r.ByDay, day)
+				return err
+			})
+		case "BYSETPOS":
+			err = parseList(value, func(v string) error {
+				pos, err := parseSignedInt(v, 366)
+				r.BySetPos = append(r.BySetPos, pos)
+				return err
+			})
+		case "WKST":
+			var ok bool
+			if r.WeekStart, ok = weekdays[value]; !ok {
+				err = fmt.Errorf("unknown weekday %s", value)
+			}
+		default:
+			err = fmt.Errorf("unsupported part %s", name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidRule, err)
+		}
+	}
+
+	if err := r.validate(); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidRule, err)
+	}
+	return r, nil
+}
+
+func (r *Rule) validate() error {
+	if r.Freq == 0 {
+		return errors.New("FREQ is required")
+	}
+	if r.Count > 0 && r.untilKind != untilNone {
+		return errors.New("COUNT and UNTIL must not be used together")
+	}
+	if r.Freq == Weekly && len(r.ByMonthDay) > 0 {
+		return errors.New("BYMONTHDAY must not be used with FREQ=WEEKLY")
+	}
+	if r.Freq == Daily || r.Freq == Weekly {
+		for _, day := range r.ByDay {
+			if day.N != 0 {
+				return errors.New("numbered BYDAY is only allowed with FREQ=MONTHLY or FREQ=YEARLY")
+			}
+		}
+	}
+	if len(r.BySetPos) > 0 && len(r.ByMonth)+len(r.ByMonthDay)+len(r.ByDay) == 0 {
+		return errors.New("BYSETPOS requires another BYxxx part")
+	}
+	return nil
+}
+
+func (r *Rule) parseUntil(value string) error {
+	layouts := []struct {
+		layout string
+		kind   untilKind
+	}{
+		{"20060102T150405Z", untilUTC},
+		{"20060102T150405", untilLocal},
+		{"20060102", untilDate},
+	}
+	for _, l := range layouts {
+		if t, err := time.Parse(l.layout, value); err == nil {
+			r.until, r.untilKind, r.untilValue = t, l.kind, value
+			return nil
+		}
+	}
+	return fmt.Errorf("malformed UNTIL %s", value)
+}
+
+func parseList(value string, parse func(v string) error) error {
+	for _, v := range strings.Split(value, ",") {
+		if err := parse(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseInt(value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max || strings.HasPrefix(value, "+") {
+		return 0, fmt.Errorf("value %s out of range %d..%d", value, min, max)
+	}
+	return n, nil
+}
+
+// parseSignedInt принимает значения ±1..max, отрицательные считаются с конца.
+func parseSignedInt(value string, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n == 0 || n < -max || n > max {
+		return 0, fmt.Errorf("value %s out of range ±1..%d", value, max)
+	}
+	return n, nil
+}
+
+func parseWeekdayNum(value string) (WeekdayNum, error) {
+	if len(value) < 2 {
+		return WeekdayNum{}, fmt.Errorf("unknown weekday %s", value)
+	}
+	weekday, ok := weekdays[value[len(value)-2:]]
+	if !ok {
+		return WeekdayNum{}, fmt.Errorf("unknown weekday %s", value)
+	}
+
+	day := WeekdayNum{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		n, err := parseSignedInt(prefix, 53)
+		if err != nil {
+			return WeekdayNum{}, err
+		}
+		day.N = n
+	}
+	return day, nil
+}
+
+// String возвращает правило в каноническом виде, UNTIL записывается как было задано.
+func (r *Rule) String() string {
+	parts := []string{"FREQ=" + frequencyName(r.Freq)}
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+	if r.untilKind != untilNone {
+		parts = append(parts, "UNTIL="+r.untilValue)
+	}
+	if len(r.ByMonth) > 0 {
+		values := make([]string, len(r.ByMonth))
+		for i, month := range r.ByMonth {
+			values[i] = strconv.Itoa(int(month))
+		}
+		parts = append(parts, "BYMONTH="+strings.Join(values, ","))
+	}
+	if len(r.ByMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.ByMonthDay))
+	}
+	if len(r.ByDay) > 0 {
+		values := make([]string, len(r.ByDay))
+		for i, day := range r.ByDay {
+			values[i] = weekdayName(day.Weekday)
+			if day.N != 0 {
+				values[i] = strconv.Itoa(day.N) + values[i]
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(values, ","))
+	}
+	if len(r.BySetPos) > 0 {
+		parts = append(parts, "BYSETPOS="+joinInts(r.BySetPos))
+	}
+	if r.WeekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayName(r.WeekStart))
+	}
+	return strings.Join(parts, ";")
+}
+
+func frequencyName(freq Frequency) string {
+	for name, f := range frequencies {
+		if f == freq {
+			return name
+		}
+	}
+	return ""
+}
+
+func weekdayName(weekday time.Weekday) string {
+	for name, w := range weekdays {
+		if w == weekday {
+			return name
+		}
+	}
+	return ""
+}
+
+func joinInts(values []int) string {
+	s := make([]string, len(values))
+	for i, v := range values {
+		s[i] = strconv.Itoa(v)
+	}
+	return strings.Join(s, ",")
+}
+
+// Iterator перебирает повторения серии по возрастанию. Первым всегда идёт начало серии,
+// как DTSTART в RFC 5545, и оно же учитывается в COUNT.
+type Iterator struct {
+	rule    *Rule
+	start   time.Time
+	until   time.Time
+	emitted int
+	period  int
+	pending []time.Time
+	done    bool
+}
+
+// Iter начинает серию в момент start. Повторения сохраняют время суток start в его зоне,
+// поэтому при переходе на летнее время задача остаётся на том же часе по местному времени.
+func (r *Rule) Iter(start time.Time) *Iterator {
+	it := &Iterator{rule: r, start: start}
+
+	loc := start.Location()
+	switch r.untilKind {
+	case untilUTC:
+		it.until = r.until
+	case untilLocal:
+		u := r.until
+		it.until = time.Date(u.Year(), u.Month(), u.Day(), u.Hour(), u.Minute(), u.Second(), 0, loc)
+	case untilDate:
+		// Дата включается целиком
+		u := r.until
+		it.until = time.Date(u.Year(), u.Month(), u.Day()+1, 0, 0, 0, 0, loc).Add(-time.Nanosecond)
+	}
+	return it
+}
+
+func (it *Iterator) Next() (time.Time, bool) {
+	if it.done {
+		return time.Time{}, false
+	}
+	if it.emitted == 0 {
+		it.emitted++
+		return it.start, true
+	}
+	if it.rule.Count > 0 && it.emitted >= it.rule.Count {
+		it.done = true
+		return time.Time{}, false
+	}
+
+	for len(it.pending) == 0 {
+		if it.period > horizonYears*periodsPerYear(it.rule.Freq)/it.rule.Interval {
+			it.done = true
+			return time.Time{}, false
+		}
+		for _, t := range it.rule.period(it.start, it.period) {
+			if t.After(it.start) {
+				it.pending = append(it.pending, t)
+			}
+		}
+		it.period++
+	}
+
+	t := it.pending[0]
+	it.pending = it.pending[1:]
+	if !it.until.IsZero() && t.After(it.until) {
+		it.done = true
+		return time.Time{}, false
+	}
+	it.emitted++
+	return t, true
+}
+
+// Take возвращает не больше n первых повторений серии, начатой в start.
+func (r *Rule) Take(start time.Time, n int) []time.Time {
+	occurrences := make([]time.Time, 0, n)
+	it := r.Iter(start)
+	for len(occurrences) < n {
+		t, ok := it.Next()
+		if !ok {
+			break
+		}
+		occurrences = append(occurrences, t)
+	}
+	return occurrences
+}
+
+// Next возвращает повторение, следующее за началом серии start, и правило для серии, начатой с него:
+// COUNT в нём уменьшен на одно прошедшее повторение.
+func (r *Rule) Next(start time.Time) (time.Time, *Rule, bool) {
+	it := r.Iter(start)
+	it.Next()
+	next, ok := it.Next()
+	if !ok {
+		return time.Time{}, nil, false
+	}
+
+	rest := *r
+	if rest.Count > 0 {
+		rest.Count--
+	}
+	return next, &rest, true
+}
+
+func periodsPerYear(freq Frequency) int {
+	switch freq {
+	case Daily:
+		return 366
+	case Weekly:
+		return 53
+	case Monthly:
+		return 12
+	default:
+		return 1
+	}
+}
+
+// period возвращает повторения k-го периода серии (дня, недели, месяца или года с учётом INTERVAL) по возрастанию.
+func (r *Rule) period(start time.Time, k int) []time.Time {
+	first := date(start.Year(), start.Month(), start.Day())
+
+	var days []time.Time
+	switch r.Freq {
+	case Daily:
+		day := first.AddDate(0, 0, k*r.Interval)
+		days = r.filter([]time.Time{day}, true, true)
+	case Weekly:
+		offset := (int(first.Weekday()) - int(r.WeekStart) + 7) % 7
+		weekStart := first.AddDate(0, 0, k*r.Interval*7-offset)
+		for i := 0; i < 7; i++ {
+			day := weekStart.AddDate(0, 0, i)
+			if len(r.ByDay) > 0 || day.Weekday() == first.Weekday() {
+				days = append(days, day)
+			}
+		}
+		days = r.filter(days, false, true)
+	case Monthly:
+		month := date(first.Year(), first.Month()+time.Month(k*r.Interval), 1)
+		if r.matchMonth(month.Month()) {
+			days = r.expandMonth(month, first.Day())
+		}
+	case Yearly:
+		year := first.Year() + k*r.Interval
+		switch {
+		case len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0 && len(r.ByDay) > 0:
+			// Номера в BYDAY без BYMONTH считаются от начала года
+			days = r.byDay(date(year, time.January, 1), date(year+1, time.January, 1))
+		case len(r.ByMonth) == 0 && len(r.ByMonthDay) == 0:
+			days = r.expandMonth(date(year, first.Month(), 1), first.Day())
+		default:
+			for month := time.January; month <= time.December; month++ {
+				if r.matchMonth(month) {
+					days = append(days, r.expandMonth(date(year, month, 1), first.Day())...)
+				}
+			}
+		}
+	}
+
+	days = r.setPos(days)
+
+	occurrences := make([]time.Time, len(days))
+	for i, day := range days {
+		occurrences[i] = time.Date(day.Year(), day.Month(), day.Day(),
+			start.Hour(), start.Minute(), start.Second(), start.Nanosecond(), start.Location())
+	}
+	return occurrences
+}
+
+// expandMonth возвращает дни месяца по BYMONTHDAY и BYDAY. Без них повторение приходится на день начала серии,
+// а месяцы, в которых такого дня нет, пропускаются.
+func (r *Rule) expandMonth(month time.Time, defaultDay int) []time.Time {
+	next := month.AddDate(0, 1, 0)
+	if len(r.ByMonthDay) == 0 && len(r.ByDay) == 0 {
+		day := month.AddDate(0, 0, defaultDay-1)
+		if day.Before(next) {
+			return []time.Time{day}
+		}
+		return nil
+	}
+
+	var days []time.Time
+	if len(r.ByDay) > 0 {
+		days = r.byDay(month, next)
+	} else {
+		for day := month; day.Before(next); day = day.AddDate(0, 0, 1) {
+			days = append(days, day)
+		}
+	}
+	return r.filter(days, true, false)
+}
+
+// byDay возвращает дни [from, to), подходящие под BYDAY. Номер дня недели считается внутри этого интервала.
+func (r *Rule) byDay(from, to time.Time) []time.Time {
+	var days []time.Time
+	for day := from; day.Before(to); day = day.AddDate(0, 0, 1) {
+		fromStart := daysBetween(from, day)/7 + 1
+		fromEnd := (daysBetween(day, to)-1)/7 + 1
+		for _, wd := range r.ByDay {
+			if day.Weekday() == wd.Weekday && (wd.N == 0 || wd.N == fromStart || -wd.N == fromEnd) {
+				days = append(days, day)
+				break
+			}
+		}
+	}
+	return days
+}
+
+func daysBetween(from, to time.Time) int {
+	return int(to.Sub(from).Hours() / 24)
+}
+
+// filter оставляет дни, подходящие под BYMONTH, BYMONTHDAY (если checkMonthDay) и дни недели из BYDAY (если checkWeekday).
+func (r *Rule) filter(days []time.Time, checkMonthDay, checkWeekday bool) []time.Time {
+	result := days[:0:0]
+	for _, day := range days {
+		if !r.matchMonth(day.Month()) {
+			continue
+		}
+		if checkMonthDay && !r.matchMonthDay(day) {
+			continue
+		}
+		if checkWeekday && !r.matchWeekday(day.Weekday()) {
+			continue
+		}
+		result = append(result, day)
+	}
+	return result
+}
+
+func (r *Rule) matchMonth(month time.Month) bool {
+	if len(r.ByMonth) == 0 {
+		return true
+	}
+	for _, m := range r.ByMonth {
+		if m == month {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchMonthDay(day time.Time) bool {
+	if len(r.ByMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := date(day.Year(), day.Month()+1, 0).Day()
+	for _, d := range r.ByMonthDay {
+		if d == day.Day() || (d < 0 && daysInMonth+d+1 == day.Day()) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Rule) matchWeekday(weekday time.Weekday) bool {
+	if len(r.ByDay) == 0 {
+		return true
+	}
+	for _, wd := range r.ByDay {
+		if wd.Weekday == weekday {
+			return true
+		}
+	}
+	return false
+}
+
+// setPos выбирает из дней периода позиции BYSETPOS, отрицательные считаются с конца.
+func (r *Rule) setPos(days []time.Time) []time.Time {
+	if len(r.BySetPos) == 0 || len(days) == 0 {
+		return days
+	}
+
+	var result []time.Time
+	for _, pos := range r.BySetPos {
+		i := pos - 1
+		if pos < 0 {
+			i = len(days) + pos
+		}
+		if i >= 0 && i < len(days) {
+			result = append(result, days[i])
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Before(result[j]) })
+
+	unique := result[:0]
+	for i, day := range result {
+		if i == 0 || !day.Equal(result[i-1]) {
+			unique = append(unique, day)
+		}
+	}
+	return unique
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,193 @@
+package rrule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tt := []struct {
+		rule    string
+		want    string
+		wantErr bool
+	}{
+		{rule: "FREQ=DAILY", want: "FREQ=DAILY"},
+		{rule: "RRULE:freq=weekly;byday=mo,fr;interval=2", want: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,FR"},
+		{rule: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1", want: "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1"},
+		{rule: "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH;UNTIL=20300101T000000Z", want: "FREQ=YEARLY;UNTIL=20300101T000000Z;BYMONTH=11;BYDAY=4TH"},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=-1;COUNT=3;WKST=SU", want: "FREQ=MONTHLY;COUNT=3;BYMONTHDAY=-1;WKST=SU"},
+		{rule: "", wantErr: true},
+		{rule: "INTERVAL=2", wantErr: true},
+		{rule: "FREQ=HOURLY", wantErr: true},
+		{rule: "FREQ=DAILY;INTERVAL=0", wantErr: true},
+		{rule: "FREQ=DAILY;COUNT=2;UNTIL=20300101", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYDAY=1MO", wantErr: true},
+		{rule: "FREQ=WEEKLY;BYMONTHDAY=1", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=32", wantErr: true},
+		{rule: "FREQ=MONTHLY;BYSETPOS=1", wantErr: true},
+		{rule: "FREQ=DAILY;BYHOUR=9", wantErr: true},
+		{rule: "FREQ=DAILY;FREQ=WEEKLY", wantErr: true},
+		{rule: "FREQ=DAILY;UNTIL=tomorrow", wantErr: true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.rule, func(t *testing.T) {
+			r, err := Parse(tc.rule)
+			if tc.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidRule)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, r.String())
+		})
+	}
+}
+
+func TestRule_Take(t *testing.T) {
+	moscow, _ := time.LoadLocation("Europe/Moscow")
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+
+	tt := []struct {
+		name  string
+		rule  string
+		start time.Time
+		n     int
+		want  []string
+	}{
+		{
+			name:  "Daily interval",
+			rule:  "FREQ=DAILY;INTERVAL=3",
+			start: time.Date(2024, 2, 27, 9, 0, 0, 0, moscow),
+			n:     3,
+			want:  []string{"2024-02-27 09:00", "2024-03-01 09:00", "2024-03-04 09:00"},
+		},
+		{
+			name:  "Weekly on weekdays",
+			rule:  "FREQ=WEEKLY;BYDAY=MO,WE,FR",
+			start: time.Date(2024, 5, 3, 18, 30, 0, 0, moscow),
+			n:     4,
+			want:  []string{"2024-05-03 18:30", "2024-05-06 18:30", "2024-05-08 18:30", "2024-05-10 18:30"},
+		},
+		{
+			name:  "Biweekly counts weeks from start",
+			rule:  "FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,TH",
+			start: time.Date(2024, 5, 2, 10, 0, 0, 0, time.UTC),
+			n:     4,
+			want:  []string{"2024-05-02 10:00", "2024-05-14 10:00", "2024-05-16 10:00", "2024-05-28 10:00"},
+		},
+		{
+			name:  "Monthly on the 31st skips short months",
+			rule:  "FREQ=MONTHLY",
+			start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			n:     3,
+			want:  []string{"2024-01-31 00:00", "2024-03-31 00:00", "2024-05-31 00:00"},
+		},
+		{
+			name:  "Monthly last day",
+			rule:  "FREQ=MONTHLY;BYMONTHDAY=-1",
+			start: time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+			n:     3,
+			want:  []string{"2024-01-31 00:00", "2024-02-29 00:00", "2024-03-31 00:00"},
+		},
+		{
+			name:  "Last working day of month",
+			rule:  "FREQ=MONTHLY;BYDAY=MO,TU,WE,TH,FR;BYSETPOS=-1",
+			start: time.Date(2024, 3, 29, 12, 0, 0, 0, time.UTC),
+			n:     3,
+			want:  []string{"2024-03-29 12:00", "2024-04-30 12:00", "2024-05-31 12:00"},
+		},
+		{
+			name:  "First Monday",
+			rule:  "FREQ=MONTHLY;BYDAY=1MO",
+			start: time.Date(2024, 1, 1, 8, 0, 0, 0, time.UTC),
+			n:     3,
+			want:  []string{"2024-01-01 08:00", "2024-02-05 08:00", "2024-03-04 08:00"},
+		},
+		{
+			name:  "Thanksgiving",
+			rule:  "FREQ=YEARLY;BYMONTH=11;BYDAY=4TH",
+			start: time.Date(2024, 11, 28, 0, 0, 0, 0, time.UTC),
+			n:     3,
+			want:  []string{"2024-11-28 00:00", "2025-11-27 00:00", "2026-11-26 00:00"},
+		},
+		{
+			name:  "Yearly on leap day",
+			rule:  "FREQ=YEARLY",
+			start: time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC),
+			n:     2,
+			want:  []string{"2024-02-29 00:00", "2028-02-29 00:00"},
+		},
+		{
+			name:  "Last Friday of year",
+			rule:  "FREQ=YEARLY;BYDAY=-1FR",
+			start: time.Date(2024, 12, 27, 0, 0, 0, 0, time.UTC),
+			n:     2,
+			want:  []string{"2024-12-27 00:00", "2025-12-26 00:00"},
+		},
+		{
+			name:  "Count includes start",
+			rule:  "FREQ=DAILY;COUNT=2",
+			start: time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+			n:     5,
+			want:  []string{"2024-01-01 09:00", "2024-01-02 09:00"},
+		},
+		{
+			name:  "Until date is inclusive",
+			rule:  "FREQ=DAILY;UNTIL=20240103",
+			start: time.Date(2024, 1, 1, 23, 0, 0, 0, moscow),
+			n:     5,
+			want:  []string{"2024-01-01 23:00", "2024-01-02 23:00", "2024-01-03 23:00"},
+		},
+		{
+			name:  "Start outside of rule",
+			rule:  "FREQ=WEEKLY;BYDAY=FR",
+			start: time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC),
+			n:     2,
+			want:  []string{"2024-05-01 09:00", "2024-05-03 09:00"},
+		},
+		{
+			name:  "Local time kept over DST change",
+			rule:  "FREQ=DAILY",
+			start: time.Date(2024, 3, 30, 9, 0, 0, 0, berlin),
+			n:     2,
+			want:  []string{"2024-03-30 09:00", "2024-03-31 09:00"},
+		},
+		{
+			name:  "Never again",
+			rule:  "FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30",
+			start: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			n:     2,
+			want:  []string{"2024-01-01 00:00"},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := Parse(tc.rule)
+			assert.NoError(t, err)
+
+			got := make([]string, 0)
+			for _, occurrence := range r.Take(tc.start, tc.n) {
+				assert.Equal(t, tc.start.Location(), occurrence.Location())
+				got = append(got, occurrence.Format("2006-01-02 15:04"))
+			}
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestRule_Next(t *testing.T) {
+	r, _ := Parse("FREQ=WEEKLY;COUNT=2")
+	start := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+
+	next, rest, ok := r.Next(start)
+	assert.True(t, ok)
+	assert.Equal(t, time.Date(2024, 5, 10, 9, 0, 0, 0, time.UTC), next)
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=1", rest.String())
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=2", r.String())
+
+	_, _, ok = rest.Next(next)
+	assert.False(t, ok)
+}
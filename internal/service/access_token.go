@@ -0,0 +1,58 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+)
+
+var ErrAccessTokenNotFound = errors.New("personal access token not found")
+
+type PersonalAccessTokenService struct {
+	repo repository.PersonalAccessToken
+}
+
+func NewPersonalAccessTokenService(repo repository.PersonalAccessToken) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{repo: repo}
+}
+
+// Create выпускает новый токен. Открытое значение токена возвращается только здесь, в БД хранится его хэш.
+func (s *PersonalAccessTokenService) Create(userId int, input entity.CreatePersonalAccessTokenInput) (entity.PersonalAccessToken, error) {
+	if err := input.Validate(); err != nil {
+		return entity.PersonalAccessToken{}, err
+	}
+
+	secret, err := newRandomToken()
+	if err != nil {
+		return entity.PersonalAccessToken{}, err
+	}
+
+	token := entity.PersonalAccessToken{
+		UserId:    userId,
+		Name:      input.Name,
+		Scopes:    input.Scopes,
+		Token:     entity.PersonalAccessTokenPrefix + secret,
+		ExpiresAt: input.ExpiresAt,
+	}
+	token.TokenHash = hashToken(token.Token)
+
+	token.Id, err = s.repo.Create(token)
+	if err != nil {
+		return entity.PersonalAccessToken{}, err
+	}
+
+	return token, nil
+}
+
+func (s *PersonalAccessTokenService) GetAll(userId int) ([]entity.PersonalAccessToken, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *PersonalAccessTokenService) Revoke(userId, tokenId int) error {
+	err := s.repo.Revoke(userId, tokenId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAccessTokenNotFound
+	}
+	return err
+}
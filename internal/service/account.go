@@ -0,0 +1,180 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/mail"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/sirupsen/logrus"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	verifyEmailTokenTTL   = 48 * time.Hour
+	resetPasswordTokenTTL = time.Hour
+)
+
+var (
+	ErrInvalidUserToken     = errors.New("invalid or expired token")
+	ErrEmailAlreadyVerified = errors.New("email is already verified")
+	ErrNoEmail              = errors.New("account has no email")
+	ErrEmailTaken           = errors.New("email is already taken")
+)
+
+// AccountService отвечает за письма со ссылками: подтверждение адреса и восстановление пароля.
+type AccountService struct {
+	repo        repository.Authorization
+	tokenRepo   repository.UserToken
+	sessionRepo repository.Session
+	patRepo     repository.PersonalAccessToken
+	hasher      hash.PasswordHasher
+	mailer      mail.Mailer
+	appURL      string
+}
+
+func NewAccountService(repo repository.Authorization, tokenRepo repository.UserToken, sessionRepo repository.Session,
+	patRepo repository.PersonalAccessToken, hasher hash.PasswordHasher, mailer mail.Mailer, appURL string) *AccountService {
+	return &AccountService{
+		repo:        repo,
+		tokenRepo:   tokenRepo,
+		sessionRepo: sessionRepo,
+		patRepo:     patRepo,
+		hasher:      hasher,
+		mailer:      mailer,
+		appURL:      strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+func (s *AccountService) SendVerification(userId int) error {
+	user, err := s.repo.GetUserById(userId)
+	if err != nil {
+		return err
+	}
+	if user.Email == "" {
+		return ErrNoEmail
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	token, err := s.issueToken(user, entity.UserTokenVerifyEmail, verifyEmailTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mail.Message{
+		To:      user.Email,
+		Subject: "Confirm your email",
+		Body: fmt.Sprintf("Hi %s,\n\nplease confirm your email address by opening the link below:\n\n%s\n\nThe link is valid for %d hours.\n",
+			user.Name, s.link("/verify-email", token), int(verifyEmailTokenTTL.Hours())),
+	})
+}
+
+func (s *AccountService) VerifyEmail(token string) error {
+	userToken, err := s.tokenRepo.Consume(entity.UserTokenVerifyEmail, hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidUserToken
+	}
+	if err != nil {
+		return err
+	}
+
+	// Адрес мог смениться после отправки письма, тогда ссылка уже ничего не подтверждает
+	err = s.repo.SetEmailVerified(userToken.UserId, userToken.Email)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidUserToken
+	}
+	return err
+}
+
+// ForgotPassword отправляет ссылку для сброса пароля. Если адрес не найден, ошибка не возвращается,
+// чтобы по ответу нельзя было узнать, зарегистрирован ли адрес.
+func (s *AccountService) ForgotPassword(email string) error {
+	user, err := s.repo.GetUserByEmail(email)
+	if errors.Is(err, sql.ErrNoRows) {
+		logrus.Infof("Запрошен сброс пароля для незарегистрированного адреса")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(user, entity.UserTokenResetPassword, resetPasswordTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	return s.mailer.Send(mail.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nsomeone requested a password reset for your account %s.\n"+
+			"If it was you, open the link below within an hour:\n\n%s\n\nOtherwise just ignore this email.\n",
+			user.Name, user.Username, s.link("/reset-password", token)),
+	})
+}
+
+// ResetPassword задаёт новый пароль по ссылке из письма. Все сессии и персональные токены
+// пользователя отзываются, остальные ссылки на сброс перестают действовать.
+func (s *AccountService) ResetPassword(input entity.ResetPasswordInput) error {
+	userToken, err := s.tokenRepo.Consume(entity.UserTokenResetPassword, hashToken(input.Token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidUserToken
+	}
+	if err != nil {
+		return err
+	}
+
+	passwordHash, err := s.hasher.Hash(input.NewPassword)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.UpdatePasswordHash(userToken.UserId, passwordHash); err != nil {
+		return err
+	}
+
+	if err := s.tokenRepo.RevokeAll(userToken.UserId, entity.UserTokenResetPassword); err != nil {
+		return err
+	}
+	if err := s.sessionRepo.RevokeAll(userToken.UserId); err != nil {
+		return err
+	}
+	if err := s.patRepo.RevokeAll(userToken.UserId); err != nil {
+		return err
+	}
+
+	// Письмо дошло до владельца адреса, значит адрес подтверждён
+	if err := s.repo.SetEmailVerified(userToken.UserId, userToken.Email); err != nil && !errors.Is(err, sql.ErrNoRows) {
+		logrus.Errorf("Ошибка при подтверждении адреса пользователя %d: %s", userToken.UserId, err.Error())
+	}
+
+	return nil
+}
+
+func (s *AccountService) issueToken(user entity.User, purpose string, ttl time.Duration) (string, error) {
+	token, err := newRandomToken()
+	if err != nil {
+		return "", err
+	}
+
+	err = s.tokenRepo.Create(entity.UserToken{
+		UserId:    user.Id,
+		Purpose:   purpose,
+		TokenHash: hashToken(token),
+		Email:     user.Email,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+func (s *AccountService) link(path, token string) string {
+	return s.appURL + path + "?token=" + url.QueryEscape(token)
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/mail"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var linkToken = regexp.MustCompile(`https://todo\.example\.com/[a-z-]+\?token=([A-Za-z0-9_-]+)`)
+
+type accountTestRepos struct {
+	auth    *mock_repository.MockAuthorization
+	token   *mock_repository.MockUserToken
+	session *mock_repository.MockSession
+	pat     *mock_repository.MockPersonalAccessToken
+}
+
+func newTestAccountService(t *testing.T, c *gomock.Controller) (*AccountService, accountTestRepos, string) {
+	dir := t.TempDir()
+	mailer, err := mail.NewFileMailer(dir, "no-reply@example.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos := accountTestRepos{
+		auth:    mock_repository.NewMockAuthorization(c),
+		token:   mock_repository.NewMockUserToken(c),
+		session: mock_repository.NewMockSession(c),
+		pat:     mock_repository.NewMockPersonalAccessToken(c),
+	}
+	hasher := hash.NewArgon2idHasher(hash.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}, "")
+
+	return NewAccountService(repos.auth, repos.token, repos.session, repos.pat, hasher, mailer, "https://todo.example.com/"), repos, dir
+}
+
+// sentToken достаёт токен из ссылки в единственном отправленном письме.
+func sentToken(t *testing.T, dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.eml"))
+	if !assert.Len(t, files, 1) {
+		t.FailNow()
+	}
+
+	data, _ := os.ReadFile(files[0])
+	match := linkToken.FindStringSubmatch(string(data))
+	if !assert.NotNil(t, match, string(data)) {
+		t.FailNow()
+	}
+	return match[1]
+}
+
+func TestAccountService_ForgotPassword(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, repos, dir := newTestAccountService(t, c)
+	user := entity.User{Id: 1, Name: "Test", Username: "test", Email: "test@example.com"}
+
+	var stored entity.UserToken
+	repos.auth.EXPECT().GetUserByEmail("test@example.com").Return(user, nil)
+	repos.token.EXPECT().Create(gomock.Any()).DoAndReturn(func(token entity.UserToken) error {
+		stored = token
+		return nil
+	})
+
+	assert.NoError(t, s.ForgotPassword("test@example.com"))
+
+	token := sentToken(t, dir)
+	assert.Equal(t, hashToken(token), stored.TokenHash)
+	assert.Equal(t, entity.UserTokenResetPassword, stored.Purpose)
+	assert.Equal(t, "test@example.com", stored.Email)
+
+	// Для неизвестного адреса письмо не отправляется, но и ошибки нет
+	repos.auth.EXPECT().GetUserByEmail("other@example.com").Return(entity.User{}, sql.ErrNoRows)
+	assert.NoError(t, s.ForgotPassword("other@example.com"))
+}
+
+func TestAccountService_ResetPassword(t *testing.T) {
+	tt := []struct {
+		name         string
+		mockBehavior func(r accountTestRepos)
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r accountTestRepos) {
+				r.token.EXPECT().Consume(entity.UserTokenResetPassword, hashToken("token")).
+					Return(entity.UserToken{UserId: 1, Email: "test@example.com"}, nil)
+				r.auth.EXPECT().UpdatePasswordHash(1, gomock.Any()).Return(nil)
+				r.token.EXPECT().RevokeAll(1, entity.UserTokenResetPassword).Return(nil)
+				r.session.EXPECT().RevokeAll(1).Return(nil)
+				r.pat.EXPECT().RevokeAll(1).Return(nil)
+				r.auth.EXPECT().SetEmailVerified(1, "test@example.com").Return(nil)
+			},
+		},
+		{
+			name: "Used or expired token",
+			mockBehavior: func(r accountTestRepos) {
+				r.token.EXPECT().Consume(entity.UserTokenResetPassword, hashToken("token")).
+					Return(entity.UserToken{}, sql.ErrNoRows)
+			},
+			wantErr: ErrInvalidUserToken,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, repos, _ := newTestAccountService(t, c)
+			tc.mockBehavior(repos)
+
+			err := s.ResetPassword(entity.ResetPasswordInput{Token: "token", NewPassword: "new password"})
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAccountService_VerifyEmail(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, repos, dir := newTestAccountService(t, c)
+
+	repos.auth.EXPECT().GetUserById(1).Return(entity.User{Id: 1, Name: "Test", Email: "test@example.com"}, nil)
+	repos.token.EXPECT().Create(gomock.Any()).Return(nil)
+	assert.NoError(t, s.SendVerification(1))
+
+	token := sentToken(t, dir)
+
+	repos.token.EXPECT().Consume(entity.UserTokenVerifyEmail, hashToken(token)).
+		Return(entity.UserToken{UserId: 1, Email: "test@example.com"}, nil)
+	repos.auth.EXPECT().SetEmailVerified(1, "test@example.com").Return(nil)
+	assert.NoError(t, s.VerifyEmail(token))
+
+	// Адрес сменили после отправки письма
+	repos.token.EXPECT().Consume(entity.UserTokenVerifyEmail, hashToken("stale")).
+		Return(entity.UserToken{UserId: 1, Email: "old@example.com"}, nil)
+	repos.auth.EXPECT().SetEmailVerified(1, "old@example.com").Return(sql.ErrNoRows)
+	assert.ErrorIs(t, s.VerifyEmail("stale"), ErrInvalidUserToken)
+
+	repos.auth.EXPECT().GetUserById(1).Return(entity.User{Id: 1, Email: "test@example.com", EmailVerified: true}, nil)
+	assert.ErrorIs(t, s.SendVerification(1), ErrEmailAlreadyVerified)
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/IncubusX/go-todo-app/internal/storage"
+	"github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+)
+
+// sniffLength - сколько байт начала файла нужно http.DetectContentType.
+const sniffLength = 512
+
+var (
+	ErrAttachmentNotFound  = errors.New("attachment not found")
+	ErrAttachmentTooLarge  = errors.New("attachment is too large")
+	ErrUnsupportedFileType = errors.New("unsupported file type")
+)
+
+// AttachmentService: просматривать и скачивать вложения могут все участники списка, прикреплять и удалять - редакторы.
+// Файлы удалённых вложений убирает из хранилища worker.BlobCollector.
+type AttachmentService struct {
+	repo     repository.Attachment
+	itemRepo repository.TodoItem
+	blobs    storage.BlobStore
+	maxSize  int64
+}
+
+func NewAttachmentService(repo repository.Attachment, itemRepo repository.TodoItem, blobs storage.BlobStore,
+	maxSize int64) *AttachmentService {
+	return &AttachmentService{repo: repo, itemRepo: itemRepo, blobs: blobs, maxSize: maxSize}
+}
+
+// MaxSize - наибольший размер вложения, по нему обработчик ограничивает тело запроса.
+func (s *AttachmentService) MaxSize() int64 {
+	return s.maxSize
+}
+
+// Create сохраняет файл в хранилище, определяя его тип по содержимому, а затем записывает метаданные.
+func (s *AttachmentService) Create(userId, itemId int, upload entity.AttachmentUpload) (int, error) {
+	if err := requireItemEditor(s.itemRepo, userId, itemId); err != nil {
+		return 0, err
+	}
+	if upload.Size > s.maxSize {
+		return 0, ErrAttachmentTooLarge
+	}
+
+	head := make([]byte, sniffLength)
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, err
+	}
+	head = head[:n]
+
+	contentType := http.DetectContentType(head)
+	if !entity.AllowedAttachmentType(contentType) {
+		return 0, ErrUnsupportedFileType
+	}
+
+	token, err := newRandomToken()
+	if err != nil {
+		return 0, err
+	}
+	key := fmt.Sprintf("items/%d/%s", itemId, token)
+
+	hash := sha256.New()
+	content := io.TeeReader(io.MultiReader(bytes.NewReader(head), upload.Content), hash)
+	if err = s.blobs.Put(context.Background(), key, content, upload.Size, contentType); err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.Create(entity.Attachment{
+		ItemId:      itemId,
+		UserId:      &userId,
+		FileName:    entity.SanitizeFileName(upload.FileName),
+		ContentType: contentType,
+		Size:        upload.Size,
+		BlobKey:     key,
+		ETag:        hex.EncodeToString(hash.Sum(nil)),
+	})
+	if err != nil {
+		// На файл без метаданных ничего не ссылается, поэтому он удаляется сразу
+		if deleteErr := s.blobs.Delete(context.Background(), key); deleteErr != nil {
+			logrus.Errorf("Ошибка при удалении файла %s: %s", key, deleteErr.Error())
+		}
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (s *AttachmentService) GetAll(userId, itemId int) ([]entity.Attachment, error) {
+	if err := s.requireItemAccess(userId, itemId); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(itemId)
+}
+
+// Open возвращает метаданные вложения и его содержимое, которое нужно закрыть после чтения.
+func (s *AttachmentService) Open(userId, itemId, attachmentId int) (entity.Attachment, io.ReadSeekCloser, error) {
+	if err := s.requireItemAccess(userId, itemId); err != nil {
+		return entity.Attachment{}, nil, err
+	}
+
+	attachment, err := s.repo.GetById(itemId, attachmentId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return attachment, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return attachment, nil, err
+	}
+
+	content, err := s.blobs.Open(context.Background(), attachment.BlobKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		return attachment, nil, ErrAttachmentNotFound
+	}
+	return attachment, content, err
+}
+
+func (s *AttachmentService) Delete(userId, itemId, attachmentId int) error {
+	if err := requireItemEditor(s.itemRepo, userId, itemId); err != nil {
+		return err
+	}
+
+	err := s.repo.Delete(itemId, attachmentId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrAttachmentNotFound
+	}
+	return err
+}
+
+func (s *AttachmentService) requireItemAccess(userId, itemId int) error {
+	_, err := s.itemRepo.GetRole(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrItemNotFound
+	}
+	return err
+}
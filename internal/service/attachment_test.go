@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/IncubusX/go-todo-app/internal/storage"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var pngHeader = []byte("\x89PNG\x0D\x0A\x1A\x0A\x00\x00\x00\x0DIHDR")
+
+func TestAttachmentService_Create(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	attachments := mock_repository.NewMockAttachment(c)
+	items := mock_repository.NewMockTodoItem(c)
+	blobs, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	s := NewAttachmentService(attachments, items, blobs, 1024)
+
+	content := append(append([]byte{}, pngHeader...), bytes.Repeat([]byte{1}, 600)...)
+	sum := sha256.Sum256(content)
+
+	var saved entity.Attachment
+	items.EXPECT().GetRole(1, 3).Return(entity.ListRoleEditor, nil)
+	attachments.EXPECT().Create(gomock.Any()).DoAndReturn(func(attachment entity.Attachment) (int, error) {
+		saved = attachment
+		return 7, nil
+	})
+
+	id, err := s.Create(1, 3, entity.AttachmentUpload{
+		FileName: `C:\photos\trip.png`,
+		Size:     int64(len(content)),
+		Content:  bytes.NewReader(content),
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 7, id)
+	assert.Equal(t, "trip.png", saved.FileName)
+	assert.Equal(t, "image/png", saved.ContentType)
+	assert.Equal(t, int64(len(content)), saved.Size)
+	assert.Equal(t, hex.EncodeToString(sum[:]), saved.ETag)
+	assert.True(t, strings.HasPrefix(saved.BlobKey, "items/3/"))
+
+	f, err := blobs.Open(context.Background(), saved.BlobKey)
+	assert.NoError(t, err)
+	got, err := io.ReadAll(f)
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+	assert.NoError(t, f.Close())
+}
+
+func TestAttachmentService_CreateErrors(t *testing.T) {
+	type mocks struct {
+		attachments *mock_repository.MockAttachment
+		items       *mock_repository.MockTodoItem
+	}
+
+	tt := []struct {
+		name         string
+		content      string
+		size         int64
+		mockBehavior func(m mocks)
+		wantErr      error
+	}{
+		{
+			name:    "Too large",
+			content: string(pngHeader),
+			size:    2048,
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetRole(1, 3).Return(entity.ListRoleOwner, nil)
+			},
+			wantErr: ErrAttachmentTooLarge,
+		},
+		{
+			name:    "Html",
+			content: "<!DOCTYPE html><script>alert(1)</script>",
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetRole(1, 3).Return(entity.ListRoleOwner, nil)
+			},
+			wantErr: ErrUnsupportedFileType,
+		},
+		{
+			name:    "Zip",
+			content: "PK\x03\x04\x14\x00\x00\x00",
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetRole(1, 3).Return(entity.ListRoleOwner, nil)
+			},
+			wantErr: ErrUnsupportedFileType,
+		},
+		{
+			name:    "Viewer",
+			content: "notes",
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetRole(1, 3).Return(entity.ListRoleViewer, nil)
+			},
+			wantErr: ErrInsufficientRole,
+		},
+		{
+			name:    "Item not found",
+			content: "notes",
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetRole(1, 3).Return("", sql.ErrNoRows)
+			},
+			wantErr: ErrItemNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			m := mocks{
+				attachments: mock_repository.NewMockAttachment(c),
+				items:       mock_repository.NewMockTodoItem(c),
+			}
+			tc.mockBehavior(m)
+
+			s := NewAttachmentService(m.attachments, m.items, nil, 1024)
+			size := tc.size
+			if size == 0 {
+				size = int64(len(tc.content))
+			}
+			_, err := s.Create(1, 3, entity.AttachmentUpload{FileName: "file", Size: size, Content: strings.NewReader(tc.content)})
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
+
+func TestAttachmentService_CreateRemovesBlobOnFailure(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	attachments := mock_repository.NewMockAttachment(c)
+	items := mock_repository.NewMockTodoItem(c)
+	blobs, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	s := NewAttachmentService(attachments, items, blobs, 1024)
+
+	var key string
+	items.EXPECT().GetRole(1, 3).Return(entity.ListRoleEditor, nil)
+	attachments.EXPECT().Create(gomock.Any()).DoAndReturn(func(attachment entity.Attachment) (int, error) {
+		key = attachment.BlobKey
+		return 0, errors.New("insert failed")
+	})
+
+	_, err = s.Create(1, 3, entity.AttachmentUpload{FileName: "notes.txt", Size: 5, Content: strings.NewReader("notes")})
+	assert.Error(t, err)
+
+	_, err = blobs.Open(context.Background(), key)
+	assert.ErrorIs(t, err, storage.ErrNotFound)
+}
+
+func TestAttachmentService_Open(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	attachments := mock_repository.NewMockAttachment(c)
+	items := mock_repository.NewMockTodoItem(c)
+	blobs, err := storage.NewLocalStore(t.TempDir())
+	assert.NoError(t, err)
+	s := NewAttachmentService(attachments, items, blobs, 1024)
+
+	assert.NoError(t, blobs.Put(context.Background(), "items/3/abc", strings.NewReader("notes"), 5, "text/plain"))
+
+	items.EXPECT().GetRole(2, 3).Return(entity.ListRoleViewer, nil).Times(3)
+	attachments.EXPECT().GetById(3, 7).Return(entity.Attachment{Id: 7, ItemId: 3, BlobKey: "items/3/abc"}, nil)
+	attachments.EXPECT().GetById(3, 8).Return(entity.Attachment{}, sql.ErrNoRows)
+	attachments.EXPECT().GetById(3, 9).Return(entity.Attachment{Id: 9, ItemId: 3, BlobKey: "items/3/gone"}, nil)
+
+	attachment, content, err := s.Open(2, 3, 7)
+	assert.NoError(t, err)
+	assert.Equal(t, 7, attachment.Id)
+	got, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, "notes", string(got))
+	assert.NoError(t, content.Close())
+
+	_, _, err = s.Open(2, 3, 8)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound)
+
+	_, _, err = s.Open(2, 3, 9)
+	assert.ErrorIs(t, err, ErrAttachmentNotFound, "файл мог быть уже удалён")
+}
+
+func TestAttachmentService_Delete(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	attachments := mock_repository.NewMockAttachment(c)
+	items := mock_repository.NewMockTodoItem(c)
+	s := NewAttachmentService(attachments, items, nil, 1024)
+
+	items.EXPECT().GetRole(1, 3).Return(entity.ListRoleEditor, nil).Times(2)
+	attachments.EXPECT().Delete(3, 7).Return(nil)
+	attachments.EXPECT().Delete(3, 8).Return(sql.ErrNoRows)
+
+	assert.NoError(t, s.Delete(1, 3, 7))
+	assert.ErrorIs(t, s.Delete(1, 3, 8), ErrAttachmentNotFound)
+}
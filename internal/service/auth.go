@@ -1,77 +1,506 @@
 package service
 
 import (
-	"crypto/sha1"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base64"
+	"encoding/hex"
 	"errors"
-	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
 	"github.com/IncubusX/go-todo-app/internal/repository"
 	jwt "github.com/golang-jwt/jwt/v5"
-	"os"
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+	"strings"
 	"time"
 )
 
 const (
-	tokenTTL = 12 * time.Hour
+	refreshTokenLength = 32
+	challengeTokenTTL  = 5 * time.Minute
+	challengeAudience  = "2fa-challenge"
+	// lastSeenInterval - как часто обновляется время последней активности сессии, чтобы не писать в БД на каждый запрос
+	lastSeenInterval = time.Minute
+)
+
+var (
+	ErrInvalidCredentials  = errors.New("invalid username or password")
+	ErrInvalidRefreshToken = errors.New("invalid refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token reuse detected")
+	ErrSessionRevoked      = errors.New("session is revoked or expired")
+	ErrInvalidAccessToken  = errors.New("personal access token is invalid, revoked or expired")
+	ErrUsernameTaken       = errors.New("username is already taken")
+	ErrInvalidChallenge    = errors.New("invalid or expired two-factor challenge")
 )
 
 type AuthService struct {
-	repo repository.Authorization
+	repo        repository.Authorization
+	sessionRepo repository.Session
+	patRepo     repository.PersonalAccessToken
+	twoFactor   *TwoFactorService
+	account     *AccountService
+	throttle    *LoginThrottle
+	hasher      hash.PasswordHasher
+	keys        *keyring.KeyRing
+	// dummyHash сравнивается с паролем, если пользователь не найден, чтобы время ответа не выдавало существование логина
+	dummyHash string
+
+	accessTokenTTL  time.Duration
+	refreshTokenTTL time.Duration
 }
 
 type tokenClaims struct {
 	jwt.RegisteredClaims
-	UserId int `json:"user_id"`
+	UserId    int `json:"user_id"`
+	SessionId int `json:"sid"`
 }
 
-func NewAuthService(repo repository.Authorization) *AuthService {
-	return &AuthService{repo: repo}
+func NewAuthService(repo repository.Authorization, sessionRepo repository.Session, patRepo repository.PersonalAccessToken,
+	twoFactor *TwoFactorService, account *AccountService, throttle *LoginThrottle, hasher hash.PasswordHasher, keys *keyring.KeyRing, accessTokenTTL, refreshTokenTTL time.Duration) *AuthService {
+	dummyHash, _ := hasher.Hash("dummy password")
+	return &AuthService{
+		repo:            repo,
+		sessionRepo:     sessionRepo,
+		patRepo:         patRepo,
+		twoFactor:       twoFactor,
+		account:         account,
+		throttle:        throttle,
+		hasher:          hasher,
+		keys:            keys,
+		dummyHash:       dummyHash,
+		accessTokenTTL:  accessTokenTTL,
+		refreshTokenTTL: refreshTokenTTL,
+	}
 }
 
 func (s *AuthService) CreateUser(user entity.User) (int, error) {
-	user.Password = generatePasswordHash(user.Password)
-	return s.repo.CreateUser(user)
+	passwordHash, err := s.hasher.Hash(user.Password)
+	if err != nil {
+		return 0, err
+	}
+	user.Password = passwordHash
+
+	id, err := s.repo.CreateUser(user)
+	if isUniqueViolation(err) {
+		return 0, userConflict(err)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	if user.Email == "" {
+		return id, nil
+	}
+	if err := s.account.SendVerification(id); err != nil {
+		logrus.Errorf("Ошибка при отправке письма с подтверждением адреса пользователю %d: %s", id, err.Error())
+	}
+
+	return id, nil
 }
 
-func (s *AuthService) GenerateToken(username, password string) (string, error) {
-	user, err := s.repo.GetUser(username, generatePasswordHash(password))
+func (s *AuthService) GetProfile(userId int) (entity.Profile, error) {
+	user, err := s.repo.GetUserById(userId)
 	if err != nil {
-		return "", err
+		return entity.Profile{}, err
+	}
+
+	return entity.Profile{
+		Id:            user.Id,
+		Name:          user.Name,
+		Username:      user.Username,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		TimeZone:      user.TimeZone,
+	}, nil
+}
+
+func (s *AuthService) UpdateProfile(userId int, input entity.UpdateUserInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	err := s.repo.UpdateUser(userId, input)
+	if isUniqueViolation(err) {
+		return userConflict(err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if input.Email != nil {
+		err := s.account.SendVerification(userId)
+		if err != nil && !errors.Is(err, ErrEmailAlreadyVerified) {
+			logrus.Errorf("Ошибка при отправке письма с подтверждением адреса пользователю %d: %s", userId, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// ChangePassword меняет пароль и отзывает все сессии и персональные токены пользователя.
+// Вызывающему выдаётся новая сессия, чтобы смена пароля не разлогинивала текущее устройство.
+func (s *AuthService) ChangePassword(userId int, input entity.ChangePasswordInput, client entity.Client) (entity.Tokens, error) {
+	user, err := s.repo.GetUserById(userId)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	ok, err := s.hasher.Verify(input.OldPassword, user.Password)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+	if !ok {
+		return entity.Tokens{}, ErrInvalidCredentials
+	}
+
+	if err := s.rehash(userId, input.NewPassword); err != nil {
+		return entity.Tokens{}, err
+	}
+
+	if err := s.sessionRepo.RevokeAll(userId); err != nil {
+		return entity.Tokens{}, err
+	}
+	if err := s.patRepo.RevokeAll(userId); err != nil {
+		return entity.Tokens{}, err
+	}
+
+	return s.createSession(userId, client)
+}
+
+func (s *AuthService) DeleteUser(userId int) error {
+	return s.repo.DeleteUser(userId)
+}
+
+// GenerateToken проверяет логин и пароль. Если у пользователя включён второй фактор,
+// вместо пары токенов возвращается короткоживущий ChallengeToken для SignInTwoFactor.
+// После серии неудачных попыток вход по логину или IP-адресу временно блокируется.
+func (s *AuthService) GenerateToken(username, password string, client entity.Client) (entity.Tokens, error) {
+	if err := s.throttle.Check(username, client.IP); err != nil {
+		return entity.Tokens{}, err
+	}
+
+	user, err := s.authenticate(username, password)
+	if errors.Is(err, ErrInvalidCredentials) {
+		if err := s.throttle.Fail(username, client.IP); err != nil {
+			logrus.Errorf("Ошибка при учёте неудачной попытки входа: %s", err.Error())
+		}
+		return entity.Tokens{}, err
+	}
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	if err := s.throttle.Succeed(username); err != nil {
+		logrus.Errorf("Ошибка при сбросе счётчика попыток входа: %s", err.Error())
+	}
+
+	return s.signIn(user.Id, client)
+}
+
+// SignInTwoFactor обменивает ChallengeToken и код на пару токенов. Неверные коды учитываются так же,
+// как неверные пароли, поэтому после серии ошибок challenge отклоняется до конца блокировки.
+func (s *AuthService) SignInTwoFactor(challengeToken, code string, client entity.Client) (entity.Tokens, error) {
+	token, err := jwt.ParseWithClaims(challengeToken, &tokenClaims{}, s.keys.Keyfunc, jwt.WithAudience(challengeAudience))
+	if err != nil {
+		return entity.Tokens{}, ErrInvalidChallenge
+	}
+
+	claims, ok := token.Claims.(*tokenClaims)
+	if !ok {
+		return entity.Tokens{}, ErrInvalidChallenge
+	}
+
+	if err := s.throttle.CheckTwoFactor(claims.UserId, client.IP); err != nil {
+		return entity.Tokens{}, err
+	}
+
+	err = s.twoFactor.Verify(claims.UserId, code)
+	if errors.Is(err, ErrInvalidTwoFactorCode) {
+		if err := s.throttle.FailTwoFactor(claims.UserId, client.IP); err != nil {
+			logrus.Errorf("Ошибка при учёте неудачной попытки входа: %s", err.Error())
+		}
+		return entity.Tokens{}, err
+	}
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	if err := s.throttle.SucceedTwoFactor(claims.UserId); err != nil {
+		logrus.Errorf("Ошибка при сбросе счётчика попыток входа: %s", err.Error())
+	}
+
+	return s.createSession(claims.UserId, client)
+}
+
+// RefreshTokens обменивает refresh-токен на новую пару токенов. Каждый refresh-токен одноразовый:
+// повторное предъявление уже использованного токена означает его утечку, поэтому вся сессия отзывается.
+func (s *AuthService) RefreshTokens(refreshToken string) (entity.Tokens, error) {
+	token, err := s.sessionRepo.GetRefreshToken(hashToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Tokens{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	if token.RevokedAt != nil {
+		return entity.Tokens{}, ErrInvalidRefreshToken
+	}
+	if token.UsedAt != nil {
+		return entity.Tokens{}, s.revokeReused(token.SessionId)
+	}
+	if time.Now().After(token.ExpiresAt) {
+		return entity.Tokens{}, ErrInvalidRefreshToken
+	}
+
+	newRefreshToken, err := newRandomToken()
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	err = s.sessionRepo.RotateRefreshToken(token.Id, entity.RefreshToken{
+		SessionId: token.SessionId,
+		TokenHash: hashToken(newRefreshToken),
+		ExpiresAt: time.Now().Add(s.refreshTokenTTL),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Tokens{}, s.revokeReused(token.SessionId)
+	}
+	if err != nil {
+		return entity.Tokens{}, err
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, &tokenClaims{
+	accessToken, err := s.newAccessToken(token.UserId, token.SessionId)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	return entity.Tokens{AccessToken: accessToken, RefreshToken: newRefreshToken}, nil
+}
+
+func (s *AuthService) Logout(refreshToken string) error {
+	token, err := s.sessionRepo.GetRefreshToken(hashToken(refreshToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.sessionRepo.Revoke(token.SessionId)
+}
+
+// PublicKeys возвращает открытые ключи, которыми другие сервисы могут проверять выданные токены.
+func (s *AuthService) PublicKeys() entity.JSONWebKeySet {
+	return s.keys.JWKS()
+}
+
+// ParseToken проверяет JWT сессии или персональный токен доступа. JWT даёт полный доступ,
+// персональный токен - только выданные ему scopes.
+func (s *AuthService) ParseToken(accessToken string) (entity.Identity, error) {
+	if strings.HasPrefix(accessToken, entity.PersonalAccessTokenPrefix) {
+		return s.parsePersonalAccessToken(accessToken)
+	}
+
+	token, err := jwt.ParseWithClaims(accessToken, &tokenClaims{}, s.keys.Keyfunc)
+	if err != nil {
+		return entity.Identity{}, err
+	}
+
+	claims, ok := token.Claims.(*tokenClaims)
+	if !ok {
+		return entity.Identity{}, errors.New("token claims are not of type *tokenClaims")
+	}
+	// У challenge-токена второго фактора нет сессии, как доступ он не принимается
+	if claims.SessionId == 0 {
+		return entity.Identity{}, ErrSessionRevoked
+	}
+
+	session, err := s.sessionRepo.GetById(claims.SessionId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Identity{}, ErrSessionRevoked
+	}
+	if err != nil {
+		return entity.Identity{}, err
+	}
+	if session.UserId != claims.UserId || !session.Active(time.Now()) {
+		return entity.Identity{}, ErrSessionRevoked
+	}
+
+	if time.Since(session.LastSeenAt) > lastSeenInterval {
+		if err := s.sessionRepo.Touch(session.Id); err != nil {
+			logrus.Errorf("Ошибка при обновлении активности сессии %d: %s", session.Id, err.Error())
+		}
+	}
+
+	return entity.Identity{
+		UserId:    claims.UserId,
+		SessionId: claims.SessionId,
+		Scopes:    []string{entity.ScopeAdmin},
+	}, nil
+}
+
+func (s *AuthService) parsePersonalAccessToken(accessToken string) (entity.Identity, error) {
+	token, err := s.patRepo.GetByHash(hashToken(accessToken))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Identity{}, ErrInvalidAccessToken
+	}
+	if err != nil {
+		return entity.Identity{}, err
+	}
+	if !token.Active(time.Now()) {
+		return entity.Identity{}, ErrInvalidAccessToken
+	}
+
+	if token.LastUsedAt == nil || time.Since(*token.LastUsedAt) > lastSeenInterval {
+		if err := s.patRepo.Touch(token.Id); err != nil {
+			logrus.Errorf("Ошибка при обновлении времени использования токена %d: %s", token.Id, err.Error())
+		}
+	}
+
+	return entity.Identity{UserId: token.UserId, Scopes: token.Scopes}, nil
+}
+
+// signIn завершает вход пользователя, личность которого уже подтверждена: выдаёт пару токенов
+// или, если включён второй фактор, ChallengeToken.
+func (s *AuthService) signIn(userId int, client entity.Client) (entity.Tokens, error) {
+	enabled, err := s.twoFactor.Enabled(userId)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+	if enabled {
+		challenge, err := s.newChallengeToken(userId)
+		if err != nil {
+			return entity.Tokens{}, err
+		}
+		return entity.Tokens{ChallengeToken: challenge}, nil
+	}
+
+	return s.createSession(userId, client)
+}
+
+func (s *AuthService) createSession(userId int, client entity.Client) (entity.Tokens, error) {
+	refreshToken, err := newRandomToken()
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	expiresAt := time.Now().Add(s.refreshTokenTTL)
+	sessionId, err := s.sessionRepo.Create(entity.Session{
+		UserId:    userId,
+		UserAgent: client.UserAgent,
+		IP:        client.IP,
+		ExpiresAt: expiresAt,
+	}, entity.RefreshToken{
+		TokenHash: hashToken(refreshToken),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	accessToken, err := s.newAccessToken(userId, sessionId)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	return entity.Tokens{AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+func (s *AuthService) newAccessToken(userId, sessionId int) (string, error) {
+	return s.keys.Sign(&tokenClaims{
 		jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(tokenTTL)),
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.accessTokenTTL)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
-		user.Id,
+		userId,
+		sessionId,
 	})
-
-	return token.SignedString([]byte(os.Getenv("JWT_SIGNING_KEY")))
 }
 
-func (s *AuthService) ParseToken(accessToken string) (int, error) {
-	token, err := jwt.ParseWithClaims(accessToken, &tokenClaims{}, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("invalid signing method")
-		}
-		return []byte(os.Getenv("JWT_SIGNING_KEY")), nil
+func (s *AuthService) newChallengeToken(userId int) (string, error) {
+	return s.keys.Sign(&tokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{challengeAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(challengeTokenTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		UserId: userId,
 	})
+}
+
+func (s *AuthService) revokeReused(sessionId int) error {
+	logrus.Warnf("Повторное использование refresh-токена, сессия %d отозвана", sessionId)
+	if err := s.sessionRepo.Revoke(sessionId); err != nil {
+		return err
+	}
+	return ErrRefreshTokenReused
+}
+
+// authenticate проверяет пароль пользователя и при необходимости прозрачно
+// переводит устаревший хэш (SHA-1, старые параметры argon2id) на текущую схему.
+func (s *AuthService) authenticate(username, password string) (entity.User, error) {
+	user, err := s.repo.GetUser(username)
+	if errors.Is(err, sql.ErrNoRows) {
+		_, _ = s.hasher.Verify(password, s.dummyHash)
+		return entity.User{}, ErrInvalidCredentials
+	}
 	if err != nil {
-		return 0, err
+		return entity.User{}, err
 	}
 
-	claims, ok := token.Claims.(*tokenClaims)
+	ok, err := s.hasher.Verify(password, user.Password)
+	if err != nil {
+		return entity.User{}, err
+	}
 	if !ok {
-		return 0, errors.New("token claims are not of type *tokenClaims")
+		return entity.User{}, ErrInvalidCredentials
+	}
+
+	if s.hasher.NeedsRehash(user.Password) {
+		if err := s.rehash(user.Id, password); err != nil {
+			logrus.Errorf("Ошибка при обновлении хэша пароля пользователя %d: %s", user.Id, err.Error())
+		}
+	}
+
+	return user, nil
+}
+
+func (s *AuthService) rehash(userId int, password string) error {
+	passwordHash, err := s.hasher.Hash(password)
+	if err != nil {
+		return err
 	}
+	return s.repo.UpdatePasswordHash(userId, passwordHash)
+}
 
-	return claims.UserId, nil
+func newRandomToken() (string, error) {
+	b := make([]byte, refreshTokenLength)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
-func generatePasswordHash(password string) string {
-	hash := sha1.New()
-	hash.Write([]byte(password))
+// hashToken - в БД хранятся только хэши токенов, поэтому утечка таблицы не позволяет ими воспользоваться.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
 
-	return fmt.Sprintf("%x", hash.Sum([]byte(os.Getenv("JWT_SALT"))))
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == "23505"
+}
+
+// userConflict определяет по имени ограничения, что именно уже занято: логин или адрес.
+func userConflict(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Constraint == "users_email_key" {
+		return ErrEmailTaken
+	}
+	return ErrUsernameTaken
 }
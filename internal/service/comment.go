@@ -0,0 +1,171 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	ErrCommentNotFound      = errors.New("comment not found")
+	ErrInvalidCommentParent = errors.New("parent must be a comment of the same item")
+	ErrNotCommentAuthor     = errors.New("only the author can change a comment")
+)
+
+// CommentService: читать и писать комментарии могут все участники списка задачи, включая наблюдателей.
+type CommentService struct {
+	repo          repository.Comment
+	itemRepo      repository.TodoItem
+	memberRepo    repository.ListMember
+	notifications notifier
+}
+
+func NewCommentService(repo repository.Comment, itemRepo repository.TodoItem, memberRepo repository.ListMember,
+	notifications notifier) *CommentService {
+	return &CommentService{repo: repo, itemRepo: itemRepo, memberRepo: memberRepo, notifications: notifications}
+}
+
+func (s *CommentService) Create(userId, itemId int, input entity.CreateCommentInput) (int, error) {
+	if err := input.Validate(); err != nil {
+		return 0, err
+	}
+
+	item, err := s.getItem(userId, itemId)
+	if err != nil {
+		return 0, err
+	}
+	if input.ParentId != nil {
+		parent, err := s.repo.GetById(itemId, *input.ParentId)
+		if errors.Is(err, sql.ErrNoRows) || (err == nil && parent.Deleted) {
+			return 0, ErrInvalidCommentParent
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	id, err := s.repo.Create(entity.Comment{ItemId: itemId, ParentId: input.ParentId, AuthorId: &userId, Body: input.Body})
+	if err != nil {
+		return 0, err
+	}
+
+	s.notifyMentions(userId, item, input.Body, nil)
+	return id, nil
+}
+
+// GetAll возвращает обсуждение задачи в виде веток, ответы вложены в комментарии, на которые они даны.
+func (s *CommentService) GetAll(userId, itemId int) ([]entity.Comment, error) {
+	if _, err := s.getItem(userId, itemId); err != nil {
+		return nil, err
+	}
+
+	comments, err := s.repo.GetAll(itemId)
+	if err != nil {
+		return nil, err
+	}
+	return entity.BuildCommentTree(comments), nil
+}
+
+// Update меняет текст комментария. Уведомления получают только те, кто упомянут впервые.
+func (s *CommentService) Update(userId, itemId, commentId int, input entity.UpdateCommentInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	item, err := s.getItem(userId, itemId)
+	if err != nil {
+		return err
+	}
+	comment, err := s.getOwnComment(userId, itemId, commentId)
+	if err != nil {
+		return err
+	}
+
+	if err = s.repo.Update(userId, commentId, input.Body); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrCommentNotFound
+		}
+		return err
+	}
+
+	s.notifyMentions(userId, item, input.Body, entity.Mentions(comment.Body))
+	return nil
+}
+
+func (s *CommentService) Delete(userId, itemId, commentId int) error {
+	if _, err := s.getItem(userId, itemId); err != nil {
+		return err
+	}
+	if _, err := s.getOwnComment(userId, itemId, commentId); err != nil {
+		return err
+	}
+
+	err := s.repo.Delete(userId, commentId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrCommentNotFound
+	}
+	return err
+}
+
+func (s *CommentService) getItem(userId, itemId int) (entity.TodoItem, error) {
+	item, err := s.itemRepo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return item, ErrItemNotFound
+	}
+	return item, err
+}
+
+func (s *CommentService) getOwnComment(userId, itemId, commentId int) (entity.Comment, error) {
+	comment, err := s.repo.GetById(itemId, commentId)
+	if errors.Is(err, sql.ErrNoRows) || (err == nil && comment.Deleted) {
+		return comment, ErrCommentNotFound
+	}
+	if err != nil {
+		return comment, err
+	}
+	if comment.AuthorId == nil || *comment.AuthorId != userId {
+		return comment, ErrNotCommentAuthor
+	}
+	return comment, nil
+}
+
+// notifyMentions уведомляет участников списка, упомянутых в тексте, кроме автора и уже упомянутых раньше (known).
+// Упоминания пользователей не из списка игнорируются.
+func (s *CommentService) notifyMentions(userId int, item entity.TodoItem, body string, known []string) {
+	skip := make(map[string]bool, len(known))
+	for _, username := range known {
+		skip[username] = true
+	}
+	mentioned := make(map[string]bool)
+	for _, username := range entity.Mentions(body) {
+		if !skip[username] {
+			mentioned[username] = true
+		}
+	}
+	if len(mentioned) == 0 {
+		return
+	}
+
+	members, err := s.memberRepo.GetAll(item.ListId)
+	if err != nil {
+		logrus.Errorf("Ошибка при получении участников списка %d для упоминаний: %s", item.ListId, err.Error())
+		return
+	}
+
+	for _, member := range members {
+		if member.UserId == userId || !mentioned[member.Username] {
+			continue
+		}
+		s.notifications.Notify(entity.Notification{
+			UserId:  member.UserId,
+			Type:    entity.NotificationMention,
+			Title:   item.Title,
+			Body:    "You were mentioned in a comment",
+			ListId:  &item.ListId,
+			ItemId:  &item.Id,
+			ActorId: &userId,
+		})
+	}
+}
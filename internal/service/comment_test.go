@@ -0,0 +1,211 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentService(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	item := entity.TodoItem{Id: 3, ListId: 2, Title: "Trip"}
+	members := []entity.ListMember{
+		{UserId: 1, Username: "alice", Role: entity.ListRoleOwner},
+		{UserId: 2, Username: "bob", Role: entity.ListRoleViewer},
+		{UserId: 4, Username: "dave", Role: entity.ListRoleEditor},
+	}
+	mention := func(userId int) entity.Notification {
+		return entity.Notification{
+			UserId:  userId,
+			Type:    entity.NotificationMention,
+			Title:   "Trip",
+			Body:    "You were mentioned in a comment",
+			ListId:  intPtr(2),
+			ItemId:  intPtr(3),
+			ActorId: intPtr(1),
+		}
+	}
+
+	type mocks struct {
+		comments      *mock_repository.MockComment
+		items         *mock_repository.MockTodoItem
+		members       *mock_repository.MockListMember
+		notifications *mock_repository.MockNotification
+	}
+
+	tt := []struct {
+		name         string
+		call         func(s *CommentService) error
+		mockBehavior func(m mocks)
+		wantErr      error
+	}{
+		{
+			name: "Create with mentions",
+			call: func(s *CommentService) error {
+				_, err := s.Create(1, 3, entity.CreateCommentInput{Body: "@bob, @carol and @alice: mail me at alice@example.com. cc @bob."})
+				return err
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().Create(entity.Comment{ItemId: 3, AuthorId: intPtr(1),
+					Body: "@bob, @carol and @alice: mail me at alice@example.com. cc @bob."}).Return(5, nil)
+				m.members.EXPECT().GetAll(2).Return(members, nil)
+				m.notifications.EXPECT().Create(mention(2)).Return(1, nil)
+			},
+		},
+		{
+			name: "Create without mentions",
+			call: func(s *CommentService) error {
+				_, err := s.Create(1, 3, entity.CreateCommentInput{Body: "**Booked**"})
+				return err
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().Create(entity.Comment{ItemId: 3, AuthorId: intPtr(1), Body: "**Booked**"}).Return(5, nil)
+			},
+		},
+		{
+			name: "Reply",
+			call: func(s *CommentService) error {
+				_, err := s.Create(1, 3, entity.CreateCommentInput{Body: "Thanks", ParentId: intPtr(4)})
+				return err
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 4).Return(entity.Comment{Id: 4, ItemId: 3, AuthorId: intPtr(2)}, nil)
+				m.comments.EXPECT().Create(entity.Comment{ItemId: 3, ParentId: intPtr(4), AuthorId: intPtr(1), Body: "Thanks"}).Return(5, nil)
+			},
+		},
+		{
+			name: "Reply to comment of other item",
+			call: func(s *CommentService) error {
+				_, err := s.Create(1, 3, entity.CreateCommentInput{Body: "Thanks", ParentId: intPtr(4)})
+				return err
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 4).Return(entity.Comment{}, sql.ErrNoRows)
+			},
+			wantErr: ErrInvalidCommentParent,
+		},
+		{
+			name: "No access to item",
+			call: func(s *CommentService) error {
+				_, err := s.GetAll(1, 3)
+				return err
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(entity.TodoItem{}, sql.ErrNoRows)
+			},
+			wantErr: ErrItemNotFound,
+		},
+		{
+			name: "Update notifies new mentions only",
+			call: func(s *CommentService) error {
+				return s.Update(1, 3, 5, entity.UpdateCommentInput{Body: "@bob @dave"})
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 5).Return(entity.Comment{Id: 5, ItemId: 3, AuthorId: intPtr(1), Body: "@bob"}, nil)
+				m.comments.EXPECT().Update(1, 5, "@bob @dave").Return(nil)
+				m.members.EXPECT().GetAll(2).Return(members, nil)
+				m.notifications.EXPECT().Create(mention(4)).Return(1, nil)
+			},
+		},
+		{
+			name: "Update comment of other user",
+			call: func(s *CommentService) error {
+				return s.Update(1, 3, 5, entity.UpdateCommentInput{Body: "Edited"})
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 5).Return(entity.Comment{Id: 5, ItemId: 3, AuthorId: intPtr(2)}, nil)
+			},
+			wantErr: ErrNotCommentAuthor,
+		},
+		{
+			name: "Update comment of deleted user",
+			call: func(s *CommentService) error {
+				return s.Update(1, 3, 5, entity.UpdateCommentInput{Body: "Edited"})
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 5).Return(entity.Comment{Id: 5, ItemId: 3, Author: entity.DeletedAuthor}, nil)
+			},
+			wantErr: ErrNotCommentAuthor,
+		},
+		{
+			name: "Delete removed comment",
+			call: func(s *CommentService) error {
+				return s.Delete(1, 3, 5)
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 5).Return(entity.Comment{Id: 5, ItemId: 3, AuthorId: intPtr(1), Deleted: true}, nil)
+			},
+			wantErr: ErrCommentNotFound,
+		},
+		{
+			name: "Delete",
+			call: func(s *CommentService) error {
+				return s.Delete(1, 3, 5)
+			},
+			mockBehavior: func(m mocks) {
+				m.items.EXPECT().GetById(1, 3).Return(item, nil)
+				m.comments.EXPECT().GetById(3, 5).Return(entity.Comment{Id: 5, ItemId: 3, AuthorId: intPtr(1)}, nil)
+				m.comments.EXPECT().Delete(1, 5).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			m := mocks{
+				comments:      mock_repository.NewMockComment(c),
+				items:         mock_repository.NewMockTodoItem(c),
+				members:       mock_repository.NewMockListMember(c),
+				notifications: mock_repository.NewMockNotification(c),
+			}
+			tc.mockBehavior(m)
+
+			s := NewCommentService(m.comments, m.items, m.members, NewNotificationService(m.notifications))
+			assert.ErrorIs(t, tc.call(s), tc.wantErr)
+		})
+	}
+}
+
+func TestCommentService_GetAll(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	comments := mock_repository.NewMockComment(c)
+	items := mock_repository.NewMockTodoItem(c)
+	s := NewCommentService(comments, items, mock_repository.NewMockListMember(c), nil)
+
+	parentId, replyId := 1, 2
+	items.EXPECT().GetById(1, 3).Return(entity.TodoItem{Id: 3, ListId: 2}, nil)
+	comments.EXPECT().GetAll(3).Return([]entity.Comment{
+		{Id: 1, Body: "Who books tickets?"},
+		{Id: 2, ParentId: &parentId, Body: "I will"},
+		{Id: 3, Body: "Hotel is booked"},
+		{Id: 4, ParentId: &replyId, Body: "Thanks"},
+	}, nil)
+
+	got, err := s.GetAll(1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []entity.Comment{
+		{Id: 1, Body: "Who books tickets?", Replies: []entity.Comment{
+			{Id: 2, ParentId: &parentId, Body: "I will", Replies: []entity.Comment{
+				{Id: 4, ParentId: &replyId, Body: "Thanks"},
+			}},
+		}},
+		{Id: 3, Body: "Hotel is booked"},
+	}, got)
+}
@@ -1,14 +1,55 @@
 package service
 
-import "github.com/IncubusX/go-todo-app/internal/entity"
+import (
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"io"
+	"time"
+)
 
 //go:generate mockgen -source=interfaces.go -destination=mocks/mock.go
 
 type (
 	Authorization interface {
 		CreateUser(user entity.User) (int, error)
-		GenerateToken(username, password string) (string, error)
-		ParseToken(token string) (int, error)
+		GetProfile(userId int) (entity.Profile, error)
+		UpdateProfile(userId int, input entity.UpdateUserInput) error
+		ChangePassword(userId int, input entity.ChangePasswordInput, client entity.Client) (entity.Tokens, error)
+		DeleteUser(userId int) error
+		GenerateToken(username, password string, client entity.Client) (entity.Tokens, error)
+		SignInTwoFactor(challengeToken, code string, client entity.Client) (entity.Tokens, error)
+		RefreshTokens(refreshToken string) (entity.Tokens, error)
+		Logout(refreshToken string) error
+		ParseToken(token string) (entity.Identity, error)
+		PublicKeys() entity.JSONWebKeySet
+	}
+
+	Account interface {
+		SendVerification(userId int) error
+		VerifyEmail(token string) error
+		ForgotPassword(email string) error
+		ResetPassword(input entity.ResetPasswordInput) error
+	}
+
+	OIDC interface {
+		LoginURL(provider string) (entity.OIDCLogin, error)
+		Callback(provider string, input entity.OIDCCallbackInput, client entity.Client) (entity.Tokens, error)
+	}
+
+	Session interface {
+		GetAll(userId, currentSessionId int) ([]entity.Session, error)
+		Revoke(userId, sessionId int) error
+	}
+
+	TwoFactor interface {
+		Enroll(userId int) (entity.TwoFactorEnrollment, error)
+		Confirm(userId int, code string) ([]string, error)
+		Disable(userId int, code string) error
+	}
+
+	PersonalAccessToken interface {
+		Create(userId int, input entity.CreatePersonalAccessTokenInput) (entity.PersonalAccessToken, error)
+		GetAll(userId int) ([]entity.PersonalAccessToken, error)
+		Revoke(userId, tokenId int) error
 	}
 
 	TodoList interface {
@@ -17,13 +58,92 @@ type (
 		GetById(userId, listId int) (entity.TodoList, error)
 		Update(userId, listId int, input entity.UpdateListInput) error
 		Delete(userId, listId int) error
+		Move(userId, listId int, move entity.MoveInput) error
+		Duplicate(userId, listId int, input entity.DuplicateListInput) (int, error)
+	}
+
+	ListTemplate interface {
+		Save(userId, listId int, input entity.SaveTemplateInput) (int, error)
+		GetAll(userId int) ([]entity.ListTemplate, error)
+		GetById(userId, templateId int) (entity.ListTemplate, error)
+		Delete(userId, templateId int) error
+		Instantiate(userId, templateId int, input entity.InstantiateTemplateInput) (int, error)
+	}
+
+	ListMember interface {
+		GetAll(userId, listId int) ([]entity.ListMember, error)
+		UpdateRole(userId, listId, memberId int, input entity.UpdateMemberInput) error
+		Remove(userId, listId, memberId int) error
+	}
+
+	Invitation interface {
+		Invite(userId, listId int, input entity.InviteMemberInput) (entity.ListInvitation, error)
+		GetAll(userId int) ([]entity.ListInvitation, error)
+		Accept(userId, invitationId int) (entity.TodoList, error)
+		Decline(userId, invitationId int) error
+		CreateLink(userId, listId int, input entity.CreateInviteLinkInput) (entity.InviteLink, error)
+		RevokeLink(userId, listId, linkId int) error
+		AcceptLink(userId int, token string) (entity.TodoList, error)
+	}
+
+	PublicLink interface {
+		Publish(userId, listId int, input entity.PublishListInput) (entity.PublicLink, error)
+		Unpublish(userId, listId int) error
+		Get(token, password, ip string) (entity.PublicList, error)
 	}
 
 	TodoItem interface {
 		Create(userId, listId int, input entity.TodoItem) (int, error)
-		GetAll(userId, listId int) ([]entity.TodoItem, error)
+		GetAll(userId, listId int, filter entity.ItemFilter) ([]entity.TodoItem, error)
+		Search(userId int, filter entity.ItemFilter) ([]entity.TodoItem, error)
 		GetById(userId, itemId int) (entity.TodoItem, error)
 		Update(userId, itemId int, input entity.UpdateItemInput) error
-		Delete(userId, itemId int) error
+		Delete(userId, itemId int, keepChildren bool) error
+		Move(userId, itemId int, move entity.MoveItemInput) error
+		Copy(userId, itemId int, input entity.CopyItemInput) (int, error)
+		Skip(userId, itemId int) error
+		Occurrences(userId, itemId, count int) ([]time.Time, error)
+		GetDue(userId int, period string) ([]entity.TodoItem, error)
+		GetAssigned(userId int) ([]entity.TodoItem, error)
+	}
+
+	Label interface {
+		Create(userId int, label entity.Label) (int, error)
+		GetAll(userId int) ([]entity.Label, error)
+		GetById(userId, labelId int) (entity.Label, error)
+		Update(userId, labelId int, input entity.UpdateLabelInput) error
+		Delete(userId, labelId int) error
+		Attach(userId, itemId, labelId int) error
+		Detach(userId, itemId, labelId int) error
+	}
+
+	Notification interface {
+		GetAll(userId int, filter entity.NotificationFilter) ([]entity.Notification, error)
+		CountUnread(userId int) (map[string]int, error)
+		MarkRead(userId, notificationId int) error
+		MarkAllRead(userId int) error
+		GetPreferences(userId int) ([]entity.NotificationPreference, error)
+		UpdatePreferences(userId int, input entity.UpdateNotificationPreferencesInput) error
+	}
+
+	Reminder interface {
+		Create(userId, itemId int, reminder entity.Reminder) (int, error)
+		GetAll(userId, itemId int) ([]entity.Reminder, error)
+		Delete(userId, itemId, reminderId int) error
+	}
+
+	Comment interface {
+		Create(userId, itemId int, input entity.CreateCommentInput) (int, error)
+		GetAll(userId, itemId int) ([]entity.Comment, error)
+		Update(userId, itemId, commentId int, input entity.UpdateCommentInput) error
+		Delete(userId, itemId, commentId int) error
+	}
+
+	Attachment interface {
+		Create(userId, itemId int, upload entity.AttachmentUpload) (int, error)
+		GetAll(userId, itemId int) ([]entity.Attachment, error)
+		Open(userId, itemId, attachmentId int) (entity.Attachment, io.ReadSeekCloser, error)
+		Delete(userId, itemId, attachmentId int) error
+		MaxSize() int64
 	}
 )
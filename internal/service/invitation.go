@@ -0,0 +1,221 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const inviteLinkAudience = "list-invite"
+
+var (
+	ErrUserNotFound       = errors.New("user not found")
+	ErrAlreadyMember      = errors.New("user is already a member of the list")
+	ErrAlreadyInvited     = errors.New("user is already invited to the list")
+	ErrInvitationNotFound = errors.New("invitation not found")
+	ErrInviteLinkNotFound = errors.New("invite link not found")
+	ErrInvalidInviteLink  = errors.New("invalid or expired invite link")
+)
+
+// inviteLinkClaims подписываются ключами приложения. Роль и срок берутся из БД,
+// запись нужна, чтобы ссылку можно было отозвать и погасить после единственного использования.
+type inviteLinkClaims struct {
+	jwt.RegisteredClaims
+	ListId int `json:"list_id"`
+}
+
+type InvitationService struct {
+	repo          repository.ListInvitation
+	linkRepo      repository.InviteLink
+	listRepo      repository.TodoList
+	userRepo      repository.Authorization
+	notifications notifier
+	keys          *keyring.KeyRing
+	appURL        string
+}
+
+func NewInvitationService(repo repository.ListInvitation, linkRepo repository.InviteLink, listRepo repository.TodoList,
+	userRepo repository.Authorization, notifications notifier, keys *keyring.KeyRing, appURL string) *InvitationService {
+	return &InvitationService{
+		repo:          repo,
+		linkRepo:      linkRepo,
+		listRepo:      listRepo,
+		userRepo:      userRepo,
+		notifications: notifications,
+		keys:          keys,
+		appURL:        strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+// Invite приглашает пользователя в список и уведомляет его об этом. Участником он станет только после того, как примет приглашение.
+func (s *InvitationService) Invite(userId, listId int, input entity.InviteMemberInput) (entity.ListInvitation, error) {
+	if err := input.Validate(); err != nil {
+		return entity.ListInvitation{}, err
+	}
+	if err := requireListRole(s.listRepo, userId, listId, isListOwner); err != nil {
+		return entity.ListInvitation{}, err
+	}
+
+	user, err := s.userRepo.GetUser(input.Username)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.ListInvitation{}, ErrUserNotFound
+	}
+	if err != nil {
+		return entity.ListInvitation{}, err
+	}
+
+	_, err = s.listRepo.GetRole(user.Id, listId)
+	if err == nil {
+		return entity.ListInvitation{}, ErrAlreadyMember
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return entity.ListInvitation{}, err
+	}
+
+	list, err := s.listRepo.GetById(userId, listId)
+	if err != nil {
+		return entity.ListInvitation{}, err
+	}
+
+	invitation := entity.ListInvitation{
+		ListId:    listId,
+		UserId:    user.Id,
+		InviterId: userId,
+		Role:      input.Role,
+	}
+	invitation.Id, err = s.repo.Create(invitation)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return entity.ListInvitation{}, ErrAlreadyInvited
+		}
+		return entity.ListInvitation{}, err
+	}
+
+	s.notifications.Notify(entity.Notification{
+		UserId:  user.Id,
+		Type:    entity.NotificationListInvitation,
+		Title:   list.Title,
+		Body:    "You are invited to the list as " + input.Role,
+		ListId:  &listId,
+		ActorId: &userId,
+	})
+
+	return invitation, nil
+}
+
+func (s *InvitationService) GetAll(userId int) ([]entity.ListInvitation, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *InvitationService) Accept(userId, invitationId int) (entity.TodoList, error) {
+	invitation, err := s.repo.Accept(userId, invitationId)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return entity.TodoList{}, ErrInvitationNotFound
+		case isUniqueViolation(err):
+			return entity.TodoList{}, ErrAlreadyMember
+		}
+		return entity.TodoList{}, err
+	}
+
+	return s.listRepo.GetById(userId, invitation.ListId)
+}
+
+func (s *InvitationService) Decline(userId, invitationId int) error {
+	err := s.repo.Decline(userId, invitationId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvitationNotFound
+	}
+	return err
+}
+
+// CreateLink выпускает подписанную ссылку-приглашение. Токен возвращается только здесь.
+func (s *InvitationService) CreateLink(userId, listId int, input entity.CreateInviteLinkInput) (entity.InviteLink, error) {
+	if err := input.Validate(); err != nil {
+		return entity.InviteLink{}, err
+	}
+	if err := requireListRole(s.listRepo, userId, listId, isListOwner); err != nil {
+		return entity.InviteLink{}, err
+	}
+
+	link := entity.InviteLink{
+		ListId:    listId,
+		CreatedBy: userId,
+		Role:      input.Role,
+		SingleUse: input.SingleUse,
+		ExpiresAt: time.Now().Add(input.TTL()).Truncate(time.Second),
+	}
+
+	var err error
+	link.Id, err = s.linkRepo.Create(link)
+	if err != nil {
+		return entity.InviteLink{}, err
+	}
+
+	link.Token, err = s.keys.Sign(&inviteLinkClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        strconv.Itoa(link.Id),
+			Audience:  jwt.ClaimStrings{inviteLinkAudience},
+			ExpiresAt: jwt.NewNumericDate(link.ExpiresAt),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		ListId: listId,
+	})
+	if err != nil {
+		return entity.InviteLink{}, err
+	}
+	link.URL = s.appURL + "/invite?token=" + link.Token
+
+	return link, nil
+}
+
+func (s *InvitationService) RevokeLink(userId, listId, linkId int) error {
+	if err := requireListRole(s.listRepo, userId, listId, isListOwner); err != nil {
+		return err
+	}
+
+	err := s.linkRepo.Delete(listId, linkId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInviteLinkNotFound
+	}
+	return err
+}
+
+// AcceptLink добавляет обладателя ссылки в список с ролью, заданной при её создании.
+func (s *InvitationService) AcceptLink(userId int, token string) (entity.TodoList, error) {
+	parsed, err := jwt.ParseWithClaims(token, &inviteLinkClaims{}, s.keys.Keyfunc, jwt.WithAudience(inviteLinkAudience))
+	if err != nil {
+		return entity.TodoList{}, ErrInvalidInviteLink
+	}
+	claims, ok := parsed.Claims.(*inviteLinkClaims)
+	if !ok || claims.ExpiresAt == nil {
+		return entity.TodoList{}, ErrInvalidInviteLink
+	}
+	linkId, err := strconv.Atoi(claims.ID)
+	if err != nil {
+		return entity.TodoList{}, ErrInvalidInviteLink
+	}
+
+	link, err := s.linkRepo.Redeem(linkId, userId)
+	if err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return entity.TodoList{}, ErrInvalidInviteLink
+		case isUniqueViolation(err):
+			return entity.TodoList{}, ErrAlreadyMember
+		}
+		return entity.TodoList{}, err
+	}
+	if link.ListId != claims.ListId {
+		return entity.TodoList{}, ErrInvalidInviteLink
+	}
+
+	return s.listRepo.GetById(userId, link.ListId)
+}
@@ -0,0 +1,172 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type invitationTestRepos struct {
+	invitations   *mock_repository.MockListInvitation
+	links         *mock_repository.MockInviteLink
+	lists         *mock_repository.MockTodoList
+	users         *mock_repository.MockAuthorization
+	notifications *mock_repository.MockNotification
+}
+
+func newTestInvitationService(c *gomock.Controller) (*InvitationService, invitationTestRepos) {
+	repos := invitationTestRepos{
+		invitations:   mock_repository.NewMockListInvitation(c),
+		links:         mock_repository.NewMockInviteLink(c),
+		lists:         mock_repository.NewMockTodoList(c),
+		users:         mock_repository.NewMockAuthorization(c),
+		notifications: mock_repository.NewMockNotification(c),
+	}
+	keys, _ := keyring.NewKeyRing("test", keyring.NewHMACKey("test", []byte("secret")))
+
+	return NewInvitationService(repos.invitations, repos.links, repos.lists, repos.users,
+		NewNotificationService(repos.notifications), keys, "https://todo.example.com/"), repos
+}
+
+func TestInvitationService_Invite(t *testing.T) {
+	input := entity.InviteMemberInput{Username: "bob", Role: entity.ListRoleEditor}
+
+	tt := []struct {
+		name         string
+		mockBehavior func(r invitationTestRepos)
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(r invitationTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+				r.users.EXPECT().GetUser("bob").Return(entity.User{Id: 3, Username: "bob"}, nil)
+				r.lists.EXPECT().GetRole(3, 2).Return("", sql.ErrNoRows)
+				r.lists.EXPECT().GetById(1, 2).Return(entity.TodoList{Id: 2, Title: "Groceries"}, nil)
+				r.invitations.EXPECT().Create(entity.ListInvitation{ListId: 2, UserId: 3, InviterId: 1, Role: entity.ListRoleEditor}).Return(5, nil)
+				listId, actorId := 2, 1
+				r.notifications.EXPECT().Create(entity.Notification{UserId: 3, Type: entity.NotificationListInvitation, Title: "Groceries",
+					Body: "You are invited to the list as editor", ListId: &listId, ActorId: &actorId}).Return(7, nil)
+			},
+		},
+		{
+			name: "Editor",
+			mockBehavior: func(r invitationTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleEditor, nil)
+			},
+			wantErr: ErrInsufficientRole,
+		},
+		{
+			name: "Unknown user",
+			mockBehavior: func(r invitationTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+				r.users.EXPECT().GetUser("bob").Return(entity.User{}, sql.ErrNoRows)
+			},
+			wantErr: ErrUserNotFound,
+		},
+		{
+			name: "Already member",
+			mockBehavior: func(r invitationTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+				r.users.EXPECT().GetUser("bob").Return(entity.User{Id: 3, Username: "bob"}, nil)
+				r.lists.EXPECT().GetRole(3, 2).Return(entity.ListRoleViewer, nil)
+			},
+			wantErr: ErrAlreadyMember,
+		},
+		{
+			name: "Already invited",
+			mockBehavior: func(r invitationTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+				r.users.EXPECT().GetUser("bob").Return(entity.User{Id: 3, Username: "bob"}, nil)
+				r.lists.EXPECT().GetRole(3, 2).Return("", sql.ErrNoRows)
+				r.lists.EXPECT().GetById(1, 2).Return(entity.TodoList{Id: 2, Title: "Groceries"}, nil)
+				r.invitations.EXPECT().Create(gomock.Any()).Return(0, &pq.Error{Code: "23505"})
+			},
+			wantErr: ErrAlreadyInvited,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, repos := newTestInvitationService(c)
+			tc.mockBehavior(repos)
+
+			invitation, err := s.Invite(1, 2, input)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantErr == nil {
+				assert.Equal(t, 5, invitation.Id)
+			}
+		})
+	}
+}
+
+func TestInvitationService_Accept(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, repos := newTestInvitationService(c)
+
+	repos.invitations.EXPECT().Accept(3, 5).Return(entity.ListInvitation{Id: 5, ListId: 2, Role: entity.ListRoleViewer}, nil)
+	repos.lists.EXPECT().GetById(3, 2).Return(entity.TodoList{Id: 2, Role: entity.ListRoleViewer}, nil)
+	list, err := s.Accept(3, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.Id)
+
+	repos.invitations.EXPECT().Accept(3, 6).Return(entity.ListInvitation{}, sql.ErrNoRows)
+	_, err = s.Accept(3, 6)
+	assert.ErrorIs(t, err, ErrInvitationNotFound)
+}
+
+func TestInvitationService_Link(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, repos := newTestInvitationService(c)
+
+	repos.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+	repos.links.EXPECT().Create(gomock.Any()).DoAndReturn(func(link entity.InviteLink) (int, error) {
+		assert.Equal(t, entity.ListRoleViewer, link.Role)
+		assert.True(t, link.SingleUse)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), link.ExpiresAt, time.Minute)
+		return 7, nil
+	})
+
+	link, err := s.CreateLink(1, 2, entity.CreateInviteLinkInput{Role: entity.ListRoleViewer, ExpiresIn: 3600, SingleUse: true})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "https://todo.example.com/invite?token="+link.Token, link.URL)
+
+	repos.links.EXPECT().Redeem(7, 3).Return(entity.InviteLink{Id: 7, ListId: 2, Role: entity.ListRoleViewer}, nil)
+	repos.lists.EXPECT().GetById(3, 2).Return(entity.TodoList{Id: 2, Role: entity.ListRoleViewer}, nil)
+	list, err := s.AcceptLink(3, link.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, list.Id)
+
+	repos.links.EXPECT().Redeem(7, 3).Return(entity.InviteLink{}, sql.ErrNoRows)
+	_, err = s.AcceptLink(3, link.Token)
+	assert.ErrorIs(t, err, ErrInvalidInviteLink, "использованная ссылка")
+
+	repos.links.EXPECT().Redeem(7, 3).Return(entity.InviteLink{}, &pq.Error{Code: "23505"})
+	_, err = s.AcceptLink(3, link.Token)
+	assert.ErrorIs(t, err, ErrAlreadyMember)
+
+	parts := strings.Split(link.Token, ".")
+	_, err = s.AcceptLink(3, parts[0]+"."+parts[1]+".forged")
+	assert.ErrorIs(t, err, ErrInvalidInviteLink)
+
+	state, _ := s.keys.Sign(&oidcStateClaims{})
+	_, err = s.AcceptLink(3, state)
+	assert.ErrorIs(t, err, ErrInvalidInviteLink, "токен с чужой аудиторией")
+}
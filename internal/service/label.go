@@ -0,0 +1,100 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+)
+
+var (
+	ErrLabelNotFound = errors.New("label not found")
+	ErrLabelExists   = errors.New("label with this name already exists")
+)
+
+type LabelService struct {
+	repo     repository.Label
+	itemRepo repository.TodoItem
+}
+
+func NewLabelService(repo repository.Label, itemRepo repository.TodoItem) *LabelService {
+	return &LabelService{repo: repo, itemRepo: itemRepo}
+}
+
+func (s *LabelService) Create(userId int, label entity.Label) (int, error) {
+	if err := label.Validate(); err != nil {
+		return 0, err
+	}
+
+	id, err := s.repo.Create(userId, label)
+	if isUniqueViolation(err) {
+		return 0, ErrLabelExists
+	}
+	return id, err
+}
+
+func (s *LabelService) GetAll(userId int) ([]entity.Label, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *LabelService) GetById(userId, labelId int) (entity.Label, error) {
+	label, err := s.repo.GetById(userId, labelId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return label, ErrLabelNotFound
+	}
+	return label, err
+}
+
+func (s *LabelService) Update(userId, labelId int, input entity.UpdateLabelInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+
+	err := s.repo.Update(userId, labelId, input)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrLabelNotFound
+	case isUniqueViolation(err):
+		return ErrLabelExists
+	}
+	return err
+}
+
+func (s *LabelService) Delete(userId, labelId int) error {
+	err := s.repo.Delete(userId, labelId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLabelNotFound
+	}
+	return err
+}
+
+// Attach вешает метку пользователя на задачу. Редактировать задачу пользователь должен иметь право,
+// а метка должна принадлежать ему самому.
+func (s *LabelService) Attach(userId, itemId, labelId int) error {
+	if err := s.checkAccess(userId, itemId, labelId); err != nil {
+		return err
+	}
+	return s.repo.Attach(itemId, labelId)
+}
+
+func (s *LabelService) Detach(userId, itemId, labelId int) error {
+	if err := s.checkAccess(userId, itemId, labelId); err != nil {
+		return err
+	}
+
+	err := s.repo.Detach(itemId, labelId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrLabelNotFound
+	}
+	return err
+}
+
+func (s *LabelService) checkAccess(userId, itemId, labelId int) error {
+	if err := requireItemEditor(s.itemRepo, userId, itemId); err != nil {
+		return err
+	}
+	if _, err := s.GetById(userId, labelId); err != nil {
+		return err
+	}
+	return nil
+}
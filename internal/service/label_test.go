@@ -0,0 +1,81 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabelService_Attach(t *testing.T) {
+	tt := []struct {
+		name         string
+		mockBehavior func(labels *mock_repository.MockLabel, items *mock_repository.MockTodoItem)
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			mockBehavior: func(labels *mock_repository.MockLabel, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetRole(1, 3).Return(entity.ListRoleEditor, nil)
+				labels.EXPECT().GetById(1, 2).Return(entity.Label{Id: 2, UserId: 1, Name: "work"}, nil)
+				labels.EXPECT().Attach(3, 2).Return(nil)
+			},
+		},
+		{
+			name: "Viewer",
+			mockBehavior: func(labels *mock_repository.MockLabel, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetRole(1, 3).Return(entity.ListRoleViewer, nil)
+			},
+			wantErr: ErrInsufficientRole,
+		},
+		{
+			name: "Item not found",
+			mockBehavior: func(labels *mock_repository.MockLabel, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetRole(1, 3).Return("", sql.ErrNoRows)
+			},
+			wantErr: ErrItemNotFound,
+		},
+		{
+			name: "Foreign label",
+			mockBehavior: func(labels *mock_repository.MockLabel, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetRole(1, 3).Return(entity.ListRoleOwner, nil)
+				labels.EXPECT().GetById(1, 2).Return(entity.Label{}, sql.ErrNoRows)
+			},
+			wantErr: ErrLabelNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			labels := mock_repository.NewMockLabel(c)
+			items := mock_repository.NewMockTodoItem(c)
+			tc.mockBehavior(labels, items)
+
+			s := NewLabelService(labels, items)
+			assert.ErrorIs(t, s.Attach(1, 3, 2), tc.wantErr)
+		})
+	}
+}
+
+func TestLabelService_Create(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	labels := mock_repository.NewMockLabel(c)
+	s := NewLabelService(labels, mock_repository.NewMockTodoItem(c))
+
+	labels.EXPECT().Create(1, entity.Label{Name: "work", Color: "#808080"}).Return(0, &pq.Error{Code: "23505"})
+	_, err := s.Create(1, entity.Label{Name: " #work"})
+	assert.ErrorIs(t, err, ErrLabelExists)
+
+	labels.EXPECT().Update(1, 2, gomock.Any()).Return(sql.ErrNoRows)
+	color := "#00ff00"
+	assert.ErrorIs(t, s.Update(1, 2, entity.UpdateLabelInput{Color: &color}), ErrLabelNotFound)
+}
@@ -0,0 +1,92 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+)
+
+var (
+	ErrMemberNotFound = errors.New("list member not found")
+	ErrLastOwner      = errors.New("list must keep at least one owner")
+)
+
+type ListMemberService struct {
+	repo     repository.ListMember
+	listRepo repository.TodoList
+}
+
+func NewListMemberService(repo repository.ListMember, listRepo repository.TodoList) *ListMemberService {
+	return &ListMemberService{repo: repo, listRepo: listRepo}
+}
+
+func (s *ListMemberService) GetAll(userId, listId int) ([]entity.ListMember, error) {
+	if err := requireListRole(s.listRepo, userId, listId, entity.ValidListRole); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(listId)
+}
+
+func (s *ListMemberService) UpdateRole(userId, listId, memberId int, input entity.UpdateMemberInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	if err := requireListRole(s.listRepo, userId, listId, isListOwner); err != nil {
+		return err
+	}
+
+	role, err := s.memberRole(listId, memberId)
+	if err != nil {
+		return err
+	}
+	if role == entity.ListRoleOwner && input.Role != entity.ListRoleOwner {
+		if err = s.requireAnotherOwner(listId); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.UpdateRole(listId, memberId, input.Role)
+}
+
+// Remove исключает участника из списка. Владелец может исключить любого, остальные - только покинуть список сами.
+func (s *ListMemberService) Remove(userId, listId, memberId int) error {
+	allowed := isListOwner
+	if memberId == userId {
+		allowed = entity.ValidListRole
+	}
+	if err := requireListRole(s.listRepo, userId, listId, allowed); err != nil {
+		return err
+	}
+
+	role, err := s.memberRole(listId, memberId)
+	if err != nil {
+		return err
+	}
+	if role == entity.ListRoleOwner {
+		if err = s.requireAnotherOwner(listId); err != nil {
+			return err
+		}
+	}
+
+	return s.repo.Remove(listId, memberId)
+}
+
+func (s *ListMemberService) memberRole(listId, memberId int) (string, error) {
+	role, err := s.listRepo.GetRole(memberId, listId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", ErrMemberNotFound
+	}
+	return role, err
+}
+
+func (s *ListMemberService) requireAnotherOwner(listId int) error {
+	owners, err := s.repo.CountOwners(listId)
+	if err != nil {
+		return err
+	}
+	if owners <= 1 {
+		return ErrLastOwner
+	}
+	return nil
+}
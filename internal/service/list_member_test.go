@@ -0,0 +1,124 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type listMemberTestRepos struct {
+	members *mock_repository.MockListMember
+	lists   *mock_repository.MockTodoList
+}
+
+func newTestListMemberService(c *gomock.Controller) (*ListMemberService, listMemberTestRepos) {
+	repos := listMemberTestRepos{
+		members: mock_repository.NewMockListMember(c),
+		lists:   mock_repository.NewMockTodoList(c),
+	}
+	return NewListMemberService(repos.members, repos.lists), repos
+}
+
+func TestListMemberService_Remove(t *testing.T) {
+	tt := []struct {
+		name         string
+		memberId     int
+		mockBehavior func(r listMemberTestRepos)
+		wantErr      error
+	}{
+		{
+			name:     "Owner removes editor",
+			memberId: 3,
+			mockBehavior: func(r listMemberTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+				r.lists.EXPECT().GetRole(3, 2).Return(entity.ListRoleEditor, nil)
+				r.members.EXPECT().Remove(2, 3).Return(nil)
+			},
+		},
+		{
+			name:     "Viewer leaves",
+			memberId: 1,
+			mockBehavior: func(r listMemberTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleViewer, nil).Times(2)
+				r.members.EXPECT().Remove(2, 1).Return(nil)
+			},
+		},
+		{
+			name:     "Viewer removes other",
+			memberId: 3,
+			mockBehavior: func(r listMemberTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleViewer, nil)
+			},
+			wantErr: ErrInsufficientRole,
+		},
+		{
+			name:     "Last owner leaves",
+			memberId: 1,
+			mockBehavior: func(r listMemberTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil).Times(2)
+				r.members.EXPECT().CountOwners(2).Return(1, nil)
+			},
+			wantErr: ErrLastOwner,
+		},
+		{
+			name:     "Not a member",
+			memberId: 3,
+			mockBehavior: func(r listMemberTestRepos) {
+				r.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+				r.lists.EXPECT().GetRole(3, 2).Return("", sql.ErrNoRows)
+			},
+			wantErr: ErrMemberNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, repos := newTestListMemberService(c)
+			tc.mockBehavior(repos)
+
+			assert.ErrorIs(t, s.Remove(1, 2, tc.memberId), tc.wantErr)
+		})
+	}
+}
+
+func TestListMemberService_UpdateRole(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, repos := newTestListMemberService(c)
+	repos.lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil).Times(2)
+	repos.members.EXPECT().CountOwners(2).Return(2, nil)
+	repos.members.EXPECT().UpdateRole(2, 1, entity.ListRoleViewer).Return(nil)
+
+	assert.NoError(t, s.UpdateRole(1, 2, 1, entity.UpdateMemberInput{Role: entity.ListRoleViewer}))
+}
+
+func TestTodoItemService_RoleChecks(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	items := mock_repository.NewMockTodoItem(c)
+	lists := mock_repository.NewMockTodoList(c)
+	s := NewTodoItemService(items, lists, nil, nil)
+
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleViewer, nil)
+	assert.ErrorIs(t, s.Delete(1, 5, false), ErrInsufficientRole)
+
+	items.EXPECT().GetRole(1, 6).Return("", sql.ErrNoRows)
+	assert.ErrorIs(t, s.Delete(1, 6, false), ErrItemNotFound)
+
+	lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleViewer, nil)
+	_, err := s.Create(1, 2, entity.TodoItem{Title: "Item"})
+	assert.ErrorIs(t, err, ErrInsufficientRole)
+
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().Delete(1, 5, false).Return(nil)
+	assert.NoError(t, s.Delete(1, 5, false))
+}
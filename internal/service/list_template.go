@@ -0,0 +1,96 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"strings"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("template not found")
+	ErrMissingVariables = errors.New("missing template variables")
+)
+
+type ListTemplateService struct {
+	repo     repository.ListTemplate
+	listRepo repository.TodoList
+}
+
+func NewListTemplateService(repo repository.ListTemplate, listRepo repository.TodoList) *ListTemplateService {
+	return &ListTemplateService{repo: repo, listRepo: listRepo}
+}
+
+// Save сохраняет список как шаблон. Шаблон принадлежит пользователю, поэтому подходит любая роль в списке.
+func (s *ListTemplateService) Save(userId, listId int, input entity.SaveTemplateInput) (int, error) {
+	title := strings.TrimSpace(input.Title)
+	if err := checkTitleLength(title); err != nil {
+		return 0, err
+	}
+	if err := requireListRole(s.listRepo, userId, listId, entity.ValidListRole); err != nil {
+		return 0, err
+	}
+	return s.repo.CreateFromList(userId, listId, title)
+}
+
+func (s *ListTemplateService) GetAll(userId int) ([]entity.ListTemplate, error) {
+	return s.repo.GetAll(userId)
+}
+
+func (s *ListTemplateService) GetById(userId, templateId int) (entity.ListTemplate, error) {
+	template, err := s.repo.GetById(userId, templateId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return template, ErrTemplateNotFound
+	}
+	if err != nil {
+		return template, err
+	}
+
+	template.Variables = template.Placeholders()
+	return template, nil
+}
+
+func (s *ListTemplateService) Delete(userId, templateId int) error {
+	err := s.repo.Delete(userId, templateId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTemplateNotFound
+	}
+	return err
+}
+
+// Instantiate создаёт из шаблона новый список, подставив значения переменных.
+func (s *ListTemplateService) Instantiate(userId, templateId int, input entity.InstantiateTemplateInput) (int, error) {
+	template, err := s.GetById(userId, templateId)
+	if err != nil {
+		return 0, err
+	}
+
+	var missing []string
+	for _, name := range template.Variables {
+		if _, ok := input.Variables[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		return 0, fmt.Errorf("%w: %s", ErrMissingVariables, strings.Join(missing, ", "))
+	}
+
+	template.Render(input.Variables)
+	if title := strings.TrimSpace(input.Title); title != "" {
+		template.Title = title
+	}
+
+	// Значения переменных могут удлинить названия сверх длины колонки
+	if err := checkTitleLength(template.Title); err != nil {
+		return 0, err
+	}
+	for _, item := range template.Items {
+		if err := checkTitleLength(item.Title); err != nil {
+			return 0, err
+		}
+	}
+
+	return s.repo.Instantiate(userId, template)
+}
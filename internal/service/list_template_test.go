@@ -0,0 +1,128 @@
+package service
+
+import (
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListTemplateService_Instantiate(t *testing.T) {
+	parentId := 7
+	// Render меняет задачи на месте, поэтому каждый вызов GetById получает свою копию
+	template := func() entity.ListTemplate {
+		return entity.ListTemplate{
+			Id:    3,
+			Title: "Trip to {{ city }}",
+			Items: []entity.TemplateItem{
+				{Id: 7, Title: "Book hotel in {{city}}", Position: 65536},
+				{Id: 8, ParentId: &parentId, Title: "Pay", Description: "Before {{date}}", Position: 65536},
+			},
+		}
+	}
+
+	tt := []struct {
+		name         string
+		input        entity.InstantiateTemplateInput
+		mockBehavior func(templates *mock_repository.MockListTemplate)
+		wantId       int
+		wantErr      error
+		wantMessage  string
+	}{
+		{
+			name:  "Ok",
+			input: entity.InstantiateTemplateInput{Variables: map[string]string{"city": "Rome", "date": "May 1"}},
+			mockBehavior: func(templates *mock_repository.MockListTemplate) {
+				templates.EXPECT().GetById(1, 3).Return(template(), nil)
+				templates.EXPECT().Instantiate(1, entity.ListTemplate{
+					Id:        3,
+					Title:     "Trip to Rome",
+					Variables: []string{"city", "date"},
+					Items: []entity.TemplateItem{
+						{Id: 7, Title: "Book hotel in Rome", Position: 65536},
+						{Id: 8, ParentId: &parentId, Title: "Pay", Description: "Before May 1", Position: 65536},
+					},
+				}).Return(10, nil)
+			},
+			wantId: 10,
+		},
+		{
+			name:  "Title override",
+			input: entity.InstantiateTemplateInput{Title: " Holidays ", Variables: map[string]string{"city": "Rome", "date": ""}},
+			mockBehavior: func(templates *mock_repository.MockListTemplate) {
+				templates.EXPECT().GetById(1, 3).Return(template(), nil)
+				templates.EXPECT().Instantiate(1, gomock.Any()).DoAndReturn(func(userId int, template entity.ListTemplate) (int, error) {
+					assert.Equal(t, "Holidays", template.Title)
+					assert.Equal(t, "Before ", template.Items[1].Description)
+					return 11, nil
+				})
+			},
+			wantId: 11,
+		},
+		{
+			name:  "Missing variables",
+			input: entity.InstantiateTemplateInput{Variables: map[string]string{"town": "Rome"}},
+			mockBehavior: func(templates *mock_repository.MockListTemplate) {
+				templates.EXPECT().GetById(1, 3).Return(template(), nil)
+			},
+			wantErr:     ErrMissingVariables,
+			wantMessage: "missing template variables: city, date",
+		},
+		{
+			name:  "Title too long",
+			input: entity.InstantiateTemplateInput{Variables: map[string]string{"city": strings.Repeat("я", 250), "date": ""}},
+			mockBehavior: func(templates *mock_repository.MockListTemplate) {
+				templates.EXPECT().GetById(1, 3).Return(template(), nil)
+			},
+			wantErr: ErrTitleTooLong,
+		},
+		{
+			name: "Not found",
+			mockBehavior: func(templates *mock_repository.MockListTemplate) {
+				templates.EXPECT().GetById(1, 3).Return(entity.ListTemplate{}, sql.ErrNoRows)
+			},
+			wantErr: ErrTemplateNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			templates := mock_repository.NewMockListTemplate(c)
+			tc.mockBehavior(templates)
+
+			s := NewListTemplateService(templates, mock_repository.NewMockTodoList(c))
+			id, err := s.Instantiate(1, 3, tc.input)
+			assert.ErrorIs(t, err, tc.wantErr)
+			if tc.wantMessage != "" {
+				assert.EqualError(t, err, tc.wantMessage)
+			}
+			assert.Equal(t, tc.wantId, id)
+		})
+	}
+}
+
+func TestListTemplateService_Save(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	templates := mock_repository.NewMockListTemplate(c)
+	lists := mock_repository.NewMockTodoList(c)
+	s := NewListTemplateService(templates, lists)
+
+	lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleViewer, nil)
+	templates.EXPECT().CreateFromList(1, 2, "Weekly").Return(4, nil)
+	id, err := s.Save(1, 2, entity.SaveTemplateInput{Title: " Weekly "})
+	assert.NoError(t, err)
+	assert.Equal(t, 4, id)
+
+	lists.EXPECT().GetRole(1, 5).Return("", sql.ErrNoRows)
+	_, err = s.Save(1, 5, entity.SaveTemplateInput{})
+	assert.ErrorIs(t, err, ErrListNotFound)
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/sirupsen/logrus"
+	"strings"
+	"time"
+)
+
+var ErrTooManyAttempts = errors.New("too many failed sign-in attempts, try again later")
+
+// LockoutError сообщает, до какого момента вход заблокирован. errors.Is(err, ErrTooManyAttempts) для неё истинно.
+type LockoutError struct {
+	Until time.Time
+}
+
+func (e *LockoutError) Error() string {
+	return ErrTooManyAttempts.Error()
+}
+
+func (e *LockoutError) Is(target error) bool {
+	return target == ErrTooManyAttempts
+}
+
+// LockoutPolicy задаёт, после скольких неудачных попыток подряд вход блокируется.
+// Каждая следующая неудача удваивает блокировку, начиная с BaseDelay, но не дольше MaxDelay.
+// Счётчик сбрасывается после успешного входа или если неудач не было дольше Window.
+type LockoutPolicy struct {
+	Threshold   int
+	IPThreshold int
+	Window      time.Duration
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// LoginThrottle считает неудачные попытки входа отдельно по логину и по IP-адресу.
+type LoginThrottle struct {
+	repo   repository.LoginAttempt
+	audit  repository.Audit
+	policy LockoutPolicy
+	now    func() time.Time
+}
+
+func NewLoginThrottle(repo repository.LoginAttempt, audit repository.Audit, policy LockoutPolicy) *LoginThrottle {
+	return &LoginThrottle{repo: repo, audit: audit, policy: policy, now: time.Now}
+}
+
+// Check возвращает *LockoutError, если вход заблокирован по логину или по IP-адресу.
+func (t *LoginThrottle) Check(username, ip string) error {
+	return t.check(t.keys(usernameKey(username), ip))
+}
+
+// Fail учитывает неудачную попытку и при превышении порога блокирует вход.
+func (t *LoginThrottle) Fail(username, ip string) error {
+	return t.fail(t.keys(usernameKey(username), ip), ip)
+}
+
+// Succeed сбрасывает счётчик логина. Счётчик IP-адреса не сбрасывается, иначе перебор
+// чужих паролей можно было бы чередовать со входом в собственный аккаунт.
+func (t *LoginThrottle) Succeed(username string) error {
+	return t.repo.Reset(usernameKey(username))
+}
+
+// CheckTwoFactor возвращает *LockoutError, если подбор кода второго фактора пользователя заблокирован.
+// Счётчик второго фактора отдельный: успешный ввод пароля его не сбрасывает.
+func (t *LoginThrottle) CheckTwoFactor(userId int, ip string) error {
+	return t.check(t.keys(twoFactorKey(userId), ip))
+}
+
+func (t *LoginThrottle) FailTwoFactor(userId int, ip string) error {
+	return t.fail(t.keys(twoFactorKey(userId), ip), ip)
+}
+
+func (t *LoginThrottle) SucceedTwoFactor(userId int) error {
+	return t.repo.Reset(twoFactorKey(userId))
+}
+
+// CheckPublicLink возвращает *LockoutError, если подбор пароля публичной ссылки заблокирован.
+func (t *LoginThrottle) CheckPublicLink(linkId int, ip string) error {
+	return t.check(t.keys(publicLinkKey(linkId), ip))
+}
+
+func (t *LoginThrottle) FailPublicLink(linkId int, ip string) error {
+	return t.fail(t.keys(publicLinkKey(linkId), ip), ip)
+}
+
+func (t *LoginThrottle) SucceedPublicLink(linkId int) error {
+	return t.repo.Reset(publicLinkKey(linkId))
+}
+
+func (t *LoginThrottle) check(keys []string) error {
+	now := t.now()
+
+	for _, key := range keys {
+		attempt, err := t.repo.Get(key)
+		if err != nil {
+			return err
+		}
+		if attempt.Locked(now) {
+			return &LockoutError{Until: *attempt.LockedUntil}
+		}
+	}
+
+	return nil
+}
+
+func (t *LoginThrottle) fail(keys []string, ip string) error {
+	now := t.now()
+
+	for _, key := range keys {
+		attempt, err := t.repo.RegisterFailure(key, now, now.Add(-t.policy.Window))
+		if err != nil {
+			return err
+		}
+
+		threshold := t.policy.Threshold
+		if strings.HasPrefix(key, "ip:") {
+			threshold = t.policy.IPThreshold
+		}
+		if attempt.Failures < threshold {
+			continue
+		}
+
+		until := now.Add(t.delay(attempt.Failures - threshold))
+		if err := t.repo.Lock(key, until); err != nil {
+			return err
+		}
+
+		logrus.Warnf("Вход по ключу %s заблокирован до %s после %d неудачных попыток", key, until.Format(time.RFC3339), attempt.Failures)
+		if err := t.audit.Create(entity.AuditEntry{
+			Event:   entity.AuditLoginLockout,
+			Subject: key,
+			IP:      ip,
+			Details: fmt.Sprintf("failures=%d locked_until=%s", attempt.Failures, until.Format(time.RFC3339)),
+		}); err != nil {
+			logrus.Errorf("Ошибка при записи в журнал аудита: %s", err.Error())
+		}
+	}
+
+	return nil
+}
+
+func (t *LoginThrottle) keys(key, ip string) []string {
+	keys := []string{key}
+	if ip != "" {
+		keys = append(keys, "ip:"+ip)
+	}
+	return keys
+}
+
+func (t *LoginThrottle) delay(excess int) time.Duration {
+	delay := t.policy.BaseDelay
+	for i := 0; i < excess && delay < t.policy.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > t.policy.MaxDelay {
+		delay = t.policy.MaxDelay
+	}
+	return delay
+}
+
+func usernameKey(username string) string {
+	return "user:" + strings.ToLower(strings.TrimSpace(username))
+}
+
+func twoFactorKey(userId int) string {
+	return fmt.Sprintf("2fa:%d", userId)
+}
+
+func publicLinkKey(linkId int) string {
+	return fmt.Sprintf("link:%d", linkId)
+}
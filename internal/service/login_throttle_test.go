@@ -0,0 +1,103 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository/memory"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+var testLockoutPolicy = LockoutPolicy{
+	Threshold:   3,
+	IPThreshold: 5,
+	Window:      10 * time.Minute,
+	BaseDelay:   time.Minute,
+	MaxDelay:    10 * time.Minute,
+}
+
+func TestLoginThrottle_Lockout(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	audit := mock_repository.NewMockAudit(c)
+	throttle := NewLoginThrottle(memory.NewLoginAttempt(), audit, testLockoutPolicy)
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, throttle.Fail("Test", "10.0.0.1"))
+	}
+	assert.NoError(t, throttle.Check("test", "10.0.0.1"))
+
+	audit.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry entity.AuditEntry) error {
+		assert.Equal(t, entity.AuditLoginLockout, entry.Event)
+		assert.Equal(t, "user:test", entry.Subject)
+		assert.Equal(t, "10.0.0.1", entry.IP)
+		return nil
+	})
+	assert.NoError(t, throttle.Fail("test", "10.0.0.1"))
+
+	err := throttle.Check("test", "10.0.0.2")
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+	assert.Equal(t, now.Add(time.Minute), err.(*LockoutError).Until)
+
+	// Каждая следующая неудача удваивает блокировку
+	audit.EXPECT().Create(gomock.Any()).Return(nil)
+	assert.NoError(t, throttle.Fail("test", "10.0.0.2"))
+	assert.Equal(t, now.Add(2*time.Minute), throttle.Check("test", "10.0.0.3").(*LockoutError).Until)
+
+	now = now.Add(3 * time.Minute)
+	assert.NoError(t, throttle.Check("test", "10.0.0.3"))
+
+	assert.NoError(t, throttle.Succeed("test"))
+	assert.NoError(t, throttle.Fail("test", "10.0.0.3"))
+	assert.NoError(t, throttle.Check("test", "10.0.0.3"))
+}
+
+func TestLoginThrottle_IP(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	audit := mock_repository.NewMockAudit(c)
+	throttle := NewLoginThrottle(memory.NewLoginAttempt(), audit, testLockoutPolicy)
+	throttle.now = func() time.Time { return now }
+
+	// Перебор разных логинов с одного адреса блокируется по IP
+	for i, username := range []string{"a", "b", "c", "d"} {
+		assert.NoError(t, throttle.Fail(username, "10.0.0.1"), i)
+	}
+	audit.EXPECT().Create(gomock.Any()).Return(nil)
+	assert.NoError(t, throttle.Fail("e", "10.0.0.1"))
+
+	assert.ErrorIs(t, throttle.Check("f", "10.0.0.1"), ErrTooManyAttempts)
+	assert.NoError(t, throttle.Check("f", "10.0.0.2"))
+}
+
+func TestLoginThrottle_Window(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	throttle := NewLoginThrottle(memory.NewLoginAttempt(), nil, testLockoutPolicy)
+	throttle.now = func() time.Time { return now }
+
+	for i := 0; i < 2; i++ {
+		assert.NoError(t, throttle.Fail("test", ""))
+	}
+
+	// Старые неудачи забываются, поэтому третья попытка после паузы не блокирует вход
+	now = now.Add(testLockoutPolicy.Window + time.Second)
+	assert.NoError(t, throttle.Fail("test", ""))
+	assert.NoError(t, throttle.Check("test", ""))
+}
+
+func TestLoginThrottle_delay(t *testing.T) {
+	throttle := NewLoginThrottle(nil, nil, testLockoutPolicy)
+
+	assert.Equal(t, time.Minute, throttle.delay(0))
+	assert.Equal(t, 8*time.Minute, throttle.delay(3))
+	assert.Equal(t, 10*time.Minute, throttle.delay(4))
+	assert.Equal(t, 10*time.Minute, throttle.delay(1000))
+}
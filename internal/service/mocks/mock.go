@@ -5,7 +5,9 @@
 package mock_service
 
 import (
+	io "io"
 	reflect "reflect"
+	time "time"
 
 	entity "github.com/IncubusX/go-todo-app/internal/entity"
 	gomock "github.com/golang/mock/gomock"
@@ -34,6 +36,21 @@ func (m *MockAuthorization) EXPECT() *MockAuthorizationMockRecorder {
 	return m.recorder
 }
 
+// ChangePassword mocks base method.
+func (m *MockAuthorization) ChangePassword(userId int, input entity.ChangePasswordInput, client entity.Client) (entity.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", userId, input, client)
+	ret0, _ := ret[0].(entity.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ChangePassword indicates an expected call of ChangePassword.
+func (mr *MockAuthorizationMockRecorder) ChangePassword(userId, input, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthorization)(nil).ChangePassword), userId, input, client)
+}
+
 // CreateUser mocks base method.
 func (m *MockAuthorization) CreateUser(user entity.User) (int, error) {
 	m.ctrl.T.Helper()
@@ -43,230 +60,1616 @@ func (m *MockAuthorization) CreateUser(user entity.User) (int, error) {
 	return ret0, ret1
 }
 
-// CreateUser indicates an expected call of CreateUser.
-func (mr *MockAuthorizationMockRecorder) CreateUser(user interface{}) *gomock.Call {
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockAuthorizationMockRecorder) CreateUser(user interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
+}
+
+// DeleteUser mocks base method.
+func (m *MockAuthorization) DeleteUser(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteUser", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteUser indicates an expected call of DeleteUser.
+func (mr *MockAuthorizationMockRecorder) DeleteUser(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteUser", reflect.TypeOf((*MockAuthorization)(nil).DeleteUser), userId)
+}
+
+// GenerateToken mocks base method.
+func (m *MockAuthorization) GenerateToken(username, password string, client entity.Client) (entity.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GenerateToken", username, password, client)
+	ret0, _ := ret[0].(entity.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GenerateToken indicates an expected call of GenerateToken.
+func (mr *MockAuthorizationMockRecorder) GenerateToken(username, password, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), username, password, client)
+}
+
+// GetProfile mocks base method.
+func (m *MockAuthorization) GetProfile(userId int) (entity.Profile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfile", userId)
+	ret0, _ := ret[0].(entity.Profile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetProfile indicates an expected call of GetProfile.
+func (mr *MockAuthorizationMockRecorder) GetProfile(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfile", reflect.TypeOf((*MockAuthorization)(nil).GetProfile), userId)
+}
+
+// Logout mocks base method.
+func (m *MockAuthorization) Logout(refreshToken string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Logout", refreshToken)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Logout indicates an expected call of Logout.
+func (mr *MockAuthorizationMockRecorder) Logout(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthorization)(nil).Logout), refreshToken)
+}
+
+// ParseToken mocks base method.
+func (m *MockAuthorization) ParseToken(token string) (entity.Identity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ParseToken", token)
+	ret0, _ := ret[0].(entity.Identity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ParseToken indicates an expected call of ParseToken.
+func (mr *MockAuthorizationMockRecorder) ParseToken(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), token)
+}
+
+// PublicKeys mocks base method.
+func (m *MockAuthorization) PublicKeys() entity.JSONWebKeySet {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublicKeys")
+	ret0, _ := ret[0].(entity.JSONWebKeySet)
+	return ret0
+}
+
+// PublicKeys indicates an expected call of PublicKeys.
+func (mr *MockAuthorizationMockRecorder) PublicKeys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublicKeys", reflect.TypeOf((*MockAuthorization)(nil).PublicKeys))
+}
+
+// RefreshTokens mocks base method.
+func (m *MockAuthorization) RefreshTokens(refreshToken string) (entity.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshTokens", refreshToken)
+	ret0, _ := ret[0].(entity.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RefreshTokens indicates an expected call of RefreshTokens.
+func (mr *MockAuthorizationMockRecorder) RefreshTokens(refreshToken interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshTokens", reflect.TypeOf((*MockAuthorization)(nil).RefreshTokens), refreshToken)
+}
+
+// SignInTwoFactor mocks base method.
+func (m *MockAuthorization) SignInTwoFactor(challengeToken, code string, client entity.Client) (entity.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SignInTwoFactor", challengeToken, code, client)
+	ret0, _ := ret[0].(entity.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SignInTwoFactor indicates an expected call of SignInTwoFactor.
+func (mr *MockAuthorizationMockRecorder) SignInTwoFactor(challengeToken, code, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SignInTwoFactor", reflect.TypeOf((*MockAuthorization)(nil).SignInTwoFactor), challengeToken, code, client)
+}
+
+// UpdateProfile mocks base method.
+func (m *MockAuthorization) UpdateProfile(userId int, input entity.UpdateUserInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateProfile", userId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateProfile indicates an expected call of UpdateProfile.
+func (mr *MockAuthorizationMockRecorder) UpdateProfile(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateProfile", reflect.TypeOf((*MockAuthorization)(nil).UpdateProfile), userId, input)
+}
+
+// MockAccount is a mock of Account interface.
+type MockAccount struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountMockRecorder
+}
+
+// MockAccountMockRecorder is the mock recorder for MockAccount.
+type MockAccountMockRecorder struct {
+	mock *MockAccount
+}
+
+// NewMockAccount creates a new mock instance.
+func NewMockAccount(ctrl *gomock.Controller) *MockAccount {
+	mock := &MockAccount{ctrl: ctrl}
+	mock.recorder = &MockAccountMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccount) EXPECT() *MockAccountMockRecorder {
+	return m.recorder
+}
+
+// ForgotPassword mocks base method.
+func (m *MockAccount) ForgotPassword(email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAccountMockRecorder) ForgotPassword(email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAccount)(nil).ForgotPassword), email)
+}
+
+// ResetPassword mocks base method.
+func (m *MockAccount) ResetPassword(input entity.ResetPasswordInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAccountMockRecorder) ResetPassword(input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAccount)(nil).ResetPassword), input)
+}
+
+// SendVerification mocks base method.
+func (m *MockAccount) SendVerification(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SendVerification", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SendVerification indicates an expected call of SendVerification.
+func (mr *MockAccountMockRecorder) SendVerification(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SendVerification", reflect.TypeOf((*MockAccount)(nil).SendVerification), userId)
+}
+
+// VerifyEmail mocks base method.
+func (m *MockAccount) VerifyEmail(token string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "VerifyEmail", token)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// VerifyEmail indicates an expected call of VerifyEmail.
+func (mr *MockAccountMockRecorder) VerifyEmail(token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "VerifyEmail", reflect.TypeOf((*MockAccount)(nil).VerifyEmail), token)
+}
+
+// MockOIDC is a mock of OIDC interface.
+type MockOIDC struct {
+	ctrl     *gomock.Controller
+	recorder *MockOIDCMockRecorder
+}
+
+// MockOIDCMockRecorder is the mock recorder for MockOIDC.
+type MockOIDCMockRecorder struct {
+	mock *MockOIDC
+}
+
+// NewMockOIDC creates a new mock instance.
+func NewMockOIDC(ctrl *gomock.Controller) *MockOIDC {
+	mock := &MockOIDC{ctrl: ctrl}
+	mock.recorder = &MockOIDCMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOIDC) EXPECT() *MockOIDCMockRecorder {
+	return m.recorder
+}
+
+// Callback mocks base method.
+func (m *MockOIDC) Callback(provider string, input entity.OIDCCallbackInput, client entity.Client) (entity.Tokens, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Callback", provider, input, client)
+	ret0, _ := ret[0].(entity.Tokens)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Callback indicates an expected call of Callback.
+func (mr *MockOIDCMockRecorder) Callback(provider, input, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Callback", reflect.TypeOf((*MockOIDC)(nil).Callback), provider, input, client)
+}
+
+// LoginURL mocks base method.
+func (m *MockOIDC) LoginURL(provider string) (entity.OIDCLogin, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LoginURL", provider)
+	ret0, _ := ret[0].(entity.OIDCLogin)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// LoginURL indicates an expected call of LoginURL.
+func (mr *MockOIDCMockRecorder) LoginURL(provider interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LoginURL", reflect.TypeOf((*MockOIDC)(nil).LoginURL), provider)
+}
+
+// MockSession is a mock of Session interface.
+type MockSession struct {
+	ctrl     *gomock.Controller
+	recorder *MockSessionMockRecorder
+}
+
+// MockSessionMockRecorder is the mock recorder for MockSession.
+type MockSessionMockRecorder struct {
+	mock *MockSession
+}
+
+// NewMockSession creates a new mock instance.
+func NewMockSession(ctrl *gomock.Controller) *MockSession {
+	mock := &MockSession{ctrl: ctrl}
+	mock.recorder = &MockSessionMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSession) EXPECT() *MockSessionMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockSession) GetAll(userId, currentSessionId int) ([]entity.Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, currentSessionId)
+	ret0, _ := ret[0].([]entity.Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSessionMockRecorder) GetAll(userId, currentSessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSession)(nil).GetAll), userId, currentSessionId)
+}
+
+// Revoke mocks base method.
+func (m *MockSession) Revoke(userId, sessionId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userId, sessionId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockSessionMockRecorder) Revoke(userId, sessionId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockSession)(nil).Revoke), userId, sessionId)
+}
+
+// MockTwoFactor is a mock of TwoFactor interface.
+type MockTwoFactor struct {
+	ctrl     *gomock.Controller
+	recorder *MockTwoFactorMockRecorder
+}
+
+// MockTwoFactorMockRecorder is the mock recorder for MockTwoFactor.
+type MockTwoFactorMockRecorder struct {
+	mock *MockTwoFactor
+}
+
+// NewMockTwoFactor creates a new mock instance.
+func NewMockTwoFactor(ctrl *gomock.Controller) *MockTwoFactor {
+	mock := &MockTwoFactor{ctrl: ctrl}
+	mock.recorder = &MockTwoFactorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTwoFactor) EXPECT() *MockTwoFactorMockRecorder {
+	return m.recorder
+}
+
+// Confirm mocks base method.
+func (m *MockTwoFactor) Confirm(userId int, code string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Confirm", userId, code)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Confirm indicates an expected call of Confirm.
+func (mr *MockTwoFactorMockRecorder) Confirm(userId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Confirm", reflect.TypeOf((*MockTwoFactor)(nil).Confirm), userId, code)
+}
+
+// Disable mocks base method.
+func (m *MockTwoFactor) Disable(userId int, code string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Disable", userId, code)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Disable indicates an expected call of Disable.
+func (mr *MockTwoFactorMockRecorder) Disable(userId, code interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Disable", reflect.TypeOf((*MockTwoFactor)(nil).Disable), userId, code)
+}
+
+// Enroll mocks base method.
+func (m *MockTwoFactor) Enroll(userId int) (entity.TwoFactorEnrollment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Enroll", userId)
+	ret0, _ := ret[0].(entity.TwoFactorEnrollment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Enroll indicates an expected call of Enroll.
+func (mr *MockTwoFactorMockRecorder) Enroll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Enroll", reflect.TypeOf((*MockTwoFactor)(nil).Enroll), userId)
+}
+
+// MockPersonalAccessToken is a mock of PersonalAccessToken interface.
+type MockPersonalAccessToken struct {
+	ctrl     *gomock.Controller
+	recorder *MockPersonalAccessTokenMockRecorder
+}
+
+// MockPersonalAccessTokenMockRecorder is the mock recorder for MockPersonalAccessToken.
+type MockPersonalAccessTokenMockRecorder struct {
+	mock *MockPersonalAccessToken
+}
+
+// NewMockPersonalAccessToken creates a new mock instance.
+func NewMockPersonalAccessToken(ctrl *gomock.Controller) *MockPersonalAccessToken {
+	mock := &MockPersonalAccessToken{ctrl: ctrl}
+	mock.recorder = &MockPersonalAccessTokenMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPersonalAccessToken) EXPECT() *MockPersonalAccessTokenMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPersonalAccessToken) Create(userId int, input entity.CreatePersonalAccessTokenInput) (entity.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret0, _ := ret[0].(entity.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPersonalAccessTokenMockRecorder) Create(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPersonalAccessToken)(nil).Create), userId, input)
+}
+
+// GetAll mocks base method.
+func (m *MockPersonalAccessToken) GetAll(userId int) ([]entity.PersonalAccessToken, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.PersonalAccessToken)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockPersonalAccessTokenMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockPersonalAccessToken)(nil).GetAll), userId)
+}
+
+// Revoke mocks base method.
+func (m *MockPersonalAccessToken) Revoke(userId, tokenId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Revoke", userId, tokenId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Revoke indicates an expected call of Revoke.
+func (mr *MockPersonalAccessTokenMockRecorder) Revoke(userId, tokenId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Revoke", reflect.TypeOf((*MockPersonalAccessToken)(nil).Revoke), userId, tokenId)
+}
+
+// MockTodoList is a mock of TodoList interface.
+type MockTodoList struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoListMockRecorder
+}
+
+// MockTodoListMockRecorder is the mock recorder for MockTodoList.
+type MockTodoListMockRecorder struct {
+	mock *MockTodoList
+}
+
+// NewMockTodoList creates a new mock instance.
+func NewMockTodoList(ctrl *gomock.Controller) *MockTodoList {
+	mock := &MockTodoList{ctrl: ctrl}
+	mock.recorder = &MockTodoListMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockTodoList) Create(userId int, input entity.TodoList) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTodoListMockRecorder) Create(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoList)(nil).Create), userId, input)
+}
+
+// Delete mocks base method.
+func (m *MockTodoList) Delete(userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTodoListMockRecorder) Delete(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, listId)
+}
+
+// Duplicate mocks base method.
+func (m *MockTodoList) Duplicate(userId, listId int, input entity.DuplicateListInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Duplicate", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Duplicate indicates an expected call of Duplicate.
+func (mr *MockTodoListMockRecorder) Duplicate(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Duplicate", reflect.TypeOf((*MockTodoList)(nil).Duplicate), userId, listId, input)
+}
+
+// GetAll mocks base method.
+func (m *MockTodoList) GetAll(userId int) ([]entity.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockTodoList) GetById(userId, listId int) (entity.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, listId)
+	ret0, _ := ret[0].(entity.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTodoListMockRecorder) GetById(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), userId, listId)
+}
+
+// Move mocks base method.
+func (m *MockTodoList) Move(userId, listId int, move entity.MoveInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, listId, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoListMockRecorder) Move(userId, listId, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoList)(nil).Move), userId, listId, move)
+}
+
+// Update mocks base method.
+func (m *MockTodoList) Update(userId, listId int, input entity.UpdateListInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", userId, listId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockTodoListMockRecorder) Update(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, listId, input)
+}
+
+// MockListTemplate is a mock of ListTemplate interface.
+type MockListTemplate struct {
+	ctrl     *gomock.Controller
+	recorder *MockListTemplateMockRecorder
+}
+
+// MockListTemplateMockRecorder is the mock recorder for MockListTemplate.
+type MockListTemplateMockRecorder struct {
+	mock *MockListTemplate
+}
+
+// NewMockListTemplate creates a new mock instance.
+func NewMockListTemplate(ctrl *gomock.Controller) *MockListTemplate {
+	mock := &MockListTemplate{ctrl: ctrl}
+	mock.recorder = &MockListTemplateMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListTemplate) EXPECT() *MockListTemplateMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockListTemplate) Delete(userId, templateId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, templateId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockListTemplateMockRecorder) Delete(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockListTemplate)(nil).Delete), userId, templateId)
+}
+
+// GetAll mocks base method.
+func (m *MockListTemplate) GetAll(userId int) ([]entity.ListTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.ListTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListTemplateMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListTemplate)(nil).GetAll), userId)
+}
+
+// GetById mocks base method.
+func (m *MockListTemplate) GetById(userId, templateId int) (entity.ListTemplate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, templateId)
+	ret0, _ := ret[0].(entity.ListTemplate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockListTemplateMockRecorder) GetById(userId, templateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockListTemplate)(nil).GetById), userId, templateId)
+}
+
+// Instantiate mocks base method.
+func (m *MockListTemplate) Instantiate(userId, templateId int, input entity.InstantiateTemplateInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Instantiate", userId, templateId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Instantiate indicates an expected call of Instantiate.
+func (mr *MockListTemplateMockRecorder) Instantiate(userId, templateId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Instantiate", reflect.TypeOf((*MockListTemplate)(nil).Instantiate), userId, templateId, input)
+}
+
+// Save mocks base method.
+func (m *MockListTemplate) Save(userId, listId int, input entity.SaveTemplateInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockListTemplateMockRecorder) Save(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockListTemplate)(nil).Save), userId, listId, input)
+}
+
+// MockListMember is a mock of ListMember interface.
+type MockListMember struct {
+	ctrl     *gomock.Controller
+	recorder *MockListMemberMockRecorder
+}
+
+// MockListMemberMockRecorder is the mock recorder for MockListMember.
+type MockListMemberMockRecorder struct {
+	mock *MockListMember
+}
+
+// NewMockListMember creates a new mock instance.
+func NewMockListMember(ctrl *gomock.Controller) *MockListMember {
+	mock := &MockListMember{ctrl: ctrl}
+	mock.recorder = &MockListMemberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListMember) EXPECT() *MockListMemberMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockListMember) GetAll(userId, listId int) ([]entity.ListMember, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, listId)
+	ret0, _ := ret[0].([]entity.ListMember)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockListMemberMockRecorder) GetAll(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockListMember)(nil).GetAll), userId, listId)
+}
+
+// Remove mocks base method.
+func (m *MockListMember) Remove(userId, listId, memberId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", userId, listId, memberId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockListMemberMockRecorder) Remove(userId, listId, memberId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockListMember)(nil).Remove), userId, listId, memberId)
+}
+
+// UpdateRole mocks base method.
+func (m *MockListMember) UpdateRole(userId, listId, memberId int, input entity.UpdateMemberInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateRole", userId, listId, memberId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateRole indicates an expected call of UpdateRole.
+func (mr *MockListMemberMockRecorder) UpdateRole(userId, listId, memberId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateRole", reflect.TypeOf((*MockListMember)(nil).UpdateRole), userId, listId, memberId, input)
+}
+
+// MockInvitation is a mock of Invitation interface.
+type MockInvitation struct {
+	ctrl     *gomock.Controller
+	recorder *MockInvitationMockRecorder
+}
+
+// MockInvitationMockRecorder is the mock recorder for MockInvitation.
+type MockInvitationMockRecorder struct {
+	mock *MockInvitation
+}
+
+// NewMockInvitation creates a new mock instance.
+func NewMockInvitation(ctrl *gomock.Controller) *MockInvitation {
+	mock := &MockInvitation{ctrl: ctrl}
+	mock.recorder = &MockInvitationMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockInvitation) EXPECT() *MockInvitationMockRecorder {
+	return m.recorder
+}
+
+// Accept mocks base method.
+func (m *MockInvitation) Accept(userId, invitationId int) (entity.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Accept", userId, invitationId)
+	ret0, _ := ret[0].(entity.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Accept indicates an expected call of Accept.
+func (mr *MockInvitationMockRecorder) Accept(userId, invitationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Accept", reflect.TypeOf((*MockInvitation)(nil).Accept), userId, invitationId)
+}
+
+// AcceptLink mocks base method.
+func (m *MockInvitation) AcceptLink(userId int, token string) (entity.TodoList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AcceptLink", userId, token)
+	ret0, _ := ret[0].(entity.TodoList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AcceptLink indicates an expected call of AcceptLink.
+func (mr *MockInvitationMockRecorder) AcceptLink(userId, token interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AcceptLink", reflect.TypeOf((*MockInvitation)(nil).AcceptLink), userId, token)
+}
+
+// CreateLink mocks base method.
+func (m *MockInvitation) CreateLink(userId, listId int, input entity.CreateInviteLinkInput) (entity.InviteLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLink", userId, listId, input)
+	ret0, _ := ret[0].(entity.InviteLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLink indicates an expected call of CreateLink.
+func (mr *MockInvitationMockRecorder) CreateLink(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLink", reflect.TypeOf((*MockInvitation)(nil).CreateLink), userId, listId, input)
+}
+
+// Decline mocks base method.
+func (m *MockInvitation) Decline(userId, invitationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Decline", userId, invitationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Decline indicates an expected call of Decline.
+func (mr *MockInvitationMockRecorder) Decline(userId, invitationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Decline", reflect.TypeOf((*MockInvitation)(nil).Decline), userId, invitationId)
+}
+
+// GetAll mocks base method.
+func (m *MockInvitation) GetAll(userId int) ([]entity.ListInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId)
+	ret0, _ := ret[0].([]entity.ListInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockInvitationMockRecorder) GetAll(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockInvitation)(nil).GetAll), userId)
+}
+
+// Invite mocks base method.
+func (m *MockInvitation) Invite(userId, listId int, input entity.InviteMemberInput) (entity.ListInvitation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Invite", userId, listId, input)
+	ret0, _ := ret[0].(entity.ListInvitation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Invite indicates an expected call of Invite.
+func (mr *MockInvitationMockRecorder) Invite(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Invite", reflect.TypeOf((*MockInvitation)(nil).Invite), userId, listId, input)
+}
+
+// RevokeLink mocks base method.
+func (m *MockInvitation) RevokeLink(userId, listId, linkId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RevokeLink", userId, listId, linkId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RevokeLink indicates an expected call of RevokeLink.
+func (mr *MockInvitationMockRecorder) RevokeLink(userId, listId, linkId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RevokeLink", reflect.TypeOf((*MockInvitation)(nil).RevokeLink), userId, listId, linkId)
+}
+
+// MockPublicLink is a mock of PublicLink interface.
+type MockPublicLink struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublicLinkMockRecorder
+}
+
+// MockPublicLinkMockRecorder is the mock recorder for MockPublicLink.
+type MockPublicLinkMockRecorder struct {
+	mock *MockPublicLink
+}
+
+// NewMockPublicLink creates a new mock instance.
+func NewMockPublicLink(ctrl *gomock.Controller) *MockPublicLink {
+	mock := &MockPublicLink{ctrl: ctrl}
+	mock.recorder = &MockPublicLinkMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublicLink) EXPECT() *MockPublicLinkMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockPublicLink) Get(token, password, ip string) (entity.PublicList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", token, password, ip)
+	ret0, _ := ret[0].(entity.PublicList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockPublicLinkMockRecorder) Get(token, password, ip interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockPublicLink)(nil).Get), token, password, ip)
+}
+
+// Publish mocks base method.
+func (m *MockPublicLink) Publish(userId, listId int, input entity.PublishListInput) (entity.PublicLink, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Publish", userId, listId, input)
+	ret0, _ := ret[0].(entity.PublicLink)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockPublicLinkMockRecorder) Publish(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockPublicLink)(nil).Publish), userId, listId, input)
+}
+
+// Unpublish mocks base method.
+func (m *MockPublicLink) Unpublish(userId, listId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Unpublish", userId, listId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unpublish indicates an expected call of Unpublish.
+func (mr *MockPublicLinkMockRecorder) Unpublish(userId, listId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unpublish", reflect.TypeOf((*MockPublicLink)(nil).Unpublish), userId, listId)
+}
+
+// MockTodoItem is a mock of TodoItem interface.
+type MockTodoItem struct {
+	ctrl     *gomock.Controller
+	recorder *MockTodoItemMockRecorder
+}
+
+// MockTodoItemMockRecorder is the mock recorder for MockTodoItem.
+type MockTodoItemMockRecorder struct {
+	mock *MockTodoItem
+}
+
+// NewMockTodoItem creates a new mock instance.
+func NewMockTodoItem(ctrl *gomock.Controller) *MockTodoItem {
+	mock := &MockTodoItem{ctrl: ctrl}
+	mock.recorder = &MockTodoItemMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
+	return m.recorder
+}
+
+// Copy mocks base method.
+func (m *MockTodoItem) Copy(userId, itemId int, input entity.CopyItemInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Copy", userId, itemId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Copy indicates an expected call of Copy.
+func (mr *MockTodoItemMockRecorder) Copy(userId, itemId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Copy", reflect.TypeOf((*MockTodoItem)(nil).Copy), userId, itemId, input)
+}
+
+// Create mocks base method.
+func (m *MockTodoItem) Create(userId, listId int, input entity.TodoItem) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, listId, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockTodoItemMockRecorder) Create(userId, listId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), userId, listId, input)
+}
+
+// Delete mocks base method.
+func (m *MockTodoItem) Delete(userId, itemId int, keepChildren bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, keepChildren)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTodoItemMockRecorder) Delete(userId, itemId, keepChildren interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId, keepChildren)
+}
+
+// GetAll mocks base method.
+func (m *MockTodoItem) GetAll(userId, listId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, listId, filter)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockTodoItemMockRecorder) GetAll(userId, listId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, listId, filter)
+}
+
+// GetAssigned mocks base method.
+func (m *MockTodoItem) GetAssigned(userId int) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAssigned", userId)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAssigned indicates an expected call of GetAssigned.
+func (mr *MockTodoItemMockRecorder) GetAssigned(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAssigned", reflect.TypeOf((*MockTodoItem)(nil).GetAssigned), userId)
+}
+
+// GetById mocks base method.
+func (m *MockTodoItem) GetById(userId, itemId int) (entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetById", userId, itemId)
+	ret0, _ := ret[0].(entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetById indicates an expected call of GetById.
+func (mr *MockTodoItemMockRecorder) GetById(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), userId, itemId)
+}
+
+// GetDue mocks base method.
+func (m *MockTodoItem) GetDue(userId int, period string) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDue", userId, period)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDue indicates an expected call of GetDue.
+func (mr *MockTodoItemMockRecorder) GetDue(userId, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDue", reflect.TypeOf((*MockTodoItem)(nil).GetDue), userId, period)
+}
+
+// Move mocks base method.
+func (m *MockTodoItem) Move(userId, itemId int, move entity.MoveItemInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Move", userId, itemId, move)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Move indicates an expected call of Move.
+func (mr *MockTodoItemMockRecorder) Move(userId, itemId, move interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Move", reflect.TypeOf((*MockTodoItem)(nil).Move), userId, itemId, move)
+}
+
+// Occurrences mocks base method.
+func (m *MockTodoItem) Occurrences(userId, itemId, count int) ([]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Occurrences", userId, itemId, count)
+	ret0, _ := ret[0].([]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Occurrences indicates an expected call of Occurrences.
+func (mr *MockTodoItemMockRecorder) Occurrences(userId, itemId, count interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Occurrences", reflect.TypeOf((*MockTodoItem)(nil).Occurrences), userId, itemId, count)
+}
+
+// Search mocks base method.
+func (m *MockTodoItem) Search(userId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", userId, filter)
+	ret0, _ := ret[0].([]entity.TodoItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockTodoItemMockRecorder) Search(userId, filter interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockAuthorization)(nil).CreateUser), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockTodoItem)(nil).Search), userId, filter)
 }
 
-// GenerateToken mocks base method.
-func (m *MockAuthorization) GenerateToken(username, password string) (string, error) {
+// Skip mocks base method.
+func (m *MockTodoItem) Skip(userId, itemId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateToken", username, password)
-	ret0, _ := ret[0].(string)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Skip", userId, itemId)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// GenerateToken indicates an expected call of GenerateToken.
-func (mr *MockAuthorizationMockRecorder) GenerateToken(username, password interface{}) *gomock.Call {
+// Skip indicates an expected call of Skip.
+func (mr *MockTodoItemMockRecorder) Skip(userId, itemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateToken", reflect.TypeOf((*MockAuthorization)(nil).GenerateToken), username, password)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Skip", reflect.TypeOf((*MockTodoItem)(nil).Skip), userId, itemId)
 }
 
-// ParseToken mocks base method.
-func (m *MockAuthorization) ParseToken(token string) (int, error) {
+// Update mocks base method.
+func (m *MockTodoItem) Update(userId, itemId int, input entity.UpdateItemInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "ParseToken", token)
-	ret0, _ := ret[0].(int)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret := m.ctrl.Call(m, "Update", userId, itemId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
-// ParseToken indicates an expected call of ParseToken.
-func (mr *MockAuthorizationMockRecorder) ParseToken(token interface{}) *gomock.Call {
+// Update indicates an expected call of Update.
+func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseToken", reflect.TypeOf((*MockAuthorization)(nil).ParseToken), token)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input)
 }
 
-// MockTodoList is a mock of TodoList interface.
-type MockTodoList struct {
+// MockLabel is a mock of Label interface.
+type MockLabel struct {
 	ctrl     *gomock.Controller
-	recorder *MockTodoListMockRecorder
+	recorder *MockLabelMockRecorder
 }
 
-// MockTodoListMockRecorder is the mock recorder for MockTodoList.
-type MockTodoListMockRecorder struct {
-	mock *MockTodoList
+// MockLabelMockRecorder is the mock recorder for MockLabel.
+type MockLabelMockRecorder struct {
+	mock *MockLabel
 }
 
-// NewMockTodoList creates a new mock instance.
-func NewMockTodoList(ctrl *gomock.Controller) *MockTodoList {
-	mock := &MockTodoList{ctrl: ctrl}
-	mock.recorder = &MockTodoListMockRecorder{mock}
+// NewMockLabel creates a new mock instance.
+func NewMockLabel(ctrl *gomock.Controller) *MockLabel {
+	mock := &MockLabel{ctrl: ctrl}
+	mock.recorder = &MockLabelMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTodoList) EXPECT() *MockTodoListMockRecorder {
+func (m *MockLabel) EXPECT() *MockLabelMockRecorder {
 	return m.recorder
 }
 
+// Attach mocks base method.
+func (m *MockLabel) Attach(userId, itemId, labelId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Attach", userId, itemId, labelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Attach indicates an expected call of Attach.
+func (mr *MockLabelMockRecorder) Attach(userId, itemId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Attach", reflect.TypeOf((*MockLabel)(nil).Attach), userId, itemId, labelId)
+}
+
 // Create mocks base method.
-func (m *MockTodoList) Create(userId int, input entity.TodoList) (int, error) {
+func (m *MockLabel) Create(userId int, label entity.Label) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", userId, input)
+	ret := m.ctrl.Call(m, "Create", userId, label)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoListMockRecorder) Create(userId, input interface{}) *gomock.Call {
+func (mr *MockLabelMockRecorder) Create(userId, label interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoList)(nil).Create), userId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockLabel)(nil).Create), userId, label)
 }
 
 // Delete mocks base method.
-func (m *MockTodoList) Delete(userId, listId int) error {
+func (m *MockLabel) Delete(userId, labelId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, listId)
+	ret := m.ctrl.Call(m, "Delete", userId, labelId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoListMockRecorder) Delete(userId, listId interface{}) *gomock.Call {
+func (mr *MockLabelMockRecorder) Delete(userId, labelId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoList)(nil).Delete), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockLabel)(nil).Delete), userId, labelId)
+}
+
+// Detach mocks base method.
+func (m *MockLabel) Detach(userId, itemId, labelId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Detach", userId, itemId, labelId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Detach indicates an expected call of Detach.
+func (mr *MockLabelMockRecorder) Detach(userId, itemId, labelId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Detach", reflect.TypeOf((*MockLabel)(nil).Detach), userId, itemId, labelId)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoList) GetAll(userId int) ([]entity.TodoList, error) {
+func (m *MockLabel) GetAll(userId int) ([]entity.Label, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetAll", userId)
-	ret0, _ := ret[0].([]entity.TodoList)
+	ret0, _ := ret[0].([]entity.Label)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoListMockRecorder) GetAll(userId interface{}) *gomock.Call {
+func (mr *MockLabelMockRecorder) GetAll(userId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoList)(nil).GetAll), userId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockLabel)(nil).GetAll), userId)
 }
 
 // GetById mocks base method.
-func (m *MockTodoList) GetById(userId, listId int) (entity.TodoList, error) {
+func (m *MockLabel) GetById(userId, labelId int) (entity.Label, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", userId, listId)
-	ret0, _ := ret[0].(entity.TodoList)
+	ret := m.ctrl.Call(m, "GetById", userId, labelId)
+	ret0, _ := ret[0].(entity.Label)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetById indicates an expected call of GetById.
-func (mr *MockTodoListMockRecorder) GetById(userId, listId interface{}) *gomock.Call {
+func (mr *MockLabelMockRecorder) GetById(userId, labelId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoList)(nil).GetById), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockLabel)(nil).GetById), userId, labelId)
 }
 
 // Update mocks base method.
-func (m *MockTodoList) Update(userId, listId int, input entity.UpdateListInput) error {
+func (m *MockLabel) Update(userId, labelId int, input entity.UpdateLabelInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, listId, input)
+	ret := m.ctrl.Call(m, "Update", userId, labelId, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTodoListMockRecorder) Update(userId, listId, input interface{}) *gomock.Call {
+func (mr *MockLabelMockRecorder) Update(userId, labelId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoList)(nil).Update), userId, listId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockLabel)(nil).Update), userId, labelId, input)
 }
 
-// MockTodoItem is a mock of TodoItem interface.
-type MockTodoItem struct {
+// MockNotification is a mock of Notification interface.
+type MockNotification struct {
 	ctrl     *gomock.Controller
-	recorder *MockTodoItemMockRecorder
+	recorder *MockNotificationMockRecorder
 }
 
-// MockTodoItemMockRecorder is the mock recorder for MockTodoItem.
-type MockTodoItemMockRecorder struct {
-	mock *MockTodoItem
+// MockNotificationMockRecorder is the mock recorder for MockNotification.
+type MockNotificationMockRecorder struct {
+	mock *MockNotification
 }
 
-// NewMockTodoItem creates a new mock instance.
-func NewMockTodoItem(ctrl *gomock.Controller) *MockTodoItem {
-	mock := &MockTodoItem{ctrl: ctrl}
-	mock.recorder = &MockTodoItemMockRecorder{mock}
+// NewMockNotification creates a new mock instance.
+func NewMockNotification(ctrl *gomock.Controller) *MockNotification {
+	mock := &MockNotification{ctrl: ctrl}
+	mock.recorder = &MockNotificationMockRecorder{mock}
 	return mock
 }
 
 // EXPECT returns an object that allows the caller to indicate expected use.
-func (m *MockTodoItem) EXPECT() *MockTodoItemMockRecorder {
+func (m *MockNotification) EXPECT() *MockNotificationMockRecorder {
+	return m.recorder
+}
+
+// CountUnread mocks base method.
+func (m *MockNotification) CountUnread(userId int) (map[string]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountUnread", userId)
+	ret0, _ := ret[0].(map[string]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountUnread indicates an expected call of CountUnread.
+func (mr *MockNotificationMockRecorder) CountUnread(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountUnread", reflect.TypeOf((*MockNotification)(nil).CountUnread), userId)
+}
+
+// GetAll mocks base method.
+func (m *MockNotification) GetAll(userId int, filter entity.NotificationFilter) ([]entity.Notification, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, filter)
+	ret0, _ := ret[0].([]entity.Notification)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockNotificationMockRecorder) GetAll(userId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockNotification)(nil).GetAll), userId, filter)
+}
+
+// GetPreferences mocks base method.
+func (m *MockNotification) GetPreferences(userId int) ([]entity.NotificationPreference, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPreferences", userId)
+	ret0, _ := ret[0].([]entity.NotificationPreference)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPreferences indicates an expected call of GetPreferences.
+func (mr *MockNotificationMockRecorder) GetPreferences(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPreferences", reflect.TypeOf((*MockNotification)(nil).GetPreferences), userId)
+}
+
+// MarkAllRead mocks base method.
+func (m *MockNotification) MarkAllRead(userId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkAllRead", userId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkAllRead indicates an expected call of MarkAllRead.
+func (mr *MockNotificationMockRecorder) MarkAllRead(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkAllRead", reflect.TypeOf((*MockNotification)(nil).MarkAllRead), userId)
+}
+
+// MarkRead mocks base method.
+func (m *MockNotification) MarkRead(userId, notificationId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkRead", userId, notificationId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkRead indicates an expected call of MarkRead.
+func (mr *MockNotificationMockRecorder) MarkRead(userId, notificationId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkRead", reflect.TypeOf((*MockNotification)(nil).MarkRead), userId, notificationId)
+}
+
+// UpdatePreferences mocks base method.
+func (m *MockNotification) UpdatePreferences(userId int, input entity.UpdateNotificationPreferencesInput) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePreferences", userId, input)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePreferences indicates an expected call of UpdatePreferences.
+func (mr *MockNotificationMockRecorder) UpdatePreferences(userId, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePreferences", reflect.TypeOf((*MockNotification)(nil).UpdatePreferences), userId, input)
+}
+
+// MockReminder is a mock of Reminder interface.
+type MockReminder struct {
+	ctrl     *gomock.Controller
+	recorder *MockReminderMockRecorder
+}
+
+// MockReminderMockRecorder is the mock recorder for MockReminder.
+type MockReminderMockRecorder struct {
+	mock *MockReminder
+}
+
+// NewMockReminder creates a new mock instance.
+func NewMockReminder(ctrl *gomock.Controller) *MockReminder {
+	mock := &MockReminder{ctrl: ctrl}
+	mock.recorder = &MockReminderMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockReminder) EXPECT() *MockReminderMockRecorder {
 	return m.recorder
 }
 
 // Create mocks base method.
-func (m *MockTodoItem) Create(userId, listId int, input entity.TodoItem) (int, error) {
+func (m *MockReminder) Create(userId, itemId int, reminder entity.Reminder) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", userId, listId, input)
+	ret := m.ctrl.Call(m, "Create", userId, itemId, reminder)
 	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockTodoItemMockRecorder) Create(userId, listId, input interface{}) *gomock.Call {
+func (mr *MockReminderMockRecorder) Create(userId, itemId, reminder interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockTodoItem)(nil).Create), userId, listId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockReminder)(nil).Create), userId, itemId, reminder)
 }
 
 // Delete mocks base method.
-func (m *MockTodoItem) Delete(userId, itemId int) error {
+func (m *MockReminder) Delete(userId, itemId, reminderId int) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", userId, itemId)
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, reminderId)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockTodoItemMockRecorder) Delete(userId, itemId interface{}) *gomock.Call {
+func (mr *MockReminderMockRecorder) Delete(userId, itemId, reminderId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTodoItem)(nil).Delete), userId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockReminder)(nil).Delete), userId, itemId, reminderId)
 }
 
 // GetAll mocks base method.
-func (m *MockTodoItem) GetAll(userId, listId int) ([]entity.TodoItem, error) {
+func (m *MockReminder) GetAll(userId, itemId int) ([]entity.Reminder, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", userId, listId)
-	ret0, _ := ret[0].([]entity.TodoItem)
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]entity.Reminder)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockTodoItemMockRecorder) GetAll(userId, listId interface{}) *gomock.Call {
+func (mr *MockReminderMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockTodoItem)(nil).GetAll), userId, listId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockReminder)(nil).GetAll), userId, itemId)
 }
 
-// GetById mocks base method.
-func (m *MockTodoItem) GetById(userId, itemId int) (entity.TodoItem, error) {
+// MockComment is a mock of Comment interface.
+type MockComment struct {
+	ctrl     *gomock.Controller
+	recorder *MockCommentMockRecorder
+}
+
+// MockCommentMockRecorder is the mock recorder for MockComment.
+type MockCommentMockRecorder struct {
+	mock *MockComment
+}
+
+// NewMockComment creates a new mock instance.
+func NewMockComment(ctrl *gomock.Controller) *MockComment {
+	mock := &MockComment{ctrl: ctrl}
+	mock.recorder = &MockCommentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockComment) EXPECT() *MockCommentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockComment) Create(userId, itemId int, input entity.CreateCommentInput) (int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetById", userId, itemId)
-	ret0, _ := ret[0].(entity.TodoItem)
+	ret := m.ctrl.Call(m, "Create", userId, itemId, input)
+	ret0, _ := ret[0].(int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetById indicates an expected call of GetById.
-func (mr *MockTodoItemMockRecorder) GetById(userId, itemId interface{}) *gomock.Call {
+// Create indicates an expected call of Create.
+func (mr *MockCommentMockRecorder) Create(userId, itemId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetById", reflect.TypeOf((*MockTodoItem)(nil).GetById), userId, itemId)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockComment)(nil).Create), userId, itemId, input)
+}
+
+// Delete mocks base method.
+func (m *MockComment) Delete(userId, itemId, commentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, commentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCommentMockRecorder) Delete(userId, itemId, commentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockComment)(nil).Delete), userId, itemId, commentId)
+}
+
+// GetAll mocks base method.
+func (m *MockComment) GetAll(userId, itemId int) ([]entity.Comment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]entity.Comment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCommentMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockComment)(nil).GetAll), userId, itemId)
 }
 
 // Update mocks base method.
-func (m *MockTodoItem) Update(userId, itemId int, input entity.UpdateItemInput) error {
+func (m *MockComment) Update(userId, itemId, commentId int, input entity.UpdateCommentInput) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", userId, itemId, input)
+	ret := m.ctrl.Call(m, "Update", userId, itemId, commentId, input)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockTodoItemMockRecorder) Update(userId, itemId, input interface{}) *gomock.Call {
+func (mr *MockCommentMockRecorder) Update(userId, itemId, commentId, input interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockTodoItem)(nil).Update), userId, itemId, input)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockComment)(nil).Update), userId, itemId, commentId, input)
+}
+
+// MockAttachment is a mock of Attachment interface.
+type MockAttachment struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentMockRecorder
+}
+
+// MockAttachmentMockRecorder is the mock recorder for MockAttachment.
+type MockAttachmentMockRecorder struct {
+	mock *MockAttachment
+}
+
+// NewMockAttachment creates a new mock instance.
+func NewMockAttachment(ctrl *gomock.Controller) *MockAttachment {
+	mock := &MockAttachment{ctrl: ctrl}
+	mock.recorder = &MockAttachmentMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachment) EXPECT() *MockAttachmentMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachment) Create(userId, itemId int, upload entity.AttachmentUpload) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", userId, itemId, upload)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentMockRecorder) Create(userId, itemId, upload interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachment)(nil).Create), userId, itemId, upload)
+}
+
+// Delete mocks base method.
+func (m *MockAttachment) Delete(userId, itemId, attachmentId int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", userId, itemId, attachmentId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentMockRecorder) Delete(userId, itemId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachment)(nil).Delete), userId, itemId, attachmentId)
+}
+
+// GetAll mocks base method.
+func (m *MockAttachment) GetAll(userId, itemId int) ([]entity.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", userId, itemId)
+	ret0, _ := ret[0].([]entity.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockAttachmentMockRecorder) GetAll(userId, itemId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockAttachment)(nil).GetAll), userId, itemId)
+}
+
+// MaxSize mocks base method.
+func (m *MockAttachment) MaxSize() int64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxSize")
+	ret0, _ := ret[0].(int64)
+	return ret0
+}
+
+// MaxSize indicates an expected call of MaxSize.
+func (mr *MockAttachmentMockRecorder) MaxSize() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxSize", reflect.TypeOf((*MockAttachment)(nil).MaxSize))
+}
+
+// Open mocks base method.
+func (m *MockAttachment) Open(userId, itemId, attachmentId int) (entity.Attachment, io.ReadSeekCloser, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Open", userId, itemId, attachmentId)
+	ret0, _ := ret[0].(entity.Attachment)
+	ret1, _ := ret[1].(io.ReadSeekCloser)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// Open indicates an expected call of Open.
+func (mr *MockAttachmentMockRecorder) Open(userId, itemId, attachmentId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Open", reflect.TypeOf((*MockAttachment)(nil).Open), userId, itemId, attachmentId)
 }
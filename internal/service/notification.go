@@ -0,0 +1,85 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/sirupsen/logrus"
+)
+
+var ErrNotificationNotFound = errors.New("notification not found")
+
+// notifier рассылает уведомления о событиях, которые происходят в других сервисах.
+// Ошибка рассылки только пишется в лог и не отменяет саму операцию.
+type notifier interface {
+	Notify(notification entity.Notification)
+	NotifyMembers(listId int, notification entity.Notification)
+}
+
+type NotificationService struct {
+	repo repository.Notification
+}
+
+func NewNotificationService(repo repository.Notification) *NotificationService {
+	return &NotificationService{repo: repo}
+}
+
+func (s *NotificationService) GetAll(userId int, filter entity.NotificationFilter) ([]entity.Notification, error) {
+	return s.repo.GetAll(userId, filter)
+}
+
+func (s *NotificationService) CountUnread(userId int) (map[string]int, error) {
+	return s.repo.CountUnread(userId)
+}
+
+func (s *NotificationService) MarkRead(userId, notificationId int) error {
+	err := s.repo.MarkRead(userId, notificationId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrNotificationNotFound
+	}
+	return err
+}
+
+func (s *NotificationService) MarkAllRead(userId int) error {
+	return s.repo.MarkAllRead(userId)
+}
+
+// GetPreferences возвращает все типы уведомлений с отметкой, отключён ли тип пользователем.
+func (s *NotificationService) GetPreferences(userId int) ([]entity.NotificationPreference, error) {
+	muted, err := s.repo.GetMuted(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	isMuted := make(map[string]bool, len(muted))
+	for _, t := range muted {
+		isMuted[t] = true
+	}
+
+	preferences := make([]entity.NotificationPreference, 0, len(entity.NotificationTypes))
+	for _, t := range entity.NotificationTypes {
+		preferences = append(preferences, entity.NotificationPreference{Type: t, Muted: isMuted[t]})
+	}
+	return preferences, nil
+}
+
+func (s *NotificationService) UpdatePreferences(userId int, input entity.UpdateNotificationPreferencesInput) error {
+	if err := input.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SetMuted(userId, input.Muted)
+}
+
+func (s *NotificationService) Notify(notification entity.Notification) {
+	if _, err := s.repo.Create(notification); err != nil {
+		logrus.Errorf("Ошибка при создании уведомления %s для пользователя %d: %s", notification.Type, notification.UserId, err.Error())
+	}
+}
+
+// NotifyMembers уведомляет всех участников списка, кроме совершившего действие.
+func (s *NotificationService) NotifyMembers(listId int, notification entity.Notification) {
+	if err := s.repo.CreateForMembers(listId, notification); err != nil {
+		logrus.Errorf("Ошибка при рассылке уведомления %s участникам списка %d: %s", notification.Type, listId, err.Error())
+	}
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotificationService_Preferences(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repository.NewMockNotification(c)
+	s := NewNotificationService(repo)
+
+	repo.EXPECT().GetMuted(1).Return([]string{entity.NotificationItemCompleted}, nil)
+	preferences, err := s.GetPreferences(1)
+	assert.NoError(t, err)
+	assert.Len(t, preferences, len(entity.NotificationTypes))
+	for _, preference := range preferences {
+		assert.Equal(t, preference.Type == entity.NotificationItemCompleted, preference.Muted, preference.Type)
+	}
+
+	repo.EXPECT().SetMuted(1, []string{entity.NotificationReminder}).Return(nil)
+	assert.NoError(t, s.UpdatePreferences(1, entity.UpdateNotificationPreferencesInput{Muted: []string{entity.NotificationReminder}}))
+
+	assert.EqualError(t, s.UpdatePreferences(1, entity.UpdateNotificationPreferencesInput{Muted: []string{"spam"}}),
+		`unknown notification type "spam"`)
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repository.NewMockNotification(c)
+	s := NewNotificationService(repo)
+
+	repo.EXPECT().MarkRead(1, 5).Return(sql.ErrNoRows)
+	assert.ErrorIs(t, s.MarkRead(1, 5), ErrNotificationNotFound)
+}
+
+func TestTodoListService_Delete(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	lists := mock_repository.NewMockTodoList(c)
+	notifications := mock_repository.NewMockNotification(c)
+	s := NewTodoListService(lists, NewNotificationService(notifications))
+
+	actorId := 1
+	lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+	lists.EXPECT().GetById(1, 2).Return(entity.TodoList{Id: 2, Title: "Trip"}, nil)
+	// Ошибка рассылки не мешает удалению
+	notifications.EXPECT().CreateForMembers(2, entity.Notification{Type: entity.NotificationListDeleted, Title: "Trip",
+		Body: "The list was deleted", ActorId: &actorId}).Return(errors.New("connection reset"))
+	lists.EXPECT().Delete(1, 2).Return(nil)
+
+	assert.NoError(t, s.Delete(1, 2))
+}
@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	jwt "github.com/golang-jwt/jwt/v5"
+	"strings"
+	"time"
+)
+
+const (
+	oidcStateTTL      = 10 * time.Minute
+	oidcStateAudience = "oidc-state"
+	// provisionAttempts - сколько раз подбирается свободный логин для нового пользователя
+	provisionAttempts = 5
+)
+
+var (
+	ErrUnknownProvider  = errors.New("unknown identity provider")
+	ErrInvalidOIDCState = errors.New("invalid or expired login state")
+)
+
+// oidcStateClaims подписываются ключами приложения и хранятся в cookie браузера
+// между переходом к провайдеру и возвратом на callback.
+type oidcStateClaims struct {
+	jwt.RegisteredClaims
+	Provider string `json:"provider"`
+	State    string `json:"state"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+}
+
+type OIDCService struct {
+	auth      *AuthService
+	repo      repository.ExternalIdentity
+	keys      *keyring.KeyRing
+	providers map[string]*oidc.Provider
+}
+
+func NewOIDCService(auth *AuthService, repo repository.ExternalIdentity, keys *keyring.KeyRing, providers []*oidc.Provider) *OIDCService {
+	s := &OIDCService{auth: auth, repo: repo, keys: keys, providers: make(map[string]*oidc.Provider, len(providers))}
+	for _, provider := range providers {
+		s.providers[provider.Name()] = provider
+	}
+	return s
+}
+
+// LoginURL готовит переход на страницу входа провайдера. StateToken нужно вернуть в Callback.
+func (s *OIDCService) LoginURL(providerName string) (entity.OIDCLogin, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return entity.OIDCLogin{}, ErrUnknownProvider
+	}
+
+	claims := oidcStateClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Audience:  jwt.ClaimStrings{oidcStateAudience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(oidcStateTTL)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+		Provider: providerName,
+	}
+	for _, v := range []*string{&claims.State, &claims.Nonce, &claims.Verifier} {
+		value, err := oidc.NewVerifier()
+		if err != nil {
+			return entity.OIDCLogin{}, err
+		}
+		*v = value
+	}
+
+	loginURL, err := provider.AuthCodeURL(context.Background(), claims.State, claims.Nonce, claims.Verifier)
+	if err != nil {
+		return entity.OIDCLogin{}, err
+	}
+
+	stateToken, err := s.keys.Sign(&claims)
+	if err != nil {
+		return entity.OIDCLogin{}, err
+	}
+
+	return entity.OIDCLogin{URL: loginURL, StateToken: stateToken}, nil
+}
+
+// Callback обменивает код авторизации на ID-токен, находит пользователя по subject
+// (или создаёт нового) и выдаёт обычные токены приложения.
+func (s *OIDCService) Callback(providerName string, input entity.OIDCCallbackInput, client entity.Client) (entity.Tokens, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return entity.Tokens{}, ErrUnknownProvider
+	}
+
+	token, err := jwt.ParseWithClaims(input.StateToken, &oidcStateClaims{}, s.keys.Keyfunc, jwt.WithAudience(oidcStateAudience))
+	if err != nil {
+		return entity.Tokens{}, ErrInvalidOIDCState
+	}
+	state, ok := token.Claims.(*oidcStateClaims)
+	if !ok || state.Provider != providerName || state.State == "" || state.State != input.State {
+		return entity.Tokens{}, ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(context.Background(), input.Code, state.Verifier, state.Nonce)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	userId, err := s.resolveUser(providerName, claims)
+	if err != nil {
+		return entity.Tokens{}, err
+	}
+
+	return s.auth.signIn(userId, client)
+}
+
+func (s *OIDCService) resolveUser(providerName string, claims oidc.Claims) (int, error) {
+	userId, err := s.repo.GetUserId(providerName, claims.Subject)
+	if err == nil {
+		return userId, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return 0, err
+	}
+
+	username := provisionUsername(providerName, claims)
+	name := claims.Name
+	if name == "" {
+		name = username
+	}
+
+	for i := 0; i < provisionAttempts; i++ {
+		candidate := username
+		if i > 0 {
+			suffix := make([]byte, 2)
+			if _, err := rand.Read(suffix); err != nil {
+				return 0, err
+			}
+			candidate = username + "-" + hex.EncodeToString(suffix)
+		}
+
+		// У такого пользователя нет пароля: хэш пустой и не совпадёт ни с одним паролем
+		userId, err = s.repo.CreateWithUser(entity.User{Name: name, Username: candidate}, entity.ExternalIdentity{
+			Provider: providerName,
+			Subject:  claims.Subject,
+			Email:    claims.Email,
+		})
+		if !isUniqueViolation(err) {
+			return userId, err
+		}
+
+		// Конфликт мог возникнуть из-за параллельного входа того же пользователя
+		userId, err = s.repo.GetUserId(providerName, claims.Subject)
+		if err == nil {
+			return userId, nil
+		}
+		if !errors.Is(err, sql.ErrNoRows) {
+			return 0, err
+		}
+	}
+
+	return 0, ErrUsernameTaken
+}
+
+func provisionUsername(providerName string, claims oidc.Claims) string {
+	switch {
+	case claims.PreferredUsername != "":
+		return claims.PreferredUsername
+	case claims.Email != "":
+		return strings.SplitN(claims.Email, "@", 2)[0]
+	default:
+		return providerName + "-" + claims.Subject
+	}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
+	"github.com/IncubusX/go-todo-app/internal/oidc/oidctest"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+type oidcTestRepos struct {
+	identity  *mock_repository.MockExternalIdentity
+	session   *mock_repository.MockSession
+	twoFactor *mock_repository.MockTwoFactor
+}
+
+func newTestOIDCService(t *testing.T, c *gomock.Controller) (*OIDCService, *oidctest.Issuer, oidcTestRepos) {
+	issuer, err := oidctest.NewIssuer("todo-app", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(issuer.Close)
+
+	keys, _ := keyring.NewKeyRing("test", keyring.NewHMACKey("test", []byte("secret")))
+	repos := oidcTestRepos{
+		identity:  mock_repository.NewMockExternalIdentity(c),
+		session:   mock_repository.NewMockSession(c),
+		twoFactor: mock_repository.NewMockTwoFactor(c),
+	}
+
+	authRepo := mock_repository.NewMockAuthorization(c)
+	auth := NewAuthService(authRepo, repos.session, mock_repository.NewMockPersonalAccessToken(c),
+		NewTwoFactorService(repos.twoFactor, authRepo), nil, nil,
+		hash.NewArgon2idHasher(hash.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}, ""),
+		keys, 0, 0)
+
+	provider := oidc.NewProvider(oidc.Config{
+		Name:         "stub",
+		Issuer:       issuer.URL,
+		ClientId:     "todo-app",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8000/auth/oidc/stub/callback",
+	}, issuer.Client())
+
+	return NewOIDCService(auth, repos.identity, keys, []*oidc.Provider{provider}), issuer, repos
+}
+
+// login проходит вход у провайдера и возвращает то, что браузер принёс бы на callback.
+func login(t *testing.T, s *OIDCService, issuer *oidctest.Issuer, user oidctest.User) entity.OIDCCallbackInput {
+	start, err := s.LoginURL("stub")
+	assert.NoError(t, err)
+
+	code, state, err := issuer.Login(start.URL, user)
+	assert.NoError(t, err)
+
+	return entity.OIDCCallbackInput{Code: code, State: state, StateToken: start.StateToken}
+}
+
+func TestOIDCService_Callback(t *testing.T) {
+	user := oidctest.User{Subject: "sub-1", Email: "jane@example.com", Name: "Jane Doe"}
+
+	tt := []struct {
+		name         string
+		mockBehavior func(r oidcTestRepos)
+		tamper       func(input *entity.OIDCCallbackInput)
+		wantErr      error
+	}{
+		{
+			name: "Existing identity",
+			mockBehavior: func(r oidcTestRepos) {
+				r.identity.EXPECT().GetUserId("stub", "sub-1").Return(7, nil)
+				r.twoFactor.EXPECT().Get(7).Return(entity.TwoFactor{}, sql.ErrNoRows)
+				r.session.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+		},
+		{
+			name: "Provision",
+			mockBehavior: func(r oidcTestRepos) {
+				r.identity.EXPECT().GetUserId("stub", "sub-1").Return(0, sql.ErrNoRows)
+				r.identity.EXPECT().CreateWithUser(entity.User{Name: "Jane Doe", Username: "jane"}, entity.ExternalIdentity{
+					Provider: "stub",
+					Subject:  "sub-1",
+					Email:    "jane@example.com",
+				}).Return(7, nil)
+				r.twoFactor.EXPECT().Get(7).Return(entity.TwoFactor{}, sql.ErrNoRows)
+				r.session.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+		},
+		{
+			name: "Provision with taken username",
+			mockBehavior: func(r oidcTestRepos) {
+				r.identity.EXPECT().GetUserId("stub", "sub-1").Return(0, sql.ErrNoRows).Times(2)
+				r.identity.EXPECT().CreateWithUser(entity.User{Name: "Jane Doe", Username: "jane"}, gomock.Any()).
+					Return(0, &pq.Error{Code: "23505"})
+				r.identity.EXPECT().CreateWithUser(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(user entity.User, identity entity.ExternalIdentity) (int, error) {
+						assert.Regexp(t, "^jane-[0-9a-f]{4}$", user.Username)
+						return 8, nil
+					})
+				r.twoFactor.EXPECT().Get(8).Return(entity.TwoFactor{}, sql.ErrNoRows)
+				r.session.EXPECT().Create(gomock.Any(), gomock.Any()).Return(1, nil)
+			},
+		},
+		{
+			name: "Two-factor enabled",
+			mockBehavior: func(r oidcTestRepos) {
+				r.identity.EXPECT().GetUserId("stub", "sub-1").Return(7, nil)
+				r.twoFactor.EXPECT().Get(7).Return(entity.TwoFactor{Enabled: true}, nil)
+			},
+		},
+		{
+			name:         "State mismatch",
+			mockBehavior: func(r oidcTestRepos) {},
+			tamper: func(input *entity.OIDCCallbackInput) {
+				input.State = "forged"
+			},
+			wantErr: ErrInvalidOIDCState,
+		},
+		{
+			name:         "Forged state token",
+			mockBehavior: func(r oidcTestRepos) {},
+			tamper: func(input *entity.OIDCCallbackInput) {
+				input.StateToken += "x"
+			},
+			wantErr: ErrInvalidOIDCState,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, issuer, repos := newTestOIDCService(t, c)
+			tc.mockBehavior(repos)
+
+			input := login(t, s, issuer, user)
+			if tc.tamper != nil {
+				tc.tamper(&input)
+			}
+
+			tokens, err := s.Callback("stub", input, entity.Client{})
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.True(t, tokens.AccessToken != "" || tokens.ChallengeToken != "")
+		})
+	}
+}
+
+func TestOIDCService_UnknownProvider(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	s, _, _ := newTestOIDCService(t, c)
+
+	_, err := s.LoginURL("other")
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+
+	_, err = s.Callback("other", entity.OIDCCallbackInput{}, entity.Client{})
+	assert.ErrorIs(t, err, ErrUnknownProvider)
+}
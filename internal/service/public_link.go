@@ -0,0 +1,167 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPublicLinkNotFound = errors.New("public link not found")
+	ErrPasswordRequired   = errors.New("password required")
+	ErrInvalidPassword    = errors.New("invalid password")
+)
+
+type PublicLinkService struct {
+	repo     repository.PublicLink
+	listRepo repository.TodoList
+	itemRepo repository.TodoItem
+	hasher   hash.PasswordHasher
+	throttle *LoginThrottle
+	appURL   string
+}
+
+func NewPublicLinkService(repo repository.PublicLink, listRepo repository.TodoList, itemRepo repository.TodoItem,
+	hasher hash.PasswordHasher, throttle *LoginThrottle, appURL string) *PublicLinkService {
+	return &PublicLinkService{
+		repo:     repo,
+		listRepo: listRepo,
+		itemRepo: itemRepo,
+		hasher:   hasher,
+		throttle: throttle,
+		appURL:   strings.TrimSuffix(appURL, "/"),
+	}
+}
+
+// Publish выпускает новую публичную ссылку на список. Токен возвращается только здесь.
+func (s *PublicLinkService) Publish(userId, listId int, input entity.PublishListInput) (entity.PublicLink, error) {
+	if err := input.Validate(); err != nil {
+		return entity.PublicLink{}, err
+	}
+	if err := requireListRole(s.listRepo, userId, listId, isListOwner); err != nil {
+		return entity.PublicLink{}, err
+	}
+
+	token, err := newRandomToken()
+	if err != nil {
+		return entity.PublicLink{}, err
+	}
+
+	link := entity.PublicLink{
+		ListId:    listId,
+		CreatedBy: userId,
+		TokenHash: hashToken(token),
+	}
+	if input.ExpiresIn > 0 {
+		expiresAt := time.Now().Add(time.Duration(input.ExpiresIn) * time.Second)
+		link.ExpiresAt = &expiresAt
+	}
+	if input.Password != "" {
+		if link.PasswordHash, err = s.hasher.Hash(input.Password); err != nil {
+			return entity.PublicLink{}, err
+		}
+	}
+
+	link, err = s.repo.Save(link)
+	if err != nil {
+		return entity.PublicLink{}, err
+	}
+	link.HasPassword = link.PasswordHash != ""
+	link.Token = token
+	link.URL = s.appURL + "/public/lists/" + token
+
+	return link, nil
+}
+
+func (s *PublicLinkService) Unpublish(userId, listId int) error {
+	if err := requireListRole(s.listRepo, userId, listId, isListOwner); err != nil {
+		return err
+	}
+
+	err := s.repo.Delete(listId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrPublicLinkNotFound
+	}
+	return err
+}
+
+// Get отдаёт опубликованный список без авторизации. Список и задачи читаются от имени того,
+// кто его опубликовал, поэтому ссылка перестаёт работать, если он потерял доступ к списку.
+// Подбор пароля ограничивается так же, как вход: по ссылке и по IP-адресу клиента.
+func (s *PublicLinkService) Get(token, password, ip string) (entity.PublicList, error) {
+	link, err := s.repo.GetByHash(hashToken(token))
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PublicList{}, ErrPublicLinkNotFound
+	}
+	if err != nil {
+		return entity.PublicList{}, err
+	}
+
+	if link.PasswordHash != "" {
+		if password == "" {
+			return entity.PublicList{}, ErrPasswordRequired
+		}
+		if err := s.verifyPassword(link, password, ip); err != nil {
+			return entity.PublicList{}, err
+		}
+	}
+
+	list, err := s.listRepo.GetById(link.CreatedBy, link.ListId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.PublicList{}, ErrPublicLinkNotFound
+	}
+	if err != nil {
+		return entity.PublicList{}, err
+	}
+
+	items, err := s.itemRepo.GetAll(link.CreatedBy, link.ListId, entity.ItemFilter{})
+	if err != nil {
+		return entity.PublicList{}, err
+	}
+
+	return entity.PublicList{
+		Title:       list.Title,
+		Description: list.Description,
+		Items:       publicItems(entity.BuildItemTree(items)),
+	}, nil
+}
+
+func (s *PublicLinkService) verifyPassword(link entity.PublicLink, password, ip string) error {
+	if err := s.throttle.CheckPublicLink(link.Id, ip); err != nil {
+		return err
+	}
+
+	ok, err := s.hasher.Verify(password, link.PasswordHash)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if err := s.throttle.FailPublicLink(link.Id, ip); err != nil {
+			return err
+		}
+		return ErrInvalidPassword
+	}
+
+	return s.throttle.SucceedPublicLink(link.Id)
+}
+
+func publicItems(items []entity.TodoItem) []entity.PublicItem {
+	public := make([]entity.PublicItem, 0, len(items))
+	for _, item := range items {
+		publicItem := entity.PublicItem{
+			Title:       item.Title,
+			Description: item.Description,
+			Done:        item.Done,
+			DueAt:       item.DueAt,
+		}
+		if len(item.Children) > 0 {
+			publicItem.Children = publicItems(item.Children)
+		}
+		public = append(public, publicItem)
+	}
+	return public
+}
@@ -0,0 +1,114 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/repository/memory"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicLinkService(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repository.NewMockPublicLink(c)
+	lists := mock_repository.NewMockTodoList(c)
+	items := mock_repository.NewMockTodoItem(c)
+	hasher := hash.NewArgon2idHasher(hash.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}, "")
+	throttle := NewLoginThrottle(memory.NewLoginAttempt(), mock_repository.NewMockAudit(c), testLockoutPolicy)
+	s := NewPublicLinkService(repo, lists, items, hasher, throttle, "https://todo.example.com")
+
+	var saved entity.PublicLink
+	lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleOwner, nil)
+	repo.EXPECT().Save(gomock.Any()).DoAndReturn(func(link entity.PublicLink) (entity.PublicLink, error) {
+		saved = link
+		return link, nil
+	})
+
+	link, err := s.Publish(1, 2, entity.PublishListInput{ExpiresIn: 3600, Password: "pass"})
+	if !assert.NoError(t, err) {
+		t.FailNow()
+	}
+	assert.Equal(t, "https://todo.example.com/public/lists/"+link.Token, link.URL)
+	assert.True(t, link.HasPassword)
+	assert.Equal(t, hashToken(link.Token), saved.TokenHash, "в БД хранится только хэш токена")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), *saved.ExpiresAt, time.Minute)
+
+	repo.EXPECT().GetByHash(saved.TokenHash).Return(saved, nil).Times(3)
+
+	_, err = s.Get(link.Token, "", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrPasswordRequired)
+
+	_, err = s.Get(link.Token, "wrong", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrInvalidPassword)
+
+	lists.EXPECT().GetById(1, 2).Return(entity.TodoList{Id: 2, Title: "List", Role: entity.ListRoleOwner}, nil)
+	parentId, assigneeId := 3, 5
+	dueAt := time.Date(2023, 5, 1, 18, 0, 0, 0, time.UTC)
+	items.EXPECT().GetAll(1, 2, entity.ItemFilter{}).Return([]entity.TodoItem{
+		{Id: 3, Title: "Item", ListId: 2, DueAt: &dueAt, RRule: "FREQ=DAILY", AssigneeId: &assigneeId,
+			Labels: entity.LabelList{{Id: 1, Name: "home"}}},
+		{Id: 4, Title: "Subtask", Done: true, ParentId: &parentId, Depth: 1},
+	}, nil)
+	list, err := s.Get(link.Token, "pass", "10.0.0.1")
+	assert.NoError(t, err)
+	assert.Equal(t, entity.PublicList{Title: "List", Items: []entity.PublicItem{
+		{Title: "Item", DueAt: &dueAt, Children: []entity.PublicItem{{Title: "Subtask", Done: true}}},
+	}}, list)
+
+	repo.EXPECT().GetByHash(hashToken("revoked")).Return(entity.PublicLink{}, sql.ErrNoRows)
+	_, err = s.Get("revoked", "", "10.0.0.1")
+	assert.ErrorIs(t, err, ErrPublicLinkNotFound)
+}
+
+func TestPublicLinkService_PasswordLockout(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repository.NewMockPublicLink(c)
+	audit := mock_repository.NewMockAudit(c)
+	hasher := hash.NewArgon2idHasher(hash.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}, "")
+	now := time.Date(2023, 5, 1, 10, 0, 0, 0, time.UTC)
+	throttle := NewLoginThrottle(memory.NewLoginAttempt(), audit, testLockoutPolicy)
+	throttle.now = func() time.Time { return now }
+	s := NewPublicLinkService(repo, mock_repository.NewMockTodoList(c), mock_repository.NewMockTodoItem(c), hasher, throttle, "")
+
+	passwordHash, err := hasher.Hash("pass")
+	assert.NoError(t, err)
+	repo.EXPECT().GetByHash(hashToken("secret")).
+		Return(entity.PublicLink{Id: 7, ListId: 2, CreatedBy: 1, PasswordHash: passwordHash}, nil).Times(4)
+	audit.EXPECT().Create(gomock.Any()).DoAndReturn(func(entry entity.AuditEntry) error {
+		assert.Equal(t, "link:7", entry.Subject)
+		return nil
+	})
+
+	for i := 0; i < testLockoutPolicy.Threshold; i++ {
+		_, err = s.Get("secret", "guess", "10.0.0.1")
+		assert.ErrorIs(t, err, ErrInvalidPassword)
+	}
+
+	// Пока ссылка заблокирована, не проверяется даже верный пароль, в том числе с другого адреса
+	_, err = s.Get("secret", "pass", "10.0.0.2")
+	var lockout *LockoutError
+	if assert.ErrorAs(t, err, &lockout) {
+		assert.Equal(t, now.Add(time.Minute), lockout.Until)
+	}
+}
+
+func TestPublicLinkService_Publish_NotOwner(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	lists := mock_repository.NewMockTodoList(c)
+	s := NewPublicLinkService(mock_repository.NewMockPublicLink(c), lists, mock_repository.NewMockTodoItem(c), nil, nil, "")
+
+	lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleEditor, nil)
+	_, err := s.Publish(1, 2, entity.PublishListInput{})
+	assert.ErrorIs(t, err, ErrInsufficientRole)
+}
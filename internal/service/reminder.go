@@ -0,0 +1,70 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"time"
+)
+
+var (
+	ErrReminderNotFound = errors.New("reminder not found")
+	ErrNoDueDate        = errors.New("reminder offset requires item due date")
+	ErrReminderInPast   = errors.New("remind_at must be in the future")
+)
+
+type ReminderService struct {
+	repo     repository.Reminder
+	itemRepo repository.TodoItem
+	now      func() time.Time
+}
+
+func NewReminderService(repo repository.Reminder, itemRepo repository.TodoItem) *ReminderService {
+	return &ReminderService{repo: repo, itemRepo: itemRepo, now: time.Now}
+}
+
+// Create добавляет напоминание о задаче для самого пользователя, поэтому достаточно доступа к списку на чтение.
+func (s *ReminderService) Create(userId, itemId int, reminder entity.Reminder) (int, error) {
+	if err := reminder.Validate(); err != nil {
+		return 0, err
+	}
+
+	item, err := s.getItem(userId, itemId)
+	if err != nil {
+		return 0, err
+	}
+	if reminder.OffsetMinutes != nil && item.DueAt == nil {
+		return 0, ErrNoDueDate
+	}
+	if reminder.RemindAt != nil && !reminder.RemindAt.After(s.now()) {
+		return 0, ErrReminderInPast
+	}
+
+	reminder.ItemId = itemId
+	reminder.UserId = userId
+	return s.repo.Create(reminder)
+}
+
+func (s *ReminderService) GetAll(userId, itemId int) ([]entity.Reminder, error) {
+	if _, err := s.getItem(userId, itemId); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAll(userId, itemId)
+}
+
+func (s *ReminderService) Delete(userId, itemId, reminderId int) error {
+	err := s.repo.Delete(userId, itemId, reminderId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrReminderNotFound
+	}
+	return err
+}
+
+func (s *ReminderService) getItem(userId, itemId int) (entity.TodoItem, error) {
+	item, err := s.itemRepo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return item, ErrItemNotFound
+	}
+	return item, err
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderService_Create(t *testing.T) {
+	now := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	due := now.Add(24 * time.Hour)
+	past := now.Add(-time.Minute)
+	offset := 60
+
+	tt := []struct {
+		name         string
+		reminder     entity.Reminder
+		mockBehavior func(reminders *mock_repository.MockReminder, items *mock_repository.MockTodoItem)
+		wantErr      error
+	}{
+		{
+			name:     "Offset",
+			reminder: entity.Reminder{OffsetMinutes: &offset},
+			mockBehavior: func(reminders *mock_repository.MockReminder, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetById(1, 3).Return(entity.TodoItem{Id: 3, DueAt: &due}, nil)
+				reminders.EXPECT().Create(entity.Reminder{ItemId: 3, UserId: 1, OffsetMinutes: &offset, Channel: entity.ReminderChannelInbox}).Return(5, nil)
+			},
+		},
+		{
+			name:     "Absolute time without due date",
+			reminder: entity.Reminder{RemindAt: &due, Channel: entity.ReminderChannelEmail},
+			mockBehavior: func(reminders *mock_repository.MockReminder, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetById(1, 3).Return(entity.TodoItem{Id: 3}, nil)
+				reminders.EXPECT().Create(entity.Reminder{ItemId: 3, UserId: 1, RemindAt: &due, Channel: entity.ReminderChannelEmail}).Return(5, nil)
+			},
+		},
+		{
+			name:     "Offset without due date",
+			reminder: entity.Reminder{OffsetMinutes: &offset},
+			mockBehavior: func(reminders *mock_repository.MockReminder, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetById(1, 3).Return(entity.TodoItem{Id: 3}, nil)
+			},
+			wantErr: ErrNoDueDate,
+		},
+		{
+			name:     "Absolute time in past",
+			reminder: entity.Reminder{RemindAt: &past},
+			mockBehavior: func(reminders *mock_repository.MockReminder, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetById(1, 3).Return(entity.TodoItem{Id: 3}, nil)
+			},
+			wantErr: ErrReminderInPast,
+		},
+		{
+			name:     "Item not found",
+			reminder: entity.Reminder{OffsetMinutes: &offset},
+			mockBehavior: func(reminders *mock_repository.MockReminder, items *mock_repository.MockTodoItem) {
+				items.EXPECT().GetById(1, 3).Return(entity.TodoItem{}, sql.ErrNoRows)
+			},
+			wantErr: ErrItemNotFound,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			reminders := mock_repository.NewMockReminder(c)
+			items := mock_repository.NewMockTodoItem(c)
+			tc.mockBehavior(reminders, items)
+
+			s := NewReminderService(reminders, items)
+			s.now = func() time.Time { return now }
+
+			id, err := s.Create(1, 3, tc.reminder)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, 5, id)
+		})
+	}
+}
+
+func TestReminderService_Delete(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	reminders := mock_repository.NewMockReminder(c)
+	reminders.EXPECT().Delete(1, 3, 5).Return(sql.ErrNoRows)
+
+	s := NewReminderService(reminders, mock_repository.NewMockTodoItem(c))
+	assert.ErrorIs(t, s.Delete(1, 3, 5), ErrReminderNotFound)
+}
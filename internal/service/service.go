@@ -1,19 +1,78 @@
 package service
 
 import (
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/mail"
+	"github.com/IncubusX/go-todo-app/internal/oidc"
 	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/IncubusX/go-todo-app/internal/storage"
+	"time"
 )
 
 type Service struct {
 	Authorization
+	Account
+	OIDC
+	Session
+	PersonalAccessToken
+	TwoFactor
 	TodoList
+	ListTemplate
+	ListMember
+	Invitation
+	PublicLink
 	TodoItem
+	Label
+	Reminder
+	Notification
+	Comment
+	Attachment
 }
 
-func NewService(repos *repository.Repository) *Service {
+type Deps struct {
+	Hasher          hash.PasswordHasher
+	Keys            *keyring.KeyRing
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	Lockout         LockoutPolicy
+	OIDCProviders   []*oidc.Provider
+	Mailer          mail.Mailer
+	// AppURL - адрес веб-приложения, на который ведут ссылки из писем
+	AppURL string
+	Blobs  storage.BlobStore
+	// MaxAttachmentSize - наибольший размер вложения в байтах
+	MaxAttachmentSize int64
+}
+
+func NewService(repos *repository.Repository, deps Deps) *Service {
+	twoFactor := NewTwoFactorService(repos.TwoFactor, repos.Authorization)
+	throttle := NewLoginThrottle(repos.LoginAttempt, repos.Audit, deps.Lockout)
+	account := NewAccountService(repos.Authorization, repos.UserToken, repos.Session, repos.PersonalAccessToken,
+		deps.Hasher, deps.Mailer, deps.AppURL)
+	auth := NewAuthService(repos.Authorization, repos.Session, repos.PersonalAccessToken, twoFactor, account,
+		throttle, deps.Hasher, deps.Keys, deps.AccessTokenTTL, deps.RefreshTokenTTL)
+	notification := NewNotificationService(repos.Notification)
+	invitation := NewInvitationService(repos.ListInvitation, repos.InviteLink, repos.TodoList, repos.Authorization,
+		notification, deps.Keys, deps.AppURL)
+
 	return &Service{
-		Authorization: NewAuthService(repos.Authorization),
-		TodoList:      NewTodoListService(repos.TodoList),
-		TodoItem:      NewTodoItemService(repos.TodoItem, repos.TodoList),
+		Authorization:       auth,
+		Account:             account,
+		OIDC:                NewOIDCService(auth, repos.ExternalIdentity, deps.Keys, deps.OIDCProviders),
+		Session:             NewSessionService(repos.Session),
+		PersonalAccessToken: NewPersonalAccessTokenService(repos.PersonalAccessToken),
+		TwoFactor:           twoFactor,
+		TodoList:            NewTodoListService(repos.TodoList, notification),
+		ListTemplate:        NewListTemplateService(repos.ListTemplate, repos.TodoList),
+		ListMember:          NewListMemberService(repos.ListMember, repos.TodoList),
+		Invitation:          invitation,
+		PublicLink:          NewPublicLinkService(repos.PublicLink, repos.TodoList, repos.TodoItem, deps.Hasher, throttle, deps.AppURL),
+		TodoItem:            NewTodoItemService(repos.TodoItem, repos.TodoList, repos.Authorization, notification),
+		Label:               NewLabelService(repos.Label, repos.TodoItem),
+		Reminder:            NewReminderService(repos.Reminder, repos.TodoItem),
+		Notification:        notification,
+		Comment:             NewCommentService(repos.Comment, repos.TodoItem, repos.ListMember, notification),
+		Attachment:          NewAttachmentService(repos.Attachment, repos.TodoItem, deps.Blobs, deps.MaxAttachmentSize),
 	}
 }
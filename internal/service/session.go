@@ -0,0 +1,43 @@
+package service
+
+import (
+	"database/sql"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+type SessionService struct {
+	repo repository.Session
+}
+
+func NewSessionService(repo repository.Session) *SessionService {
+	return &SessionService{repo: repo}
+}
+
+func (s *SessionService) GetAll(userId, currentSessionId int) ([]entity.Session, error) {
+	sessions, err := s.repo.GetAll(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].Id == currentSessionId
+	}
+
+	return sessions, nil
+}
+
+func (s *SessionService) Revoke(userId, sessionId int) error {
+	session, err := s.repo.GetById(sessionId)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && session.UserId != userId {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.repo.Revoke(sessionId)
+}
@@ -1,42 +1,518 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/IncubusX/go-todo-app/internal/rrule"
+	"time"
+)
+
+var (
+	ErrItemNotFound      = errors.New("item not found")
+	ErrUnknownPeriod     = errors.New("unknown due period")
+	ErrInvalidParent     = errors.New("parent must be an item of the same list outside the item's subtasks")
+	ErrNotRecurring      = errors.New("item is not recurring")
+	ErrNoMoreOccurrences = errors.New("recurring series has no more occurrences")
+	ErrInvalidAssignee   = errors.New("assignee must be a member of the item's list")
+	ErrInvalidSchedule   = errors.New("invalid schedule")
 )
 
 type TodoItemService struct {
-	repo     repository.TodoItem
-	listRepo repository.TodoList
+	repo          repository.TodoItem
+	listRepo      repository.TodoList
+	userRepo      repository.Authorization
+	notifications notifier
+	now           func() time.Time
 }
 
-func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList) *TodoItemService {
-	return &TodoItemService{repo: repo, listRepo: listRepo}
+func NewTodoItemService(repo repository.TodoItem, listRepo repository.TodoList, userRepo repository.Authorization,
+	notifications notifier) *TodoItemService {
+	return &TodoItemService{repo: repo, listRepo: listRepo, userRepo: userRepo, notifications: notifications, now: time.Now}
 }
 
 func (s *TodoItemService) Create(userId, listId int, input entity.TodoItem) (int, error) {
-	if _, err := s.listRepo.GetById(userId, listId); err != nil {
+	if err := input.Validate(); err != nil {
 		return 0, err
 	}
+	if err := requireListRole(s.listRepo, userId, listId, entity.CanEditList); err != nil {
+		return 0, err
+	}
+	if input.ParentId != nil {
+		if err := s.checkParent(userId, listId, *input.ParentId, nil); err != nil {
+			return 0, err
+		}
+	}
 
+	input.NormalizeDates()
+	rule, err := entity.NormalizeRRule(input.RRule)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+	}
+	input.RRule = rule
 	return s.repo.Create(listId, input)
 }
 
-func (s *TodoItemService) GetAll(userId, listId int) ([]entity.TodoItem, error) {
-	return s.repo.GetAll(userId, listId)
+func (s *TodoItemService) GetAll(userId, listId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	return s.repo.GetAll(userId, listId, filter)
+}
+
+func (s *TodoItemService) Search(userId int, filter entity.ItemFilter) ([]entity.TodoItem, error) {
+	return s.repo.Search(userId, filter)
 }
 
 func (s *TodoItemService) GetById(userId, itemId int) (entity.TodoItem, error) {
-	return s.repo.GetById(userId, itemId)
+	item, err := s.repo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return item, ErrItemNotFound
+	}
+	return item, err
 }
 
 func (s *TodoItemService) Update(userId, itemId int, input entity.UpdateItemInput) error {
 	if err := input.Validate(); err != nil {
 		return err
 	}
-	return s.repo.Update(userId, itemId, input)
+	if err := requireItemEditor(s.repo, userId, itemId); err != nil {
+		return err
+	}
+	if input.ParentId.Set && input.ParentId.Value != nil {
+		if err := s.checkItemParent(userId, itemId, *input.ParentId.Value); err != nil {
+			return err
+		}
+	}
+	completing := input.Done != nil && *input.Done
+	// Задача до обновления: уведомления отправляются только о новом исполнителе и о завершении невыполненной задачи
+	var before entity.TodoItem
+	if input.AssigneeId.Value != nil || completing {
+		item, err := s.GetById(userId, itemId)
+		if err != nil {
+			return err
+		}
+		before = item
+	}
+	if input.AssigneeId.Value != nil {
+		if err := s.checkAssignee(before, *input.AssigneeId.Value); err != nil {
+			return err
+		}
+	}
+	if input.TouchesDates() {
+		if err := s.normalizeDates(userId, itemId, &input); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.Update(userId, itemId, input); err != nil {
+		return err
+	}
+
+	if input.AssigneeId.Value != nil {
+		s.notifyAssignee(userId, before, *input.AssigneeId.Value)
+	}
+
+	if !completing {
+		return nil
+	}
+
+	// Выполненная повторяющаяся задача переносится на следующее повторение, а не остаётся выполненной
+	item, err := s.GetById(userId, itemId)
+	if err != nil {
+		return err
+	}
+	if !before.Done {
+		s.notifications.NotifyMembers(item.ListId, entity.Notification{
+			Type:    entity.NotificationItemCompleted,
+			Title:   item.Title,
+			Body:    "The item was completed",
+			ListId:  &item.ListId,
+			ItemId:  &item.Id,
+			ActorId: &userId,
+		})
+	}
+
+	rescheduled, err := s.reschedule(userId, item)
+	if err != nil || rescheduled {
+		return err
+	}
+
+	if input.CompleteChildren {
+		if err := s.repo.CompleteSubtree(itemId); err != nil {
+			return err
+		}
+	}
+	if input.CompleteParent {
+		return s.repo.CompleteParents(itemId)
+	}
+	return nil
+}
+
+// checkAssignee проверяет, что исполнитель состоит в списке задачи.
+func (s *TodoItemService) checkAssignee(item entity.TodoItem, assigneeId int) error {
+	_, err := s.listRepo.GetRole(assigneeId, item.ListId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidAssignee
+	}
+	return err
+}
+
+// notifyAssignee сообщает исполнителю о назначении, если он назначил задачу не сам себе и не был назначен раньше.
+func (s *TodoItemService) notifyAssignee(userId int, item entity.TodoItem, assigneeId int) {
+	if assigneeId == userId || (item.AssigneeId != nil && *item.AssigneeId == assigneeId) {
+		return
+	}
+	s.notifications.Notify(entity.Notification{
+		UserId:  assigneeId,
+		Type:    entity.NotificationItemAssigned,
+		Title:   item.Title,
+		Body:    "The item was assigned to you",
+		ListId:  &item.ListId,
+		ItemId:  &item.Id,
+		ActorId: &userId,
+	})
+}
+
+// checkItemParent проверяет, что задачу можно сделать подзадачей parentId: родитель из того же списка
+// и не является самой задачей или одной из её подзадач, иначе получится цикл.
+func (s *TodoItemService) checkItemParent(userId, itemId, parentId int) error {
+	item, err := s.repo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	subtree, err := s.repo.GetSubtreeIds(itemId)
+	if err != nil {
+		return err
+	}
+	return s.checkParent(userId, item.ListId, parentId, subtree)
+}
+
+func (s *TodoItemService) checkParent(userId, listId, parentId int, subtree []int) error {
+	parent, err := s.repo.GetById(userId, parentId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidParent
+	}
+	if err != nil {
+		return err
+	}
+	if parent.ListId != listId {
+		return ErrInvalidParent
+	}
+	for _, id := range subtree {
+		if id == parentId {
+			return ErrInvalidParent
+		}
+	}
+	return nil
+}
+
+// normalizeDates сводит новые сроки и правило повторения с текущими значениями задачи: проверяет, что начало не позже срока
+// и у повторяющейся задачи есть срок, и для задач на весь день отбрасывает время. Признак all_day мог прийти в этом же обновлении.
+func (s *TodoItemService) normalizeDates(userId, itemId int, input *entity.UpdateItemInput) error {
+	item, err := s.repo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if input.StartAt.Set {
+		item.StartAt = input.StartAt.Time
+	}
+	if input.DueAt.Set {
+		item.DueAt = input.DueAt.Time
+	}
+	if input.AllDay != nil {
+		item.AllDay = *input.AllDay
+	}
+	if input.RRule != nil {
+		item.RRule = *input.RRule
+	}
+	if err = item.Validate(); err != nil {
+		return fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+	}
+	item.NormalizeDates()
+
+	if input.RRule != nil {
+		rule, err := entity.NormalizeRRule(*input.RRule)
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrInvalidSchedule, err)
+		}
+		input.RRule = &rule
+	}
+
+	// Смена all_day пересчитывает оба срока, даже если их не передали
+	if input.StartAt.Set || input.AllDay != nil {
+		input.StartAt = entity.NullableTime{Set: true, Time: item.StartAt}
+	}
+	if input.DueAt.Set || input.AllDay != nil {
+		input.DueAt = entity.NullableTime{Set: true, Time: item.DueAt}
+	}
+	return nil
+}
+
+// Move ставит задачу рядом с якорем и, если указан другой список, переносит её туда вместе с подзадачами.
+// Писать нужно иметь право в обоих списках. Якорь не может быть подзадачей перемещаемой задачи.
+func (s *TodoItemService) Move(userId, itemId int, move entity.MoveItemInput) error {
+	if err := move.Validate(); err != nil {
+		return err
+	}
+	if err := requireItemEditor(s.repo, userId, itemId); err != nil {
+		return err
+	}
+
+	item, err := s.repo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	listId := item.ListId
+	if move.ListId != nil && *move.ListId != item.ListId {
+		listId = *move.ListId
+		if err = requireListRole(s.listRepo, userId, listId, entity.CanEditList); err != nil {
+			return err
+		}
+	}
+
+	if move.HasAnchor() {
+		if err = s.checkAnchor(userId, itemId, listId, move.MoveInput); err != nil {
+			return err
+		}
+	}
+
+	if listId != item.ListId {
+		if err = s.repo.MoveToList(itemId, listId); err != nil {
+			return err
+		}
+	}
+	if !move.HasAnchor() {
+		return nil
+	}
+
+	err = s.repo.Move(itemId, move.MoveInput)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidAnchor
+	}
+	return err
+}
+
+func (s *TodoItemService) checkAnchor(userId, itemId, listId int, move entity.MoveInput) error {
+	anchorId, _ := move.Anchor()
+	anchor, err := s.repo.GetById(userId, anchorId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidAnchor
+	}
+	if err != nil {
+		return err
+	}
+	if anchor.ListId != listId {
+		return ErrInvalidAnchor
+	}
+
+	subtree, err := s.repo.GetSubtreeIds(itemId)
+	if err != nil {
+		return err
+	}
+	for _, id := range subtree {
+		if id == anchorId {
+			return ErrInvalidAnchor
+		}
+	}
+	return nil
+}
+
+// Copy копирует задачу с подзадачами и метками. В том же списке копия остаётся у того же родителя,
+// в другом списке становится задачей верхнего уровня. Писать нужно иметь право в обоих списках.
+func (s *TodoItemService) Copy(userId, itemId int, input entity.CopyItemInput) (int, error) {
+	if err := requireItemEditor(s.repo, userId, itemId); err != nil {
+		return 0, err
+	}
+
+	item, err := s.repo.GetById(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrItemNotFound
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	listId, parentId := item.ListId, item.ParentId
+	if input.ListId != nil && *input.ListId != item.ListId {
+		listId, parentId = *input.ListId, nil
+		if err = requireListRole(s.listRepo, userId, listId, entity.CanEditList); err != nil {
+			return 0, err
+		}
+	}
+
+	return s.repo.Copy(itemId, listId, parentId)
+}
+
+// Delete удаляет задачу вместе с подзадачами, а с keepChildren переносит подзадачи на уровень удаляемой задачи.
+func (s *TodoItemService) Delete(userId, itemId int, keepChildren bool) error {
+	if err := requireItemEditor(s.repo, userId, itemId); err != nil {
+		return err
+	}
+	return s.repo.Delete(userId, itemId, keepChildren)
+}
+
+// requireItemEditor проверяет, что пользователь может редактировать задачи списка, которому принадлежит задача.
+func requireItemEditor(repo repository.TodoItem, userId, itemId int) error {
+	role, err := repo.GetRole(userId, itemId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrItemNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !entity.CanEditList(role) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
+
+// Skip пропускает текущее повторение: задача переносится на следующее, не отмечаясь выполненной.
+func (s *TodoItemService) Skip(userId, itemId int) error {
+	if err := requireItemEditor(s.repo, userId, itemId); err != nil {
+		return err
+	}
+
+	item, err := s.GetById(userId, itemId)
+	if err != nil {
+		return err
+	}
+	if item.RRule == "" || item.DueAt == nil {
+		return ErrNotRecurring
+	}
+
+	rescheduled, err := s.reschedule(userId, item)
+	if err != nil {
+		return err
+	}
+	if !rescheduled {
+		return ErrNoMoreOccurrences
+	}
+	return nil
+}
+
+// Occurrences возвращает не больше count ближайших повторений задачи, начиная с текущего срока.
+// У выполненной задачи серия закончилась, и повторений нет.
+func (s *TodoItemService) Occurrences(userId, itemId, count int) ([]time.Time, error) {
+	item, err := s.GetById(userId, itemId)
+	if err != nil {
+		return nil, err
+	}
+	if item.RRule == "" || item.DueAt == nil {
+		return nil, ErrNotRecurring
+	}
+	if item.Done {
+		return []time.Time{}, nil
+	}
+
+	rule, err := rrule.Parse(item.RRule)
+	if err != nil {
+		return nil, err
+	}
+	start, err := s.seriesStart(userId, item)
+	if err != nil {
+		return nil, err
+	}
+
+	return rule.Take(start, count), nil
+}
+
+// reschedule переносит повторяющуюся задачу на повторение после текущего срока. Сроки задачи на весь день считаются
+// датами, остальные - в часовом поясе пользователя, чтобы время по местным часам не сдвигалось при переходе на летнее время.
+// Начало задачи сдвигается вместе со сроком. Возвращает false, если задача не повторяется или серия закончилась.
+func (s *TodoItemService) reschedule(userId int, item entity.TodoItem) (bool, error) {
+	if item.RRule == "" || item.DueAt == nil {
+		return false, nil
+	}
+
+	rule, err := rrule.Parse(item.RRule)
+	if err != nil {
+		return false, err
+	}
+	start, err := s.seriesStart(userId, item)
+	if err != nil {
+		return false, err
+	}
+
+	next, rest, ok := rule.Next(start)
+	if !ok {
+		return false, nil
+	}
+
+	occurrence := entity.Occurrence{DueAt: &next, RRule: rest.String()}
+	if item.StartAt != nil {
+		startAt := next.Add(-item.DueAt.Sub(*item.StartAt))
+		occurrence.StartAt = &startAt
+	}
+	return true, s.repo.Reschedule(item.Id, occurrence)
+}
+
+func (s *TodoItemService) seriesStart(userId int, item entity.TodoItem) (time.Time, error) {
+	if item.AllDay {
+		return item.DueAt.UTC(), nil
+	}
+	location, err := s.userLocation(userId)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return item.DueAt.In(location), nil
+}
+
+func (s *TodoItemService) userLocation(userId int) (*time.Location, error) {
+	user, err := s.userRepo.GetUserById(userId)
+	if err != nil {
+		return nil, err
+	}
+	location, err := time.LoadLocation(user.TimeZone)
+	if err != nil {
+		location = time.UTC
+	}
+	return location, nil
+}
+
+// GetDue возвращает невыполненные задачи из всех списков пользователя за период, отсчитанный в его часовом поясе.
+// Неделя считается с понедельника по воскресенье, в неё попадают задачи начиная с сегодняшнего дня.
+func (s *TodoItemService) GetDue(userId int, period string) ([]entity.TodoItem, error) {
+	location, err := s.userLocation(userId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now().In(location)
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, location)
+	today := entity.DateOf(now)
+
+	var due entity.DueRange
+	switch period {
+	case entity.DueOverdue:
+		due = entity.DueRange{To: now, ToDate: today}
+	case entity.DueToday:
+		due = entity.DueRange{From: startOfToday, To: startOfToday.AddDate(0, 0, 1), FromDate: today, ToDate: today.AddDate(0, 0, 1)}
+	case entity.DueWeek:
+		// Дней до следующего понедельника; time.Weekday отсчитывается от воскресенья
+		days := (8 - int(now.Weekday())) % 7
+		if days == 0 {
+			days = 7
+		}
+		due = entity.DueRange{From: startOfToday, To: startOfToday.AddDate(0, 0, days), FromDate: today, ToDate: today.AddDate(0, 0, days)}
+	default:
+		return nil, ErrUnknownPeriod
+	}
+
+	return s.repo.GetDue(userId, due)
 }
 
-func (s *TodoItemService) Delete(userId, itemId int) error {
-	return s.repo.Delete(userId, itemId)
+// GetAssigned возвращает задачи из всех списков, исполнителем которых назначен пользователь.
+func (s *TodoItemService) GetAssigned(userId int) ([]entity.TodoItem, error) {
+	return s.repo.GetAssigned(userId)
 }
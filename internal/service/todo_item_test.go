@@ -0,0 +1,622 @@
+package service
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoItemService_GetDue(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("нет базы часовых поясов:", err)
+	}
+
+	// Среда, 3 мая 2023, 01:30 по Москве - по UTC ещё вторник
+	now := time.Date(2023, 5, 3, 1, 30, 0, 0, moscow)
+	startOfToday := time.Date(2023, 5, 3, 0, 0, 0, 0, moscow)
+	today := time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		period string
+		want   entity.DueRange
+	}{
+		{
+			period: entity.DueOverdue,
+			want:   entity.DueRange{To: now, ToDate: today},
+		},
+		{
+			period: entity.DueToday,
+			want:   entity.DueRange{From: startOfToday, To: startOfToday.AddDate(0, 0, 1), FromDate: today, ToDate: today.AddDate(0, 0, 1)},
+		},
+		{
+			period: entity.DueWeek,
+			want:   entity.DueRange{From: startOfToday, To: startOfToday.AddDate(0, 0, 5), FromDate: today, ToDate: today.AddDate(0, 0, 5)},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.period, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			items := mock_repository.NewMockTodoItem(c)
+			users := mock_repository.NewMockAuthorization(c)
+			s := NewTodoItemService(items, mock_repository.NewMockTodoList(c), users, nil)
+			s.now = func() time.Time { return now.UTC() }
+
+			users.EXPECT().GetUserById(1).Return(entity.User{Id: 1, TimeZone: "Europe/Moscow"}, nil)
+			items.EXPECT().GetDue(1, gomock.Any()).DoAndReturn(func(userId int, due entity.DueRange) ([]entity.TodoItem, error) {
+				assert.True(t, tc.want.From.Equal(due.From), "from: %s", due.From)
+				assert.True(t, tc.want.To.Equal(due.To), "to: %s", due.To)
+				assert.Equal(t, tc.want.FromDate, due.FromDate)
+				assert.Equal(t, tc.want.ToDate, due.ToDate)
+				return nil, nil
+			})
+
+			_, err := s.GetDue(1, tc.period)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestTodoItemService_Update_Dates(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	items := mock_repository.NewMockTodoItem(c)
+	s := NewTodoItemService(items, mock_repository.NewMockTodoList(c), nil, nil)
+
+	dueAt := time.Date(2023, 5, 3, 18, 30, 0, 0, time.FixedZone("MSK", 3*60*60))
+	dueDate := time.Date(2023, 5, 3, 0, 0, 0, 0, time.UTC)
+
+	// Задача становится задачей на весь день: срок из БД приводится к дате
+	var input entity.UpdateItemInput
+	assert.NoError(t, json.Unmarshal([]byte(`{"all_day":true}`), &input))
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt}, nil)
+	items.EXPECT().Update(1, 5, gomock.Any()).DoAndReturn(func(userId, itemId int, input entity.UpdateItemInput) error {
+		assert.True(t, input.DueAt.Set)
+		assert.Equal(t, dueDate, *input.DueAt.Time)
+		assert.True(t, input.StartAt.Set)
+		assert.Nil(t, input.StartAt.Time)
+		return nil
+	})
+	assert.NoError(t, s.Update(1, 5, input))
+
+	// Явный null очищает срок
+	input = entity.UpdateItemInput{}
+	assert.NoError(t, json.Unmarshal([]byte(`{"due_at":null}`), &input))
+	assert.True(t, input.DueAt.Set)
+	assert.False(t, input.StartAt.Set)
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt}, nil)
+	items.EXPECT().Update(1, 5, gomock.Any()).DoAndReturn(func(userId, itemId int, input entity.UpdateItemInput) error {
+		assert.True(t, input.DueAt.Set)
+		assert.Nil(t, input.DueAt.Time)
+		assert.False(t, input.StartAt.Set)
+		return nil
+	})
+	assert.NoError(t, s.Update(1, 5, input))
+
+	// Начало позже срока, сохранённого в БД
+	startAt := dueAt.Add(time.Hour)
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt}, nil)
+	assert.ErrorIs(t, s.Update(1, 5, entity.UpdateItemInput{StartAt: entity.NullableTime{Set: true, Time: &startAt}}), ErrInvalidSchedule)
+
+	// Правило приводится к каноническому виду
+	rule := "rrule:freq=weekly;byday=fr"
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt}, nil)
+	items.EXPECT().Update(1, 5, gomock.Any()).DoAndReturn(func(userId, itemId int, input entity.UpdateItemInput) error {
+		assert.Equal(t, "FREQ=WEEKLY;BYDAY=FR", *input.RRule)
+		assert.False(t, input.DueAt.Set)
+		return nil
+	})
+	assert.NoError(t, s.Update(1, 5, entity.UpdateItemInput{RRule: &rule}))
+
+	// Повторяющейся задаче нужен срок
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5}, nil)
+	err := s.Update(1, 5, entity.UpdateItemInput{RRule: &rule})
+	assert.ErrorIs(t, err, ErrInvalidSchedule)
+	assert.EqualError(t, err, "invalid schedule: rrule requires due_at")
+}
+
+func TestTodoItemService_Subtasks(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	done := true
+
+	tt := []struct {
+		name         string
+		call         func(s *TodoItemService) error
+		mockBehavior func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification)
+		wantErr      error
+	}{
+		{
+			name: "Create under parent",
+			call: func(s *TodoItemService) error {
+				_, err := s.Create(1, 2, entity.TodoItem{Title: "Step", ParentId: intPtr(5)})
+				return err
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				items.EXPECT().Create(2, entity.TodoItem{Title: "Step", ParentId: intPtr(5)}).Return(6, nil)
+			},
+		},
+		{
+			name: "Create under parent from other list",
+			call: func(s *TodoItemService) error {
+				_, err := s.Create(1, 2, entity.TodoItem{Title: "Step", ParentId: intPtr(5)})
+				return err
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 3}, nil)
+			},
+			wantErr: ErrInvalidParent,
+		},
+		{
+			name: "Move under own subtask",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{ParentId: entity.NullableInt{Set: true, Value: intPtr(7)}})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleOwner, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				items.EXPECT().GetSubtreeIds(5).Return([]int{5, 6, 7}, nil)
+				items.EXPECT().GetById(1, 7).Return(entity.TodoItem{Id: 7, ListId: 2}, nil)
+			},
+			wantErr: ErrInvalidParent,
+		},
+		{
+			name: "Move to top level",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{ParentId: entity.NullableInt{Set: true}})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleOwner, nil)
+				items.EXPECT().Update(1, 5, entity.UpdateItemInput{ParentId: entity.NullableInt{Set: true}}).Return(nil)
+			},
+		},
+		{
+			name: "Complete with roll-up",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{Done: &done, CompleteChildren: true, CompleteParent: true})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip"}, nil)
+				items.EXPECT().Update(1, 5, gomock.Any()).Return(nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip", Done: true}, nil)
+				notifications.EXPECT().CreateForMembers(2, completedNotification(5, 2, "Trip", 1)).Return(nil)
+				items.EXPECT().CompleteSubtree(5).Return(nil)
+				items.EXPECT().CompleteParents(5).Return(nil)
+			},
+		},
+		{
+			name: "Complete without roll-up",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{Done: &done})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip"}, nil)
+				items.EXPECT().Update(1, 5, gomock.Any()).Return(nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip", Done: true}, nil)
+				notifications.EXPECT().CreateForMembers(2, completedNotification(5, 2, "Trip", 1)).Return(nil)
+			},
+		},
+		{
+			name: "Update already completed",
+			call: func(s *TodoItemService) error {
+				title := "Trip to Rome"
+				return s.Update(1, 5, entity.UpdateItemInput{Title: &title, Done: &done})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip", Done: true}, nil)
+				items.EXPECT().Update(1, 5, gomock.Any()).Return(nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip to Rome", Done: true}, nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			items := mock_repository.NewMockTodoItem(c)
+			lists := mock_repository.NewMockTodoList(c)
+			notifications := mock_repository.NewMockNotification(c)
+			tc.mockBehavior(items, lists, notifications)
+
+			s := NewTodoItemService(items, lists, mock_repository.NewMockAuthorization(c), NewNotificationService(notifications))
+			assert.ErrorIs(t, tc.call(s), tc.wantErr)
+		})
+	}
+}
+
+func completedNotification(itemId, listId int, title string, actorId int) entity.Notification {
+	return entity.Notification{
+		Type:    entity.NotificationItemCompleted,
+		Title:   title,
+		Body:    "The item was completed",
+		ListId:  &listId,
+		ItemId:  &itemId,
+		ActorId: &actorId,
+	}
+}
+
+func TestTodoItemService_Assignee(t *testing.T) {
+	intPtr := func(i int) *int { return &i }
+	assign := func(assigneeId *int) entity.UpdateItemInput {
+		return entity.UpdateItemInput{AssigneeId: entity.NullableInt{Set: true, Value: assigneeId}}
+	}
+
+	tt := []struct {
+		name         string
+		input        entity.UpdateItemInput
+		mockBehavior func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification)
+		wantErr      error
+	}{
+		{
+			name:  "Assign member",
+			input: assign(intPtr(3)),
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip"}, nil)
+				lists.EXPECT().GetRole(3, 2).Return(entity.ListRoleViewer, nil)
+				items.EXPECT().Update(1, 5, assign(intPtr(3))).Return(nil)
+				notifications.EXPECT().Create(entity.Notification{
+					UserId:  3,
+					Type:    entity.NotificationItemAssigned,
+					Title:   "Trip",
+					Body:    "The item was assigned to you",
+					ListId:  intPtr(2),
+					ItemId:  intPtr(5),
+					ActorId: intPtr(1),
+				}).Return(1, nil)
+			},
+		},
+		{
+			name:  "Assign self",
+			input: assign(intPtr(1)),
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip"}, nil)
+				lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().Update(1, 5, assign(intPtr(1))).Return(nil)
+			},
+		},
+		{
+			name:  "Already assigned",
+			input: assign(intPtr(3)),
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip", AssigneeId: intPtr(3)}, nil)
+				lists.EXPECT().GetRole(3, 2).Return(entity.ListRoleViewer, nil)
+				items.EXPECT().Update(1, 5, assign(intPtr(3))).Return(nil)
+			},
+		},
+		{
+			name:  "Not a member",
+			input: assign(intPtr(3)),
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, Title: "Trip"}, nil)
+				lists.EXPECT().GetRole(3, 2).Return("", sql.ErrNoRows)
+			},
+			wantErr: ErrInvalidAssignee,
+		},
+		{
+			name:  "Unassign",
+			input: assign(nil),
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().Update(1, 5, assign(nil)).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			items := mock_repository.NewMockTodoItem(c)
+			lists := mock_repository.NewMockTodoList(c)
+			notifications := mock_repository.NewMockNotification(c)
+			tc.mockBehavior(items, lists, notifications)
+
+			s := NewTodoItemService(items, lists, mock_repository.NewMockAuthorization(c), NewNotificationService(notifications))
+			assert.ErrorIs(t, s.Update(1, 5, tc.input), tc.wantErr)
+		})
+	}
+}
+
+func TestTodoItemService_Move(t *testing.T) {
+	anchorId := 7
+	otherListId := 3
+
+	tt := []struct {
+		name         string
+		move         entity.MoveItemInput
+		mockBehavior func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList)
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			move: entity.MoveItemInput{MoveInput: entity.MoveInput{AfterId: &anchorId}},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				items.EXPECT().GetById(1, 7).Return(entity.TodoItem{Id: 7, ListId: 2}, nil)
+				items.EXPECT().GetSubtreeIds(5).Return([]int{5, 6}, nil)
+				items.EXPECT().Move(5, entity.MoveInput{AfterId: &anchorId}).Return(nil)
+			},
+		},
+		{
+			name: "Anchor in other list",
+			move: entity.MoveItemInput{MoveInput: entity.MoveInput{AfterId: &anchorId}},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				items.EXPECT().GetById(1, 7).Return(entity.TodoItem{Id: 7, ListId: 3}, nil)
+			},
+			wantErr: ErrInvalidAnchor,
+		},
+		{
+			name: "Anchor is own subtask",
+			move: entity.MoveItemInput{MoveInput: entity.MoveInput{AfterId: &anchorId}},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				items.EXPECT().GetById(1, 7).Return(entity.TodoItem{Id: 7, ListId: 2}, nil)
+				items.EXPECT().GetSubtreeIds(5).Return([]int{5, 7}, nil)
+			},
+			wantErr: ErrInvalidAnchor,
+		},
+		{
+			name: "Viewer",
+			move: entity.MoveItemInput{MoveInput: entity.MoveInput{AfterId: &anchorId}},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleViewer, nil)
+			},
+			wantErr: ErrInsufficientRole,
+		},
+		{
+			name: "To other list",
+			move: entity.MoveItemInput{ListId: &otherListId},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				lists.EXPECT().GetRole(1, 3).Return(entity.ListRoleOwner, nil)
+				items.EXPECT().MoveToList(5, 3).Return(nil)
+			},
+		},
+		{
+			name: "To other list next to anchor",
+			move: entity.MoveItemInput{ListId: &otherListId, MoveInput: entity.MoveInput{AfterId: &anchorId}},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				lists.EXPECT().GetRole(1, 3).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 7).Return(entity.TodoItem{Id: 7, ListId: 3}, nil)
+				items.EXPECT().GetSubtreeIds(5).Return([]int{5}, nil)
+				items.EXPECT().MoveToList(5, 3).Return(nil)
+				items.EXPECT().Move(5, entity.MoveInput{AfterId: &anchorId}).Return(nil)
+			},
+		},
+		{
+			name: "To read-only list",
+			move: entity.MoveItemInput{ListId: &otherListId},
+			mockBehavior: func(items *mock_repository.MockTodoItem, lists *mock_repository.MockTodoList) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2}, nil)
+				lists.EXPECT().GetRole(1, 3).Return(entity.ListRoleViewer, nil)
+			},
+			wantErr: ErrInsufficientRole,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			items := mock_repository.NewMockTodoItem(c)
+			lists := mock_repository.NewMockTodoList(c)
+			tc.mockBehavior(items, lists)
+
+			s := NewTodoItemService(items, lists, mock_repository.NewMockAuthorization(c), nil)
+			assert.ErrorIs(t, s.Move(1, 5, tc.move), tc.wantErr)
+		})
+	}
+}
+
+func TestTodoItemService_Copy(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	items := mock_repository.NewMockTodoItem(c)
+	lists := mock_repository.NewMockTodoList(c)
+	s := NewTodoItemService(items, lists, mock_repository.NewMockAuthorization(c), nil)
+
+	parentId := 4
+	items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil).Times(2)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, ListId: 2, ParentId: &parentId}, nil).Times(2)
+
+	// В том же списке копия остаётся подзадачей того же родителя
+	items.EXPECT().Copy(5, 2, &parentId).Return(8, nil)
+	id, err := s.Copy(1, 5, entity.CopyItemInput{})
+	assert.NoError(t, err)
+	assert.Equal(t, 8, id)
+
+	otherListId := 3
+	lists.EXPECT().GetRole(1, 3).Return(entity.ListRoleEditor, nil)
+	items.EXPECT().Copy(5, 3, nil).Return(9, nil)
+	id, err = s.Copy(1, 5, entity.CopyItemInput{ListId: &otherListId})
+	assert.NoError(t, err)
+	assert.Equal(t, 9, id)
+}
+
+func TestTodoItemService_Recurrence(t *testing.T) {
+	moscow, err := time.LoadLocation("Europe/Moscow")
+	if err != nil {
+		t.Skip("нет базы часовых поясов:", err)
+	}
+
+	done := true
+	// Пятница, 09:00 по Москве
+	dueAt := time.Date(2023, 5, 5, 6, 0, 0, 0, time.UTC)
+	startAt := dueAt.Add(-2 * time.Hour)
+	dueDate := time.Date(2023, 1, 31, 0, 0, 0, 0, time.UTC)
+	nextDueDate := time.Date(2023, 2, 28, 0, 0, 0, 0, time.UTC)
+
+	tt := []struct {
+		name         string
+		call         func(s *TodoItemService) error
+		mockBehavior func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification)
+		wantErr      error
+	}{
+		{
+			name: "Complete rolls forward",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{Done: &done, CompleteParent: true})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5}, nil)
+				items.EXPECT().Update(1, 5, gomock.Any()).Return(nil)
+				notifications.EXPECT().CreateForMembers(0, gomock.Any()).Return(nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, Done: true, StartAt: &startAt, DueAt: &dueAt,
+					RRule: "FREQ=WEEKLY;BYDAY=MO,FR;COUNT=3"}, nil)
+				users.EXPECT().GetUserById(1).Return(entity.User{Id: 1, TimeZone: "Europe/Moscow"}, nil)
+				items.EXPECT().Reschedule(5, gomock.Any()).DoAndReturn(func(itemId int, occurrence entity.Occurrence) error {
+					assert.Equal(t, time.Date(2023, 5, 8, 9, 0, 0, 0, moscow), *occurrence.DueAt)
+					assert.Equal(t, time.Date(2023, 5, 8, 7, 0, 0, 0, moscow), *occurrence.StartAt)
+					assert.Equal(t, "FREQ=WEEKLY;COUNT=2;BYDAY=MO,FR", occurrence.RRule)
+					return nil
+				})
+			},
+		},
+		{
+			name: "All-day item rolls by date",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{Done: &done})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5}, nil)
+				items.EXPECT().Update(1, 5, gomock.Any()).Return(nil)
+				notifications.EXPECT().CreateForMembers(0, gomock.Any()).Return(nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, Done: true, DueAt: &dueDate, AllDay: true,
+					RRule: "FREQ=MONTHLY;BYMONTHDAY=-1"}, nil)
+				items.EXPECT().Reschedule(5, entity.Occurrence{
+					DueAt: &nextDueDate,
+					RRule: "FREQ=MONTHLY;BYMONTHDAY=-1",
+				}).Return(nil)
+			},
+		},
+		{
+			name: "Series is over",
+			call: func(s *TodoItemService) error {
+				return s.Update(1, 5, entity.UpdateItemInput{Done: &done, CompleteParent: true})
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5}, nil)
+				items.EXPECT().Update(1, 5, gomock.Any()).Return(nil)
+				notifications.EXPECT().CreateForMembers(0, gomock.Any()).Return(nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, Done: true, DueAt: &dueAt, RRule: "FREQ=DAILY;COUNT=1"}, nil)
+				users.EXPECT().GetUserById(1).Return(entity.User{Id: 1, TimeZone: "Europe/Moscow"}, nil)
+				items.EXPECT().CompleteParents(5).Return(nil)
+			},
+		},
+		{
+			name: "Skip",
+			call: func(s *TodoItemService) error {
+				return s.Skip(1, 5)
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt, RRule: "FREQ=DAILY;INTERVAL=2"}, nil)
+				users.EXPECT().GetUserById(1).Return(entity.User{Id: 1, TimeZone: "Europe/Moscow"}, nil)
+				items.EXPECT().Reschedule(5, gomock.Any()).DoAndReturn(func(itemId int, occurrence entity.Occurrence) error {
+					assert.Equal(t, time.Date(2023, 5, 7, 9, 0, 0, 0, moscow), *occurrence.DueAt)
+					assert.Nil(t, occurrence.StartAt)
+					return nil
+				})
+			},
+		},
+		{
+			name: "Skip last occurrence",
+			call: func(s *TodoItemService) error {
+				return s.Skip(1, 5)
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt, RRule: "FREQ=DAILY;UNTIL=20230505T235959Z"}, nil)
+				users.EXPECT().GetUserById(1).Return(entity.User{Id: 1, TimeZone: "Europe/Moscow"}, nil)
+			},
+			wantErr: ErrNoMoreOccurrences,
+		},
+		{
+			name: "Skip not recurring",
+			call: func(s *TodoItemService) error {
+				return s.Skip(1, 5)
+			},
+			mockBehavior: func(items *mock_repository.MockTodoItem, users *mock_repository.MockAuthorization, notifications *mock_repository.MockNotification) {
+				items.EXPECT().GetRole(1, 5).Return(entity.ListRoleEditor, nil)
+				items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt}, nil)
+			},
+			wantErr: ErrNotRecurring,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			items := mock_repository.NewMockTodoItem(c)
+			users := mock_repository.NewMockAuthorization(c)
+			notifications := mock_repository.NewMockNotification(c)
+			tc.mockBehavior(items, users, notifications)
+
+			s := NewTodoItemService(items, mock_repository.NewMockTodoList(c), users, NewNotificationService(notifications))
+			assert.ErrorIs(t, tc.call(s), tc.wantErr)
+		})
+	}
+}
+
+func TestTodoItemService_Occurrences(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	items := mock_repository.NewMockTodoItem(c)
+	users := mock_repository.NewMockAuthorization(c)
+	s := NewTodoItemService(items, mock_repository.NewMockTodoList(c), users, nil)
+
+	dueAt := time.Date(2023, 5, 1, 9, 0, 0, 0, time.UTC)
+	items.EXPECT().GetById(1, 5).Return(entity.TodoItem{Id: 5, DueAt: &dueAt, RRule: "FREQ=MONTHLY;BYDAY=1MO"}, nil)
+	users.EXPECT().GetUserById(1).Return(entity.User{Id: 1, TimeZone: "UTC"}, nil)
+
+	occurrences, err := s.Occurrences(1, 5, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Time{
+		dueAt,
+		time.Date(2023, 6, 5, 9, 0, 0, 0, time.UTC),
+		time.Date(2023, 7, 3, 9, 0, 0, 0, time.UTC),
+	}, occurrences)
+}
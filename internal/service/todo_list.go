@@ -1,16 +1,29 @@
 package service
 
 import (
+	"database/sql"
+	"errors"
+	"fmt"
 	"github.com/IncubusX/go-todo-app/internal/entity"
 	"github.com/IncubusX/go-todo-app/internal/repository"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	ErrListNotFound     = errors.New("list not found")
+	ErrInsufficientRole = errors.New("insufficient role in list")
+	ErrInvalidAnchor    = errors.New("invalid move anchor")
+	ErrTitleTooLong     = fmt.Errorf("title must be at most %d characters", entity.MaxTitleLength)
 )
 
 type TodoListService struct {
-	repo repository.TodoList
+	repo          repository.TodoList
+	notifications notifier
 }
 
-func NewTodoListService(repo repository.TodoList) *TodoListService {
-	return &TodoListService{repo: repo}
+func NewTodoListService(repo repository.TodoList, notifications notifier) *TodoListService {
+	return &TodoListService{repo: repo, notifications: notifications}
 }
 
 func (s *TodoListService) Create(userId int, input entity.TodoList) (int, error) {
@@ -22,16 +35,106 @@ func (s *TodoListService) GetAll(userId int) ([]entity.TodoList, error) {
 }
 
 func (s *TodoListService) GetById(userId, listId int) (entity.TodoList, error) {
-	return s.repo.GetById(userId, listId)
+	list, err := s.repo.GetById(userId, listId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return list, ErrListNotFound
+	}
+	return list, err
 }
 
 func (s *TodoListService) Update(userId, listId int, input entity.UpdateListInput) error {
 	if err := input.Validate(); err != nil {
 		return err
 	}
+	if err := requireListRole(s.repo, userId, listId, entity.CanEditList); err != nil {
+		return err
+	}
 	return s.repo.Update(userId, listId, input)
 }
 
+// Delete удаляет список у всех участников, поэтому остальные участники получают уведомление.
 func (s *TodoListService) Delete(userId, listId int) error {
+	if err := requireListRole(s.repo, userId, listId, isListOwner); err != nil {
+		return err
+	}
+
+	list, err := s.GetById(userId, listId)
+	if err != nil {
+		return err
+	}
+	s.notifications.NotifyMembers(listId, entity.Notification{
+		Type:    entity.NotificationListDeleted,
+		Title:   list.Title,
+		Body:    "The list was deleted",
+		ActorId: &userId,
+	})
+
 	return s.repo.Delete(userId, listId)
 }
+
+// Move меняет порядок списков только в боковой панели самого пользователя, поэтому подходит любая роль.
+func (s *TodoListService) Move(userId, listId int, move entity.MoveInput) error {
+	if err := move.Validate(); err != nil {
+		return err
+	}
+	if err := requireListRole(s.repo, userId, listId, entity.ValidListRole); err != nil {
+		return err
+	}
+	if anchorId, _ := move.Anchor(); anchorId == listId {
+		return ErrInvalidAnchor
+	}
+
+	err := s.repo.Move(userId, listId, move)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidAnchor
+	}
+	return err
+}
+
+// Duplicate копирует список со всеми задачами. Копия принадлежит пользователю, поэтому подходит любая роль.
+func (s *TodoListService) Duplicate(userId, listId int, input entity.DuplicateListInput) (int, error) {
+	title := strings.TrimSpace(input.Title)
+	if err := checkTitleLength(title); err != nil {
+		return 0, err
+	}
+	if err := requireListRole(s.repo, userId, listId, entity.ValidListRole); err != nil {
+		return 0, err
+	}
+
+	if title == "" {
+		list, err := s.GetById(userId, listId)
+		if err != nil {
+			return 0, err
+		}
+		title = entity.CopyTitle(list.Title)
+	}
+	return s.repo.Duplicate(userId, listId, title)
+}
+
+// checkTitleLength проверяет, что название помещается в колонку title.
+func checkTitleLength(title string) error {
+	if utf8.RuneCountInString(title) > entity.MaxTitleLength {
+		return ErrTitleTooLong
+	}
+	return nil
+}
+
+func isListOwner(role string) bool {
+	return role == entity.ListRoleOwner
+}
+
+// requireListRole проверяет, что пользователь участвует в списке с подходящей ролью.
+// Для посторонних список как будто не существует, участнику без прав возвращается ErrInsufficientRole.
+func requireListRole(repo repository.TodoList, userId, listId int, allowed func(role string) bool) error {
+	role, err := repo.GetRole(userId, listId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrListNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if !allowed(role) {
+		return ErrInsufficientRole
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package service
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTodoListService_Duplicate(t *testing.T) {
+	longTitle := strings.Repeat("я", 250)
+
+	tt := []struct {
+		name         string
+		input        entity.DuplicateListInput
+		mockBehavior func(lists *mock_repository.MockTodoList)
+		wantErr      error
+	}{
+		{
+			name:  "Title",
+			input: entity.DuplicateListInput{Title: " Trip 2024 "},
+			mockBehavior: func(lists *mock_repository.MockTodoList) {
+				lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleViewer, nil)
+				lists.EXPECT().Duplicate(1, 2, "Trip 2024").Return(5, nil)
+			},
+		},
+		{
+			name: "Copy of long title",
+			mockBehavior: func(lists *mock_repository.MockTodoList) {
+				lists.EXPECT().GetRole(1, 2).Return(entity.ListRoleViewer, nil)
+				lists.EXPECT().GetById(1, 2).Return(entity.TodoList{Id: 2, Title: longTitle}, nil)
+				lists.EXPECT().Duplicate(1, 2, gomock.Any()).DoAndReturn(func(userId, listId int, title string) (int, error) {
+					assert.Equal(t, strings.Repeat("я", 248)+" (copy)", title)
+					assert.Equal(t, entity.MaxTitleLength, utf8.RuneCountInString(title))
+					return 5, nil
+				})
+			},
+		},
+		{
+			name:         "Title too long",
+			input:        entity.DuplicateListInput{Title: longTitle + "abcdef"},
+			mockBehavior: func(lists *mock_repository.MockTodoList) {},
+			wantErr:      ErrTitleTooLong,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			lists := mock_repository.NewMockTodoList(c)
+			tc.mockBehavior(lists)
+
+			s := NewTodoListService(lists, nil)
+			_, err := s.Duplicate(1, 2, tc.input)
+			assert.ErrorIs(t, err, tc.wantErr)
+		})
+	}
+}
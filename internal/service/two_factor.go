@@ -0,0 +1,172 @@
+package service
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/base32"
+	"errors"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/IncubusX/go-todo-app/internal/totp"
+	"strings"
+	"time"
+)
+
+const (
+	recoveryCodesCount  = 10
+	recoveryCodeLength  = 10
+	twoFactorIssuerName = "Todo App"
+)
+
+var (
+	ErrTwoFactorAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnrolled    = errors.New("two-factor authentication is not enrolled")
+	ErrInvalidTwoFactorCode    = errors.New("invalid two-factor code")
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+type TwoFactorService struct {
+	repo     repository.TwoFactor
+	authRepo repository.Authorization
+	now      func() time.Time
+}
+
+func NewTwoFactorService(repo repository.TwoFactor, authRepo repository.Authorization) *TwoFactorService {
+	return &TwoFactorService{repo: repo, authRepo: authRepo, now: time.Now}
+}
+
+// Enroll создаёт новый секрет, который вступит в силу после подтверждения первым кодом.
+func (s *TwoFactorService) Enroll(userId int) (entity.TwoFactorEnrollment, error) {
+	user, err := s.authRepo.GetUserById(userId)
+	if err != nil {
+		return entity.TwoFactorEnrollment{}, err
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return entity.TwoFactorEnrollment{}, err
+	}
+
+	err = s.repo.SetPending(userId, secret)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.TwoFactorEnrollment{}, ErrTwoFactorAlreadyEnabled
+	}
+	if err != nil {
+		return entity.TwoFactorEnrollment{}, err
+	}
+
+	return entity.TwoFactorEnrollment{
+		Secret: secret,
+		URI:    totp.URI(twoFactorIssuerName, user.Username, secret),
+	}, nil
+}
+
+// Confirm включает второй фактор и возвращает одноразовые резервные коды. Коды показываются только один раз.
+func (s *TwoFactorService) Confirm(userId int, code string) ([]string, error) {
+	tf, err := s.repo.Get(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+	if tf.Enabled {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.verifyTOTP(tf, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Enable(userId, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+func (s *TwoFactorService) Disable(userId int, code string) error {
+	if err := s.Verify(userId, code); err != nil {
+		return err
+	}
+
+	return s.repo.Disable(userId)
+}
+
+func (s *TwoFactorService) Enabled(userId int) (bool, error) {
+	tf, err := s.repo.Get(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return tf.Enabled, nil
+}
+
+// Verify принимает код из приложения-аутентификатора или один из резервных кодов.
+func (s *TwoFactorService) Verify(userId int, code string) error {
+	tf, err := s.repo.Get(userId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrTwoFactorNotEnrolled
+	}
+	if err != nil {
+		return err
+	}
+	if !tf.Enabled {
+		return ErrTwoFactorNotEnrolled
+	}
+
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(tf, code)
+	}
+
+	err = s.repo.UseRecoveryCode(userId, hashToken(normalizeRecoveryCode(code)))
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidTwoFactorCode
+	}
+	return err
+}
+
+func (s *TwoFactorService) verifyTOTP(tf entity.TwoFactor, code string) error {
+	step, ok := totp.Validate(tf.Secret, code, s.now())
+	if !ok {
+		return ErrInvalidTwoFactorCode
+	}
+
+	err := s.repo.UseStep(tf.UserId, step)
+	if errors.Is(err, sql.ErrNoRows) {
+		return ErrInvalidTwoFactorCode
+	}
+	return err
+}
+
+func newRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodesCount)
+	hashes := make([]string, 0, recoveryCodesCount)
+
+	for i := 0; i < recoveryCodesCount; i++ {
+		b := make([]byte, recoveryCodeLength)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:recoveryCodeLength]
+
+		codes = append(codes, raw[:recoveryCodeLength/2]+"-"+raw[recoveryCodeLength/2:])
+		hashes = append(hashes, hashToken(raw))
+	}
+
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,210 @@
+package service
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/hash"
+	"github.com/IncubusX/go-todo-app/internal/keyring"
+	"github.com/IncubusX/go-todo-app/internal/repository/memory"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/IncubusX/go-todo-app/internal/totp"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func newTestTwoFactorService(c *gomock.Controller, now time.Time) (*TwoFactorService, *mock_repository.MockTwoFactor) {
+	repo := mock_repository.NewMockTwoFactor(c)
+	s := NewTwoFactorService(repo, mock_repository.NewMockAuthorization(c))
+	s.now = func() time.Time { return now }
+	return s, repo
+}
+
+func TestTwoFactorService_Confirm(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	code, _ := totp.Code(testSecret, totp.Step(now))
+
+	tt := []struct {
+		name         string
+		code         string
+		mockBehavior func(r *mock_repository.MockTwoFactor)
+		wantErr      error
+	}{
+		{
+			name: "Ok",
+			code: code,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(entity.TwoFactor{UserId: 1, Secret: testSecret}, nil)
+				r.EXPECT().UseStep(1, totp.Step(now)).Return(nil)
+				r.EXPECT().Enable(1, gomock.Len(recoveryCodesCount)).Return(nil)
+			},
+		},
+		{
+			name: "Wrong code",
+			code: "000000",
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(entity.TwoFactor{UserId: 1, Secret: testSecret}, nil)
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "Not enrolled",
+			code: code,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(entity.TwoFactor{}, sql.ErrNoRows)
+			},
+			wantErr: ErrTwoFactorNotEnrolled,
+		},
+		{
+			name: "Already enabled",
+			code: code,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(entity.TwoFactor{UserId: 1, Secret: testSecret, Enabled: true}, nil)
+			},
+			wantErr: ErrTwoFactorAlreadyEnabled,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, repo := newTestTwoFactorService(c, now)
+			tc.mockBehavior(repo)
+
+			codes, err := s.Confirm(1, tc.code)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, codes, recoveryCodesCount)
+		})
+	}
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	code, _ := totp.Code(testSecret, totp.Step(now))
+	enabled := entity.TwoFactor{UserId: 1, Secret: testSecret, Enabled: true}
+
+	tt := []struct {
+		name         string
+		code         string
+		at           time.Time
+		mockBehavior func(r *mock_repository.MockTwoFactor)
+		wantErr      error
+	}{
+		{
+			name: "TOTP code",
+			code: code,
+			at:   now,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(enabled, nil)
+				r.EXPECT().UseStep(1, totp.Step(now)).Return(nil)
+			},
+		},
+		{
+			name: "Code from previous step within skew",
+			code: code,
+			at:   now.Add(totp.Period),
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(enabled, nil)
+				r.EXPECT().UseStep(1, totp.Step(now)).Return(nil)
+			},
+		},
+		{
+			name: "Expired code",
+			code: code,
+			at:   now.Add(5 * totp.Period),
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(enabled, nil)
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "Replayed code",
+			code: code,
+			at:   now,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(enabled, nil)
+				r.EXPECT().UseStep(1, totp.Step(now)).Return(sql.ErrNoRows)
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+		{
+			name: "Recovery code",
+			code: "ABCDE-fghij",
+			at:   now,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(enabled, nil)
+				r.EXPECT().UseRecoveryCode(1, hashToken("abcdefghij")).Return(nil)
+			},
+		},
+		{
+			name: "Used recovery code",
+			code: "abcde-fghij",
+			at:   now,
+			mockBehavior: func(r *mock_repository.MockTwoFactor) {
+				r.EXPECT().Get(1).Return(enabled, nil)
+				r.EXPECT().UseRecoveryCode(1, hashToken("abcdefghij")).Return(sql.ErrNoRows)
+			},
+			wantErr: ErrInvalidTwoFactorCode,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := gomock.NewController(t)
+			defer c.Finish()
+
+			s, repo := newTestTwoFactorService(c, tc.at)
+			tc.mockBehavior(repo)
+
+			err := s.Verify(1, tc.code)
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestAuthService_SignInTwoFactorLockout(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	now := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	twoFactor, repo := newTestTwoFactorService(c, now)
+	audit := mock_repository.NewMockAudit(c)
+	throttle := NewLoginThrottle(memory.NewLoginAttempt(), audit, testLockoutPolicy)
+	keys, _ := keyring.NewKeyRing("test", keyring.NewHMACKey("test", []byte("secret")))
+	s := NewAuthService(mock_repository.NewMockAuthorization(c), mock_repository.NewMockSession(c),
+		mock_repository.NewMockPersonalAccessToken(c), twoFactor, nil, throttle,
+		hash.NewArgon2idHasher(hash.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32}, ""),
+		keys, 0, 0)
+
+	challenge, err := s.newChallengeToken(1)
+	assert.NoError(t, err)
+	client := entity.Client{IP: "10.0.0.1"}
+
+	repo.EXPECT().Get(1).Return(entity.TwoFactor{UserId: 1, Secret: testSecret, Enabled: true}, nil).Times(testLockoutPolicy.Threshold)
+	repo.EXPECT().UseRecoveryCode(1, gomock.Any()).Return(sql.ErrNoRows).Times(testLockoutPolicy.Threshold)
+	audit.EXPECT().Create(gomock.Any()).Return(nil)
+
+	for i := 0; i < testLockoutPolicy.Threshold; i++ {
+		_, err := s.SignInTwoFactor(challenge, "wrong-recovery", client)
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	}
+
+	// После серии ошибок код даже не проверяется, в том числе с другого адреса
+	code, _ := totp.Code(testSecret, totp.Step(now))
+	_, err = s.SignInTwoFactor(challenge, code, entity.Client{IP: "10.0.0.2"})
+	assert.ErrorIs(t, err, ErrTooManyAttempts)
+}
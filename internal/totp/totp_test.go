@@ -0,0 +1,74 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Тестовые векторы RFC 6238, приложение B (SHA-1, 8 цифр).
+func TestHOTP_RFC6238Vectors(t *testing.T) {
+	key := []byte("12345678901234567890")
+
+	tt := []struct {
+		unix int64
+		code string
+	}{
+		{59, "94287082"},
+		{1111111109, "07081804"},
+		{1111111111, "14050471"},
+		{1234567890, "89005924"},
+		{2000000000, "69279037"},
+		{20000000000, "65353130"},
+	}
+
+	for _, tc := range tt {
+		step := Step(time.Unix(tc.unix, 0))
+		assert.Equal(t, tc.code, hotp(key, uint64(step), 8))
+	}
+}
+
+func TestValidate(t *testing.T) {
+	secret := base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+	now := time.Unix(1111111111, 0)
+
+	code, err := Code(secret, Step(now))
+	assert.NoError(t, err)
+
+	step, ok := Validate(secret, code, now)
+	assert.True(t, ok)
+	assert.Equal(t, Step(now), step)
+
+	// Код предыдущего шага принимается за счёт допуска на расхождение часов.
+	_, ok = Validate(secret, code, now.Add(Period))
+	assert.True(t, ok)
+
+	_, ok = Validate(secret, code, now.Add(3*Period))
+	assert.False(t, ok)
+
+	_, ok = Validate(secret, "000000", now)
+	assert.False(t, ok)
+
+	_, ok = Validate("not base32!", code, now)
+	assert.False(t, ok)
+}
+
+func TestURI(t *testing.T) {
+	uri := URI("Todo App", "user@example.com", "JBSWY3DPEHPK3PXP")
+
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Todo%20App:user@example.com?"))
+	assert.Contains(t, uri, "secret=JBSWY3DPEHPK3PXP")
+	assert.Contains(t, uri, "issuer=Todo+App")
+}
+
+func TestGenerateSecret(t *testing.T) {
+	secret, err := GenerateSecret()
+	assert.NoError(t, err)
+	assert.Len(t, secret, 32)
+
+	_, err = Code(secret, 1)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,114 @@
+package worker
+
+import (
+	"context"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/IncubusX/go-todo-app/internal/storage"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+type BlobCollectorConfig struct {
+	// Interval - как часто проверять, не осталось ли файлов от удалённых вложений
+	Interval time.Duration
+	// BatchSize - сколько ключей выбирать за один запрос
+	BatchSize int
+	// Timeout - время на удаление одного файла
+	Timeout time.Duration
+}
+
+// BlobCollector удаляет из хранилища файлы вложений, метаданные которых уже удалены вместе с задачами и списками.
+// Удаление файла повторяемо, поэтому несколько экземпляров приложения могут работать одновременно.
+type BlobCollector struct {
+	repo  repository.Attachment
+	blobs storage.BlobStore
+	cfg   BlobCollectorConfig
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewBlobCollector(repo repository.Attachment, blobs storage.BlobStore, cfg BlobCollectorConfig) *BlobCollector {
+	return &BlobCollector{
+		repo:  repo,
+		blobs: blobs,
+		cfg:   cfg,
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+}
+
+// Run удаляет файлы до вызова Shutdown.
+func (w *BlobCollector) Run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.collect()
+
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown перестаёт брать новые ключи и ждёт, пока удаление текущего файла завершится.
+func (w *BlobCollector) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// collect удаляет файлы пачками. Если файл удалить не удалось, его ключ остаётся в очереди
+// и обработка откладывается до следующей проверки, чтобы не повторять ошибку в цикле.
+func (w *BlobCollector) collect() {
+	for {
+		keys, err := w.repo.GetOrphanBlobs(w.cfg.BatchSize)
+		if err != nil {
+			logrus.Errorf("Ошибка при выборке удалённых вложений: %s", err.Error())
+			return
+		}
+
+		for _, key := range keys {
+			select {
+			case <-w.stop:
+				return
+			default:
+			}
+
+			if !w.delete(key) {
+				return
+			}
+		}
+
+		if len(keys) < w.cfg.BatchSize {
+			return
+		}
+	}
+}
+
+func (w *BlobCollector) delete(key string) bool {
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.Timeout)
+	defer cancel()
+
+	if err := w.blobs.Delete(ctx, key); err != nil {
+		logrus.Errorf("Ошибка при удалении файла %s: %s", key, err.Error())
+		return false
+	}
+	if err := w.repo.DeleteOrphanBlob(key); err != nil {
+		logrus.Errorf("Ошибка при удалении ключа файла %s из очереди: %s", key, err.Error())
+		return false
+	}
+	return true
+}
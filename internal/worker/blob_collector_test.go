@@ -0,0 +1,89 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+// deleteOnlyStore - хранилище, в котором сборщику нужен только Delete.
+type deleteOnlyStore func(ctx context.Context, key string) error
+
+func (f deleteOnlyStore) Put(context.Context, string, io.Reader, int64, string) error {
+	return errors.New("not implemented")
+}
+
+func (f deleteOnlyStore) Open(context.Context, string) (io.ReadSeekCloser, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (f deleteOnlyStore) Delete(ctx context.Context, key string) error {
+	return f(ctx, key)
+}
+
+func TestBlobCollector_collect(t *testing.T) {
+	cfg := BlobCollectorConfig{Interval: time.Hour, BatchSize: 2, Timeout: time.Second}
+
+	t.Run("Batches", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		repo := mock_repository.NewMockAttachment(c)
+		var deleted []string
+		w := NewBlobCollector(repo, deleteOnlyStore(func(ctx context.Context, key string) error {
+			_, hasDeadline := ctx.Deadline()
+			assert.True(t, hasDeadline)
+			deleted = append(deleted, key)
+			return nil
+		}), cfg)
+
+		gomock.InOrder(
+			repo.EXPECT().GetOrphanBlobs(2).Return([]string{"items/1/a", "items/1/b"}, nil),
+			repo.EXPECT().DeleteOrphanBlob("items/1/a").Return(nil),
+			repo.EXPECT().DeleteOrphanBlob("items/1/b").Return(nil),
+			repo.EXPECT().GetOrphanBlobs(2).Return([]string{"items/2/c"}, nil),
+			repo.EXPECT().DeleteOrphanBlob("items/2/c").Return(nil),
+		)
+
+		w.collect()
+		assert.Equal(t, []string{"items/1/a", "items/1/b", "items/2/c"}, deleted)
+	})
+
+	t.Run("Store failure postpones", func(t *testing.T) {
+		c := gomock.NewController(t)
+		defer c.Finish()
+
+		repo := mock_repository.NewMockAttachment(c)
+		w := NewBlobCollector(repo, deleteOnlyStore(func(ctx context.Context, key string) error {
+			return errors.New("connection refused")
+		}), cfg)
+
+		// Ключ остаётся в очереди, а следующая пачка не запрашивается
+		repo.EXPECT().GetOrphanBlobs(2).Return([]string{"items/1/a", "items/1/b"}, nil)
+
+		w.collect()
+	})
+}
+
+func TestBlobCollector_RunShutdown(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repository.NewMockAttachment(c)
+	repo.EXPECT().GetOrphanBlobs(10).Return([]string{}, nil)
+
+	w := NewBlobCollector(repo, deleteOnlyStore(func(ctx context.Context, key string) error { return nil }),
+		BlobCollectorConfig{Interval: time.Hour, BatchSize: 10, Timeout: time.Second})
+
+	go w.Run()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	assert.NoError(t, w.Shutdown(ctx))
+}
@@ -0,0 +1,150 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/notify"
+	"github.com/IncubusX/go-todo-app/internal/repository"
+	"github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+type ReminderConfig struct {
+	// Interval - как часто проверять, не сработали ли напоминания
+	Interval time.Duration
+	// MaxAttempts - число попыток доставки одного срабатывания, после которого оно отбрасывается
+	MaxAttempts int
+	// BaseDelay и MaxDelay ограничивают паузу перед повторной попыткой, пауза удваивается с каждой неудачей
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	// Timeout - время на одну доставку
+	Timeout time.Duration
+}
+
+// ReminderWorker доставляет сработавшие напоминания через notifiers, выбирая канал по Reminder.Channel.
+// Можно запускать в нескольких экземплярах приложения: напоминания разбираются через SKIP LOCKED.
+type ReminderWorker struct {
+	repo      repository.Reminder
+	notifiers map[string]notify.Notifier
+	cfg       ReminderConfig
+	now       func() time.Time
+
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func NewReminderWorker(repo repository.Reminder, notifiers map[string]notify.Notifier, cfg ReminderConfig) *ReminderWorker {
+	return &ReminderWorker{
+		repo:      repo,
+		notifiers: notifiers,
+		cfg:       cfg,
+		now:       time.Now,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// Run обрабатывает напоминания до вызова Shutdown.
+func (w *ReminderWorker) Run() {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		w.drain()
+
+		select {
+		case <-w.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Shutdown перестаёт брать новые напоминания и ждёт, пока текущая доставка завершится и её результат будет сохранён.
+// Прерванная на середине доставка не теряется: блокировка снимается, и напоминание будет взято заново.
+func (w *ReminderWorker) Shutdown(ctx context.Context) error {
+	w.stopOnce.Do(func() { close(w.stop) })
+
+	select {
+	case <-w.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// drain разбирает все сработавшие напоминания, пока они не закончатся или не будет вызван Shutdown.
+func (w *ReminderWorker) drain() {
+	for {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+
+		claimed, err := w.repo.ClaimDue(w.now(), w.deliver)
+		if err != nil {
+			logrus.Errorf("Ошибка при выборке напоминаний: %s", err.Error())
+			return
+		}
+		if !claimed {
+			return
+		}
+	}
+}
+
+// deliver не зависит от остановки воркера: начатая доставка доводится до конца в пределах Timeout.
+func (w *ReminderWorker) deliver(reminder entity.DueReminder) entity.DeliveryResult {
+	err := w.notify(reminder)
+	if err == nil {
+		return entity.DeliveryResult{}
+	}
+
+	log := logrus.WithFields(logrus.Fields{
+		"reminder_id": reminder.Id,
+		"channel":     reminder.Channel,
+		"attempt":     reminder.Attempts + 1,
+	})
+	if errors.Is(err, notify.ErrUndeliverable) || reminder.Attempts+1 >= w.cfg.MaxAttempts {
+		log.Errorf("Напоминание не доставлено: %s", err.Error())
+		return entity.DeliveryResult{Err: err}
+	}
+
+	log.Warnf("Ошибка при доставке напоминания, попытка будет повторена: %s", err.Error())
+	retryAt := w.now().Add(w.backoff(reminder.Attempts))
+	return entity.DeliveryResult{Err: err, RetryAt: &retryAt}
+}
+
+func (w *ReminderWorker) notify(reminder entity.DueReminder) error {
+	notifier, ok := w.notifiers[reminder.Channel]
+	if !ok {
+		return fmt.Errorf("%w: unknown channel %q", notify.ErrUndeliverable, reminder.Channel)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), w.cfg.Timeout)
+	defer cancel()
+
+	return notifier.Notify(ctx, notify.Delivery{
+		Notification: reminder.Notification(),
+		Email:        reminder.Email,
+		WebhookURL:   reminder.WebhookURL,
+	})
+}
+
+// backoff удваивает паузу с каждой неудачной попыткой, начиная с BaseDelay и не превышая MaxDelay.
+func (w *ReminderWorker) backoff(attempts int) time.Duration {
+	delay := w.cfg.BaseDelay
+	for i := 0; i < attempts && delay < w.cfg.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > w.cfg.MaxDelay {
+		delay = w.cfg.MaxDelay
+	}
+	return delay
+}
@@ -0,0 +1,155 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/IncubusX/go-todo-app/internal/entity"
+	"github.com/IncubusX/go-todo-app/internal/notify"
+	mock_repository "github.com/IncubusX/go-todo-app/internal/repository/mocks"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+type notifierFunc func(ctx context.Context, delivery notify.Delivery) error
+
+func (f notifierFunc) Notify(ctx context.Context, delivery notify.Delivery) error {
+	return f(ctx, delivery)
+}
+
+var testConfig = ReminderConfig{
+	Interval:    time.Hour,
+	MaxAttempts: 3,
+	BaseDelay:   time.Minute,
+	MaxDelay:    3 * time.Minute,
+	Timeout:     time.Second,
+}
+
+func TestReminderWorker_deliver(t *testing.T) {
+	now := time.Date(2024, 5, 3, 9, 0, 0, 0, time.UTC)
+	errFailed := errors.New("connection refused")
+
+	tt := []struct {
+		name      string
+		reminder  entity.DueReminder
+		notifyErr error
+		wantErr   bool
+		wantRetry time.Duration
+	}{
+		{
+			name:     "Delivered",
+			reminder: entity.DueReminder{Id: 1, Channel: entity.ReminderChannelEmail},
+		},
+		{
+			name:      "First failure",
+			reminder:  entity.DueReminder{Id: 1, Channel: entity.ReminderChannelEmail},
+			notifyErr: errFailed,
+			wantErr:   true,
+			wantRetry: time.Minute,
+		},
+		{
+			name:      "Backoff doubles",
+			reminder:  entity.DueReminder{Id: 1, Channel: entity.ReminderChannelEmail, Attempts: 1},
+			notifyErr: errFailed,
+			wantErr:   true,
+			wantRetry: 2 * time.Minute,
+		},
+		{
+			name:      "Attempts exhausted",
+			reminder:  entity.DueReminder{Id: 1, Channel: entity.ReminderChannelEmail, Attempts: 2},
+			notifyErr: errFailed,
+			wantErr:   true,
+		},
+		{
+			name:      "Undeliverable",
+			reminder:  entity.DueReminder{Id: 1, Channel: entity.ReminderChannelEmail},
+			notifyErr: fmt.Errorf("%w: rejected", notify.ErrUndeliverable),
+			wantErr:   true,
+		},
+		{
+			name:     "Unknown channel",
+			reminder: entity.DueReminder{Id: 1, Channel: "sms"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			var got notify.Delivery
+			w := NewReminderWorker(nil, map[string]notify.Notifier{
+				entity.ReminderChannelEmail: notifierFunc(func(ctx context.Context, delivery notify.Delivery) error {
+					_, hasDeadline := ctx.Deadline()
+					assert.True(t, hasDeadline)
+					got = delivery
+					return tc.notifyErr
+				}),
+			}, testConfig)
+			w.now = func() time.Time { return now }
+
+			result := w.deliver(tc.reminder)
+
+			assert.Equal(t, tc.wantErr, result.Err != nil)
+			if tc.wantRetry != 0 {
+				assert.Equal(t, now.Add(tc.wantRetry), *result.RetryAt)
+			} else {
+				assert.Nil(t, result.RetryAt)
+			}
+			if tc.reminder.Channel == entity.ReminderChannelEmail {
+				assert.Equal(t, tc.reminder.Notification(), got.Notification)
+			}
+		})
+	}
+}
+
+func TestReminderWorker_backoff(t *testing.T) {
+	w := NewReminderWorker(nil, nil, testConfig)
+
+	assert.Equal(t, time.Minute, w.backoff(0))
+	assert.Equal(t, 2*time.Minute, w.backoff(1))
+	assert.Equal(t, 3*time.Minute, w.backoff(2))
+	assert.Equal(t, 3*time.Minute, w.backoff(10))
+}
+
+func TestReminderWorker_RunShutdown(t *testing.T) {
+	c := gomock.NewController(t)
+	defer c.Finish()
+
+	repo := mock_repository.NewMockReminder(c)
+	delivered := make(chan struct{})
+	release := make(chan struct{})
+
+	w := NewReminderWorker(repo, map[string]notify.Notifier{
+		entity.ReminderChannelInbox: notifierFunc(func(ctx context.Context, delivery notify.Delivery) error {
+			close(delivered)
+			<-release
+			return nil
+		}),
+	}, testConfig)
+
+	// Первое напоминание доставляется во время остановки: Shutdown дожидается сохранения результата,
+	// а новые напоминания после этого не берутся
+	repo.EXPECT().ClaimDue(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(now time.Time, deliver func(entity.DueReminder) entity.DeliveryResult) (bool, error) {
+			result := deliver(entity.DueReminder{Id: 1, Channel: entity.ReminderChannelInbox})
+			assert.NoError(t, result.Err)
+			return true, nil
+		})
+
+	go w.Run()
+	<-delivered
+
+	shutdown := make(chan error)
+	go func() { shutdown <- w.Shutdown(context.Background()) }()
+
+	select {
+	case <-shutdown:
+		t.Fatal("Shutdown вернулся до завершения доставки")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	assert.NoError(t, <-shutdown)
+}
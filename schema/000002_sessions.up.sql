@@ -0,0 +1,18 @@
+CREATE TABLE sessions
+(
+    id         serial                                      not null unique,
+    user_id    int references users (id) on delete cascade not null,
+    created_at timestamp with time zone                    not null default now(),
+    expires_at timestamp with time zone                    not null,
+    revoked_at timestamp with time zone
+);
+
+CREATE TABLE refresh_tokens
+(
+    id         serial                                         not null unique,
+    session_id int references sessions (id) on delete cascade not null,
+    token_hash varchar(64)                                    not null unique,
+    created_at timestamp with time zone                       not null default now(),
+    expires_at timestamp with time zone                       not null,
+    used_at    timestamp with time zone
+);
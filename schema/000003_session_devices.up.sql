@@ -0,0 +1,4 @@
+ALTER TABLE sessions
+    ADD COLUMN user_agent   varchar(512)             not null default '',
+    ADD COLUMN ip           varchar(45)              not null default '',
+    ADD COLUMN last_seen_at timestamp with time zone not null default now();
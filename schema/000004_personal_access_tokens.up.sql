@@ -0,0 +1,12 @@
+CREATE TABLE personal_access_tokens
+(
+    id           serial                                      not null unique,
+    user_id      int references users (id) on delete cascade not null,
+    name         varchar(255)                                not null,
+    token_hash   varchar(64)                                 not null unique,
+    scopes       varchar(32)[]                               not null,
+    created_at   timestamp with time zone                    not null default now(),
+    last_used_at timestamp with time zone,
+    expires_at   timestamp with time zone,
+    revoked_at   timestamp with time zone
+);
@@ -0,0 +1,16 @@
+CREATE TABLE two_factor
+(
+    user_id        int references users (id) on delete cascade not null unique,
+    secret         varchar(64)                                 not null,
+    enabled        boolean                                     not null default false,
+    last_used_step bigint                                      not null default 0,
+    created_at     timestamp with time zone                    not null default now()
+);
+
+CREATE TABLE recovery_codes
+(
+    id        serial                                      not null unique,
+    user_id   int references users (id) on delete cascade not null,
+    code_hash varchar(64)                                 not null,
+    used_at   timestamp with time zone
+);
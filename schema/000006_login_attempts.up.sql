@@ -0,0 +1,17 @@
+CREATE TABLE login_attempts
+(
+    key             varchar(320)             not null unique,
+    failures        int                      not null default 0,
+    last_failure_at timestamp with time zone,
+    locked_until    timestamp with time zone
+);
+
+CREATE TABLE audit_log
+(
+    id         serial                   not null unique,
+    event      varchar(64)              not null,
+    subject    varchar(320)             not null,
+    ip         varchar(64)              not null default '',
+    details    text                     not null default '',
+    created_at timestamp with time zone not null default now()
+);
@@ -0,0 +1,10 @@
+CREATE TABLE user_identities
+(
+    id         serial                                      not null unique,
+    user_id    int references users (id) on delete cascade not null,
+    provider   varchar(64)                                 not null,
+    subject    varchar(255)                                not null,
+    email      varchar(320)                                not null default '',
+    created_at timestamp with time zone                    not null default now(),
+    unique (provider, subject)
+);
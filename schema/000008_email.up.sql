@@ -0,0 +1,16 @@
+ALTER TABLE users
+    ADD COLUMN email          varchar(320) not null default '',
+    ADD COLUMN email_verified boolean      not null default false;
+
+CREATE UNIQUE INDEX users_email_key ON users (lower(email)) WHERE email <> '';
+
+CREATE TABLE user_tokens
+(
+    id         serial                                      not null unique,
+    user_id    int references users (id) on delete cascade not null,
+    purpose    varchar(32)                                 not null,
+    token_hash varchar(64)                                 not null unique,
+    email      varchar(320)                                not null,
+    expires_at timestamp with time zone                    not null,
+    used_at    timestamp with time zone
+);
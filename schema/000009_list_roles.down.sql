@@ -0,0 +1,3 @@
+ALTER TABLE user_lists
+    DROP CONSTRAINT user_lists_user_id_list_id_key,
+    DROP COLUMN role;
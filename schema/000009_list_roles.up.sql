@@ -0,0 +1,7 @@
+-- До появления ролей в списке был только его создатель, поэтому существующие связи - владельцы
+ALTER TABLE user_lists
+    ADD COLUMN role varchar(16) not null default 'owner';
+
+ALTER TABLE user_lists
+    ALTER COLUMN role DROP DEFAULT,
+    ADD CONSTRAINT user_lists_user_id_list_id_key unique (user_id, list_id);
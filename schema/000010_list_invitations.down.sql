@@ -0,0 +1,3 @@
+DROP TABLE list_invite_links;
+
+DROP TABLE list_invitations;
@@ -0,0 +1,21 @@
+CREATE TABLE list_invitations
+(
+    id         serial                                           not null unique,
+    list_id    int references todo_lists (id) on delete cascade not null,
+    user_id    int references users (id) on delete cascade      not null,
+    inviter_id int references users (id) on delete cascade      not null,
+    role       varchar(16)                                      not null,
+    created_at timestamp with time zone                         not null default now(),
+    unique (list_id, user_id)
+);
+
+CREATE TABLE list_invite_links
+(
+    id         serial                                           not null unique,
+    list_id    int references todo_lists (id) on delete cascade not null,
+    created_by int references users (id) on delete cascade      not null,
+    role       varchar(16)                                      not null,
+    single_use boolean                                          not null default false,
+    expires_at timestamp with time zone                         not null,
+    used_at    timestamp with time zone
+);
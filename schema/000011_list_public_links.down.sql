@@ -0,0 +1 @@
+DROP TABLE list_public_links;
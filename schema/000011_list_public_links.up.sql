@@ -0,0 +1,10 @@
+CREATE TABLE list_public_links
+(
+    id            serial                                           not null unique,
+    list_id       int references todo_lists (id) on delete cascade not null unique,
+    created_by    int references users (id) on delete cascade      not null,
+    token_hash    varchar(64)                                      not null unique,
+    password_hash varchar(255)                                     not null default '',
+    expires_at    timestamp with time zone,
+    created_at    timestamp with time zone                         not null default now()
+);
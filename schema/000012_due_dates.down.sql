@@ -0,0 +1,9 @@
+ALTER TABLE users
+    DROP COLUMN time_zone;
+
+DROP INDEX todo_items_due_at_idx;
+
+ALTER TABLE todo_items
+    DROP COLUMN all_day,
+    DROP COLUMN due_at,
+    DROP COLUMN start_at;
@@ -0,0 +1,10 @@
+-- Для задач на весь день в due_at/start_at хранится полночь даты по UTC
+ALTER TABLE todo_items
+    ADD COLUMN start_at timestamp with time zone,
+    ADD COLUMN due_at   timestamp with time zone,
+    ADD COLUMN all_day  boolean not null default false;
+
+CREATE INDEX todo_items_due_at_idx ON todo_items (due_at) WHERE due_at IS NOT NULL AND NOT done;
+
+ALTER TABLE users
+    ADD COLUMN time_zone varchar(64) not null default 'UTC';
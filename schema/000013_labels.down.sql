@@ -0,0 +1,6 @@
+DROP TABLE item_labels;
+
+DROP TABLE labels;
+
+ALTER TABLE todo_items
+    DROP COLUMN priority;
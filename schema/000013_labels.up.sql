@@ -0,0 +1,22 @@
+-- Приоритет хранится числом, чтобы по нему можно было сортировать: 0 - нет, 4 - срочно
+ALTER TABLE todo_items
+    ADD COLUMN priority smallint not null default 0;
+
+CREATE TABLE labels
+(
+    id      serial                                      not null unique,
+    user_id int references users (id) on delete cascade not null,
+    name    varchar(64)                                 not null,
+    color   varchar(7)                                  not null default '#808080'
+);
+
+CREATE UNIQUE INDEX labels_user_id_name_key ON labels (user_id, lower(name));
+
+CREATE TABLE item_labels
+(
+    item_id  int references todo_items (id) on delete cascade not null,
+    label_id int references labels (id) on delete cascade     not null,
+    primary key (item_id, label_id)
+);
+
+CREATE INDEX item_labels_label_id_idx ON item_labels (label_id);
@@ -0,0 +1,2 @@
+ALTER TABLE todo_items
+    DROP COLUMN parent_id;
@@ -0,0 +1,6 @@
+-- Подзадача живёт в том же списке, что и родитель. При удалении родителя подзадачи удаляются вместе с ним,
+-- если только сервис заранее не перенёс их на уровень выше
+ALTER TABLE todo_items
+    ADD COLUMN parent_id int references todo_items (id) on delete cascade;
+
+CREATE INDEX todo_items_parent_id_idx ON todo_items (parent_id) WHERE parent_id IS NOT NULL;
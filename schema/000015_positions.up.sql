@@ -0,0 +1,21 @@
+-- Позиции выдаются с шагом 65536, чтобы перемещение обычно меняло одну строку.
+-- Порядок списков свой у каждого участника, порядок задач - среди подзадач одного родителя
+ALTER TABLE user_lists
+    ADD COLUMN position bigint not null default 0;
+
+ALTER TABLE todo_items
+    ADD COLUMN position bigint not null default 0;
+
+UPDATE user_lists AS ul
+SET position = ranked.rn * 65536
+FROM (SELECT id, row_number() OVER (PARTITION BY user_id ORDER BY list_id) AS rn FROM user_lists) AS ranked
+WHERE ranked.id = ul.id;
+
+UPDATE todo_items AS ti
+SET position = ranked.rn * 65536
+FROM (SELECT ti.id, row_number() OVER (PARTITION BY li.list_id, ti.parent_id ORDER BY ti.id) AS rn
+      FROM todo_items AS ti
+               INNER JOIN list_items AS li ON li.item_id = ti.id) AS ranked
+WHERE ranked.id = ti.id;
+
+CREATE INDEX user_lists_user_id_position_idx ON user_lists (user_id, position);
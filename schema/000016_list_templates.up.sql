@@ -0,0 +1,23 @@
+CREATE TABLE list_templates
+(
+    id          serial                                      not null unique,
+    user_id     int references users (id) on delete cascade not null,
+    title       varchar(255)                                not null,
+    description varchar(255)                                not null default '',
+    created_at  timestamp with time zone                    not null default now()
+);
+
+CREATE INDEX list_templates_user_id_idx ON list_templates (user_id);
+
+CREATE TABLE template_items
+(
+    id          serial                                               not null unique,
+    template_id int references list_templates (id) on delete cascade not null,
+    parent_id   int references template_items (id) on delete cascade,
+    title       varchar(255)                                         not null,
+    description varchar(255)                                         not null default '',
+    priority    smallint                                             not null default 0,
+    position    bigint                                               not null default 0
+);
+
+CREATE INDEX template_items_template_id_idx ON template_items (template_id);
@@ -0,0 +1,3 @@
+-- Правило повторения RFC 5545 (RRULE) для серии, начатой с текущего срока задачи
+ALTER TABLE todo_items
+    ADD COLUMN rrule varchar(512) not null default '';
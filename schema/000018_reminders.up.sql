@@ -0,0 +1,40 @@
+-- Входящие уведомления пользователя. dedup_key не даёт повторно записать одно и то же событие,
+-- например, если напоминание доставлено, а отметка об этом не успела сохраниться
+CREATE TABLE notifications
+(
+    id         serial                                      not null unique,
+    user_id    int references users (id) on delete cascade not null,
+    type       varchar(32)                                 not null,
+    title      varchar(255)                                not null,
+    body       text                                        not null default '',
+    list_id    int references todo_lists (id) on delete set null,
+    item_id    int references todo_items (id) on delete set null,
+    dedup_key  varchar(255),
+    created_at timestamp with time zone                    not null default now(),
+    read_at    timestamp with time zone
+);
+
+CREATE UNIQUE INDEX notifications_dedup_key_idx ON notifications (dedup_key);
+CREATE INDEX notifications_user_id_idx ON notifications (user_id, id DESC);
+
+-- Напоминание срабатывает за offset_minutes до срока задачи или в момент remind_at.
+-- sent_for - момент срабатывания, для которого напоминание уже доставлено: при переносе срока
+-- (в том числе у повторяющейся задачи) момент меняется, и напоминание срабатывает снова
+CREATE TABLE reminders
+(
+    id             serial                                           not null unique,
+    item_id        int references todo_items (id) on delete cascade not null,
+    user_id        int references users (id) on delete cascade      not null,
+    offset_minutes int,
+    remind_at      timestamp with time zone,
+    channel        varchar(16)                                      not null default 'inbox',
+    webhook_url    varchar(2048)                                    not null default '',
+    sent_for       timestamp with time zone,
+    attempts       int                                              not null default 0,
+    retry_at       timestamp with time zone,
+    last_error     text                                             not null default '',
+    created_at     timestamp with time zone                         not null default now(),
+    CHECK ((offset_minutes IS NULL) <> (remind_at IS NULL))
+);
+
+CREATE INDEX reminders_item_id_idx ON reminders (item_id);
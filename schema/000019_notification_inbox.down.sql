@@ -0,0 +1,6 @@
+DROP TABLE notification_mutes;
+
+DROP INDEX notifications_unread_idx;
+
+ALTER TABLE notifications
+    DROP COLUMN actor_id;
@@ -0,0 +1,12 @@
+ALTER TABLE notifications
+    ADD COLUMN actor_id int references users (id) on delete set null;
+
+CREATE INDEX notifications_unread_idx ON notifications (user_id) WHERE read_at IS NULL;
+
+-- Типы уведомлений, которые пользователь не хочет получать во входящие
+CREATE TABLE notification_mutes
+(
+    user_id int references users (id) on delete cascade not null,
+    type    varchar(32)                                 not null,
+    primary key (user_id, type)
+);
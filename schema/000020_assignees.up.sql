@@ -0,0 +1,5 @@
+-- Исполнитель задачи, участник списка. При удалении из списка назначение снимается
+ALTER TABLE todo_items
+    ADD COLUMN assignee_id int references users (id) on delete set null;
+
+CREATE INDEX todo_items_assignee_idx ON todo_items (assignee_id) WHERE assignee_id IS NOT NULL;
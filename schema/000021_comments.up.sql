@@ -0,0 +1,17 @@
+-- Комментарии к задачам. parent_id - комментарий, на который дан ответ.
+-- Комментарий с ответами при удалении помечается deleted_at и теряет текст, чтобы не разрывать ветку.
+-- При удалении пользователя его комментарии остаются без автора (user_id = null) вместе с ответами на них
+CREATE TABLE comments
+(
+    id         serial                                           not null unique,
+    item_id    int references todo_items (id) on delete cascade not null,
+    user_id    int references users (id) on delete set null,
+    parent_id  int references comments (id) on delete cascade,
+    body       text                                             not null,
+    created_at timestamp with time zone                         not null default now(),
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX comments_item_id_idx ON comments (item_id, id);
+CREATE INDEX comments_parent_id_idx ON comments (parent_id);
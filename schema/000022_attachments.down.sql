@@ -0,0 +1,7 @@
+DROP TRIGGER attachments_collect_blob ON attachments;
+
+DROP FUNCTION collect_attachment_blob;
+
+DROP TABLE orphan_blobs;
+
+DROP TABLE attachments;
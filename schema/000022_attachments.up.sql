@@ -0,0 +1,37 @@
+-- Вложения задач. Содержимое лежит в хранилище файлов под ключом blob_key, etag - SHA-256 содержимого
+CREATE TABLE attachments
+(
+    id           serial                                           not null unique,
+    item_id      int references todo_items (id) on delete cascade not null,
+    user_id      int references users (id) on delete set null,
+    file_name    varchar(255)                                     not null,
+    content_type varchar(255)                                     not null,
+    size         bigint                                           not null,
+    blob_key     varchar(255)                                     not null unique,
+    etag         varchar(64)                                      not null,
+    created_at   timestamp with time zone                         not null default now()
+);
+
+CREATE INDEX attachments_item_id_idx ON attachments (item_id);
+
+-- Ключи файлов, которые нужно удалить из хранилища. Вложения удаляются и каскадом вместе с задачами,
+-- списками и пользователями, поэтому ключи собирает триггер, а удаляет фоновый сборщик
+CREATE TABLE orphan_blobs
+(
+    blob_key   varchar(255)             not null primary key,
+    created_at timestamp with time zone not null default now()
+);
+
+CREATE FUNCTION collect_attachment_blob() RETURNS trigger AS
+$$
+BEGIN
+    INSERT INTO orphan_blobs (blob_key) VALUES (OLD.blob_key) ON CONFLICT DO NOTHING;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER attachments_collect_blob
+    AFTER DELETE
+    ON attachments
+    FOR EACH ROW
+EXECUTE FUNCTION collect_attachment_blob();